@@ -15,10 +15,17 @@ type ZomboidBackupPlanSpec struct {
 	// +kubebuilder:validation:Required
 	Destination corev1.LocalObjectReference `json:"destination"`
 
-	// Schedule specifies when backups should occur in cron format
+	// Schedule specifies when backups should occur in cron format, or as one
+	// of the macros like @daily
 	// +kubebuilder:validation:Required
-	// +kubebuilder:validation:Pattern=`^(\d+|\*)(/\d+)?(\s+(\d+|\*)(/\d+)?){4}$`
+	// +kubebuilder:validation:MinLength=1
 	Schedule string `json:"schedule"`
+
+	// TimeZone is the IANA time zone the schedule is in, like
+	// Europe/London.  Defaults to the time zone of the cluster's
+	// kube-controller-manager, usually UTC.
+	// +optional
+	TimeZone *string `json:"timeZone,omitempty"`
 }
 
 // ZomboidBackupPlanStatus defines the observed state of ZomboidBackupPlan.
@@ -0,0 +1,167 @@
+package v1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ZomboidCommandSpec defines a command to run once on a ZomboidServer.
+// Exactly one of Command, Kick, Teleport, AddItem or ServerMessage must be set.
+// +kubebuilder:validation:XValidation:rule="(has(self.command) ? 1 : 0) + (has(self.kick) ? 1 : 0) + (has(self.teleport) ? 1 : 0) + (has(self.additem) ? 1 : 0) + (has(self.servermsg) ? 1 : 0) == 1",message="exactly one of command, kick, teleport, additem or servermsg must be set"
+// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="spec is immutable"
+type ZomboidCommandSpec struct {
+	// Server references the ZomboidServer to run the command on
+	// +kubebuilder:validation:Required
+	Server corev1.LocalObjectReference `json:"server"`
+
+	// Command is a raw console command, as it would be typed into the
+	// server's console without the leading slash
+	// +kubebuilder:validation:MinLength=1
+	// +optional
+	Command string `json:"command,omitempty"`
+
+	// Kick disconnects a player from the server
+	// +optional
+	Kick *KickAction `json:"kick,omitempty"`
+
+	// Teleport moves a player to another player
+	// +optional
+	Teleport *TeleportAction `json:"teleport,omitempty"`
+
+	// AddItem gives an item to a player
+	// +optional
+	AddItem *AddItemAction `json:"additem,omitempty"`
+
+	// ServerMessage broadcasts a message to every connected player
+	// +optional
+	ServerMessage *ServerMessageAction `json:"servermsg,omitempty"`
+}
+
+// KickAction disconnects a player from the server
+type KickAction struct {
+	// Username is the player to kick
+	// +kubebuilder:validation:Pattern=`^[^"]+$`
+	Username string `json:"username"`
+
+	// Reason is shown to the player when they are kicked
+	// +kubebuilder:validation:Pattern=`^[^"]*$`
+	// +optional
+	Reason string `json:"reason,omitempty"`
+}
+
+// TeleportAction moves a player to another player
+type TeleportAction struct {
+	// Username is the player to move
+	// +kubebuilder:validation:Pattern=`^[^"]+$`
+	Username string `json:"username"`
+
+	// ToUsername is the player to move them to
+	// +kubebuilder:validation:Pattern=`^[^"]+$`
+	ToUsername string `json:"toUsername"`
+}
+
+// AddItemAction gives an item to a player
+type AddItemAction struct {
+	// Username is the player to give the item to
+	// +kubebuilder:validation:Pattern=`^[^"]+$`
+	Username string `json:"username"`
+
+	// Item is the full item type, like Base.Axe
+	// +kubebuilder:validation:Pattern=`^[A-Za-z0-9_]+\.[A-Za-z0-9_]+$`
+	Item string `json:"item"`
+
+	// Count is how many of the item to give
+	// +kubebuilder:default=1
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	Count int32 `json:"count,omitempty"`
+}
+
+// ServerMessageAction broadcasts a message to every connected player
+type ServerMessageAction struct {
+	// Message is the text to broadcast
+	// +kubebuilder:validation:Pattern=`^[^"]+$`
+	Message string `json:"message"`
+}
+
+// ZomboidCommandPhase describes where a ZomboidCommand is in its lifecycle
+// +kubebuilder:validation:Enum=Pending;Running;Succeeded;Failed
+type ZomboidCommandPhase string
+
+const (
+	// ZomboidCommandPending means the command is waiting for its server to be ready
+	ZomboidCommandPending ZomboidCommandPhase = "Pending"
+	// ZomboidCommandRunning means the command has been sent to the server
+	ZomboidCommandRunning ZomboidCommandPhase = "Running"
+	// ZomboidCommandSucceeded means the server accepted the command
+	ZomboidCommandSucceeded ZomboidCommandPhase = "Succeeded"
+	// ZomboidCommandFailed means the command could not be run, and won't be retried
+	ZomboidCommandFailed ZomboidCommandPhase = "Failed"
+)
+
+// RequestedByAnnotation may be set on a ZomboidCommand to record who asked
+// for it.  The zomboidcommand-actions admission policy only admits it when it
+// names the user creating the command, so tooling acting on a user's behalf
+// has to impersonate them.
+const RequestedByAnnotation = "zomboid.host/requested-by"
+
+// ZomboidCommandStatus defines the observed state of ZomboidCommand.
+type ZomboidCommandStatus struct {
+	// Phase is where the command is in its lifecycle
+	// +optional
+	Phase ZomboidCommandPhase `json:"phase,omitempty"`
+
+	// Command is the console command that was sent to the server
+	// +optional
+	Command string `json:"command,omitempty"`
+
+	// Response is the server's response to the command
+	// +optional
+	Response string `json:"response,omitempty"`
+
+	// Message explains why the command is pending or failed
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// RequestedBy identifies who created the command, taken from the
+	// zomboid.host/requested-by annotation if set, or otherwise the field
+	// manager that created the resource as "fieldManager:<manager>".  A field
+	// manager is the client that wrote the resource, not who it ran as.
+	// +optional
+	RequestedBy string `json:"requestedBy,omitempty"`
+
+	// ExecutedAt is when the command was sent to the server
+	// +optional
+	ExecutedAt *metav1.Time `json:"executedAt,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Server",type=string,JSONPath=`.spec.server.name`
+// +kubebuilder:printcolumn:name="Command",type=string,JSONPath=`.status.command`
+// +kubebuilder:printcolumn:name="Phase",type=string,JSONPath=`.status.phase`
+// +kubebuilder:printcolumn:name="Requested By",type=string,JSONPath=`.status.requestedBy`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// ZomboidCommand is the Schema for the zomboidcommands API.  Each
+// ZomboidCommand runs once, and is kept afterwards as a record of what was run.
+type ZomboidCommand struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ZomboidCommandSpec   `json:"spec,omitempty"`
+	Status ZomboidCommandStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ZomboidCommandList contains a list of ZomboidCommand.
+type ZomboidCommandList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ZomboidCommand `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ZomboidCommand{}, &ZomboidCommandList{})
+}
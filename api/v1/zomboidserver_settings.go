@@ -7,6 +7,13 @@ import "k8s.io/utils/ptr"
 //
 //   - https://pzwiki.net/wiki/Server_settings
 //   - https://wiki.indifferentbroccoli.com/ProjectZomboid/AllServerSettings
+//
+// Every setting is tagged with its name in server.ini, as in
+// `zomboid:"PingLimit"`, which is all the operator needs to read, change and
+// compare it.  Settings the server only reads when it starts are also tagged
+// restart, as in `zomboid:"Mods,restart"`, and only take effect once the
+// operator restarts the server as its restartPolicy allows.  Each setting's
+// default is the value in DefaultZomboidSettings.
 type ZomboidSettings struct {
 	// Identity contains settings about how the server is identified and accessed
 	// +optional
@@ -20,6 +27,13 @@ type ZomboidSettings struct {
 	// +optional
 	Map Map `json:"map,omitempty"`
 
+	// Maps lists the map folders the server loads, such as those of map mods,
+	// in a structured format.  They're merged into the Map setting after any
+	// maps it lists, in order and without duplicates, with the vanilla map
+	// Muldraugh, KY always loaded last, as the maps built on it need.
+	// +optional
+	Maps []string `json:"maps,omitempty"`
+
 	// Mods contains mod configuration settings using the classic format of parallel mod/workshop lists.
 	// This is the traditional way to specify mods in the server.ini but is less structured. Consider using WorkshopMods instead.
 	// +optional
@@ -73,12 +87,21 @@ type ZomboidSettings struct {
 	// AntiCheat configures the anti-cheat protection system
 	// +optional
 	AntiCheat AntiCheat `json:"antiCheat,omitempty"`
+
+	// Extra sets server.ini settings the operator doesn't model yet, by their
+	// name in server.ini, such as those added by a newer build of the game.
+	// Values are applied exactly as written and have no defaults, so settings
+	// left out of Extra are never changed.  In status, Extra holds every
+	// setting the server reported that the operator doesn't model.
+	// +optional
+	Extra map[string]string `json:"extra,omitempty"`
 }
 
 var DefaultZomboidSettings = ZomboidSettings{
 	Identity:      DefaultIdentity,
 	Player:        DefaultPlayer,
 	Map:           DefaultMap,
+	Maps:          []string{},
 	Mods:          DefaultMods,
 	WorkshopMods:  []WorkshopMod{},
 	Backup:        DefaultBackup,
@@ -100,28 +123,28 @@ type Identity struct {
 	// Public determines if server is visible in in-game browser. Note: Steam-enabled servers are always visible in Steam browser.
 	// +kubebuilder:default=false
 	// +optional
-	Public *bool `json:"Public,omitempty"`
+	Public *bool `json:"Public,omitempty" zomboid:"Public,restart"`
 
 	// PublicName is the server name shown in browsers
 	// +kubebuilder:default="My PZ Server"
 	// +optional
-	PublicName *string `json:"PublicName,omitempty"`
+	PublicName *string `json:"PublicName,omitempty" zomboid:"PublicName,restart"`
 
 	// PublicDescription is the server description shown in browsers. Use \n for newlines.
 	// +optional
-	PublicDescription *string `json:"PublicDescription,omitempty"`
+	PublicDescription *string `json:"PublicDescription,omitempty" zomboid:"PublicDescription,restart"`
 
 	// ResetID determines if server has undergone soft-reset. If this number doesn't match client, client must create new character.
 	// +kubebuilder:validation:Minimum=0
 	// +kubebuilder:validation:Maximum=2147483647
 	// +optional
-	ResetID *int32 `json:"ResetID,omitempty"`
+	ResetID *int32 `json:"ResetID,omitempty" zomboid:"ResetID,restart"`
 
 	// ServerPlayerID identifies characters from different servers. Used with ResetID.
 	// +kubebuilder:validation:Minimum=0
 	// +kubebuilder:validation:Maximum=2147483647
 	// +optional
-	ServerPlayerID *int32 `json:"ServerPlayerID,omitempty"`
+	ServerPlayerID *int32 `json:"ServerPlayerID,omitempty" zomboid:"ServerPlayerID,restart"`
 }
 
 var DefaultIdentity = Identity{
@@ -137,63 +160,63 @@ type Player struct {
 	// +kubebuilder:validation:Maximum=100
 	// +kubebuilder:default=32
 	// +optional
-	MaxPlayers *int32 `json:"MaxPlayers,omitempty"`
+	MaxPlayers *int32 `json:"MaxPlayers,omitempty" zomboid:"MaxPlayers,restart"`
 
 	// PingLimit is max ping in ms before kick. Set to 100 to disable.
 	// +kubebuilder:validation:Minimum=100
 	// +kubebuilder:validation:Maximum=2147483647
 	// +kubebuilder:default=400
 	// +optional
-	PingLimit *int32 `json:"PingLimit,omitempty"`
+	PingLimit *int32 `json:"PingLimit,omitempty" zomboid:"PingLimit"`
 
 	// Open allows joining without whitelist account. If false, admins must manually create accounts.
 	// +kubebuilder:default=true
 	// +optional
-	Open *bool `json:"Open,omitempty"`
+	Open *bool `json:"Open,omitempty" zomboid:"Open"`
 
 	// AutoCreateUserInWhiteList adds unknown users to whitelist. Only for Open=true servers.
 	// +kubebuilder:default=false
 	// +optional
-	AutoCreateUserInWhiteList *bool `json:"AutoCreateUserInWhiteList,omitempty"`
+	AutoCreateUserInWhiteList *bool `json:"AutoCreateUserInWhiteList,omitempty" zomboid:"AutoCreateUserInWhiteList"`
 
 	// DropOffWhiteListAfterDeath removes accounts after death. Prevents new characters after death on Open=false servers.
 	// +kubebuilder:default=false
 	// +optional
-	DropOffWhiteListAfterDeath *bool `json:"DropOffWhiteListAfterDeath,omitempty"`
+	DropOffWhiteListAfterDeath *bool `json:"DropOffWhiteListAfterDeath,omitempty" zomboid:"DropOffWhiteListAfterDeath"`
 
 	// MaxAccountsPerUser limits accounts per Steam user. Ignored when using Host button.
 	// +kubebuilder:validation:Minimum=0
 	// +kubebuilder:validation:Maximum=2147483647
 	// +kubebuilder:default=0
 	// +optional
-	MaxAccountsPerUser *int32 `json:"MaxAccountsPerUser,omitempty"`
+	MaxAccountsPerUser *int32 `json:"MaxAccountsPerUser,omitempty" zomboid:"MaxAccountsPerUser"`
 
 	// AllowCoop enables splitscreen/co-op play
 	// +kubebuilder:default=true
 	// +optional
-	AllowCoop *bool `json:"AllowCoop,omitempty"`
+	AllowCoop *bool `json:"AllowCoop,omitempty" zomboid:"AllowCoop"`
 
 	// AllowNonAsciiUsername enables non-ASCII characters in usernames
 	// +kubebuilder:default=false
 	// +optional
-	AllowNonAsciiUsername *bool `json:"AllowNonAsciiUsername,omitempty"`
+	AllowNonAsciiUsername *bool `json:"AllowNonAsciiUsername,omitempty" zomboid:"AllowNonAsciiUsername"`
 
 	// DenyLoginOnOverloadedServer prevents logins when server is overloaded
 	// +kubebuilder:default=true
 	// +optional
-	DenyLoginOnOverloadedServer *bool `json:"DenyLoginOnOverloadedServer,omitempty"`
+	DenyLoginOnOverloadedServer *bool `json:"DenyLoginOnOverloadedServer,omitempty" zomboid:"DenyLoginOnOverloadedServer"`
 
 	// LoginQueueEnabled enables login queue
 	// +kubebuilder:default=false
 	// +optional
-	LoginQueueEnabled *bool `json:"LoginQueueEnabled,omitempty"`
+	LoginQueueEnabled *bool `json:"LoginQueueEnabled,omitempty" zomboid:"LoginQueueEnabled"`
 
 	// LoginQueueConnectTimeout is timeout for login queue in seconds
 	// +kubebuilder:validation:Minimum=20
 	// +kubebuilder:validation:Maximum=1200
 	// +kubebuilder:default=60
 	// +optional
-	LoginQueueConnectTimeout *int32 `json:"LoginQueueConnectTimeout,omitempty"`
+	LoginQueueConnectTimeout *int32 `json:"LoginQueueConnectTimeout,omitempty" zomboid:"LoginQueueConnectTimeout"`
 }
 
 var DefaultPlayer = Player{
@@ -216,7 +239,7 @@ type Map struct {
 	// Map is the folder name of the map mod. Found in Steam/steamapps/workshop/modID/mods/modName/media/maps/
 	// +kubebuilder:default="Muldraugh, KY"
 	// +optional
-	Map *string `json:"Map,omitempty"`
+	Map *string `json:"Map,omitempty" zomboid:"Map,restart"`
 }
 
 var DefaultMap = Map{
@@ -228,11 +251,11 @@ var DefaultMap = Map{
 type Mods struct {
 	// WorkshopItems lists Workshop Mod IDs to download. Separate with semicolons.
 	// +optional
-	WorkshopItems *string `json:"WorkshopItems,omitempty"`
+	WorkshopItems *string `json:"WorkshopItems,omitempty" zomboid:"WorkshopItems,restart"`
 
 	// Mods lists mod loading IDs. Found in Steam/steamapps/workshop/modID/mods/modName/info.txt
 	// +optional
-	Mods *string `json:"Mods,omitempty"`
+	Mods *string `json:"Mods,omitempty" zomboid:"Mods,restart"`
 }
 
 var DefaultMods = Mods{
@@ -243,8 +266,10 @@ var DefaultMods = Mods{
 // WorkshopMod pairs a mod's loading ID with its Steam Workshop ID,
 // providing a more structured way to specify mods compared to the classic parallel lists
 type WorkshopMod struct {
-	// ModID is the mod loading ID found in Steam/steamapps/workshop/modID/mods/modName/info.txt
-	// +kubebuilder:validation:Required
+	// ModID is the mod loading ID found in Steam/steamapps/workshop/modID/mods/modName/info.txt.
+	// When it's unset, the server loads every mod in the workshop item once
+	// the operator has read them from the downloaded item, as listed in
+	// status.workshopMods.
 	// +optional
 	ModID *string `json:"modID,omitempty"`
 
@@ -262,31 +287,31 @@ type Backup struct {
 	// +kubebuilder:validation:Maximum=2147483647
 	// +kubebuilder:default=0
 	// +optional
-	SaveWorldEveryMinutes *int32 `json:"SaveWorldEveryMinutes,omitempty"`
+	SaveWorldEveryMinutes *int32 `json:"SaveWorldEveryMinutes,omitempty" zomboid:"SaveWorldEveryMinutes"`
 
 	// BackupsCount is the number of backups to keep
 	// +kubebuilder:validation:Minimum=1
 	// +kubebuilder:validation:Maximum=300
 	// +kubebuilder:default=5
 	// +optional
-	BackupsCount *int32 `json:"BackupsCount,omitempty"`
+	BackupsCount *int32 `json:"BackupsCount,omitempty" zomboid:"BackupsCount"`
 
 	// BackupsOnStart enables backups when server starts
 	// +kubebuilder:default=true
 	// +optional
-	BackupsOnStart *bool `json:"BackupsOnStart,omitempty"`
+	BackupsOnStart *bool `json:"BackupsOnStart,omitempty" zomboid:"BackupsOnStart"`
 
 	// BackupsOnVersionChange enables backups on version changes
 	// +kubebuilder:default=true
 	// +optional
-	BackupsOnVersionChange *bool `json:"BackupsOnVersionChange,omitempty"`
+	BackupsOnVersionChange *bool `json:"BackupsOnVersionChange,omitempty" zomboid:"BackupsOnVersionChange"`
 
 	// BackupsPeriod is the backup interval in minutes
 	// +kubebuilder:validation:Minimum=0
 	// +kubebuilder:validation:Maximum=1500
 	// +kubebuilder:default=0
 	// +optional
-	BackupsPeriod *int32 `json:"BackupsPeriod,omitempty"`
+	BackupsPeriod *int32 `json:"BackupsPeriod,omitempty" zomboid:"BackupsPeriod"`
 }
 
 var DefaultBackup = Backup{
@@ -302,17 +327,17 @@ type Logging struct {
 	// PerkLogs enables tracking player perk changes in PerkLog.txt
 	// +kubebuilder:default=true
 	// +optional
-	PerkLogs *bool `json:"PerkLogs,omitempty"`
+	PerkLogs *bool `json:"PerkLogs,omitempty" zomboid:"PerkLogs"`
 
 	// ClientCommandFilter lists commands not written to cmd.txt log
 	// +kubebuilder:default="-vehicle.*;+vehicle.damageWindow;+vehicle.fixPart;+vehicle.installPart;+vehicle.uninstallPart"
 	// +optional
-	ClientCommandFilter *string `json:"ClientCommandFilter,omitempty"`
+	ClientCommandFilter *string `json:"ClientCommandFilter,omitempty" zomboid:"ClientCommandFilter"`
 
 	// ClientActionLogs lists actions written to ClientActionLogs.txt
 	// +kubebuilder:default="ISEnterVehicle;ISExitVehicle;ISTakeEngineParts;"
 	// +optional
-	ClientActionLogs *string `json:"ClientActionLogs,omitempty"`
+	ClientActionLogs *string `json:"ClientActionLogs,omitempty" zomboid:"ClientActionLogs"`
 }
 
 var DefaultLogging = Logging{
@@ -327,37 +352,37 @@ type Moderation struct {
 	// DisableRadioStaff disables radio for staff
 	// +kubebuilder:default=false
 	// +optional
-	DisableRadioStaff *bool `json:"DisableRadioStaff,omitempty"`
+	DisableRadioStaff *bool `json:"DisableRadioStaff,omitempty" zomboid:"DisableRadioStaff"`
 
 	// DisableRadioAdmin disables radio for admins
 	// +kubebuilder:default=true
 	// +optional
-	DisableRadioAdmin *bool `json:"DisableRadioAdmin,omitempty"`
+	DisableRadioAdmin *bool `json:"DisableRadioAdmin,omitempty" zomboid:"DisableRadioAdmin"`
 
 	// DisableRadioGM disables radio for GMs
 	// +kubebuilder:default=true
 	// +optional
-	DisableRadioGM *bool `json:"DisableRadioGM,omitempty"`
+	DisableRadioGM *bool `json:"DisableRadioGM,omitempty" zomboid:"DisableRadioGM"`
 
 	// DisableRadioOverseer disables radio for overseers
 	// +kubebuilder:default=false
 	// +optional
-	DisableRadioOverseer *bool `json:"DisableRadioOverseer,omitempty"`
+	DisableRadioOverseer *bool `json:"DisableRadioOverseer,omitempty" zomboid:"DisableRadioOverseer"`
 
 	// DisableRadioModerator disables radio for moderators
 	// +kubebuilder:default=false
 	// +optional
-	DisableRadioModerator *bool `json:"DisableRadioModerator,omitempty"`
+	DisableRadioModerator *bool `json:"DisableRadioModerator,omitempty" zomboid:"DisableRadioModerator"`
 
 	// DisableRadioInvisible disables radio for invisible players
 	// +kubebuilder:default=true
 	// +optional
-	DisableRadioInvisible *bool `json:"DisableRadioInvisible,omitempty"`
+	DisableRadioInvisible *bool `json:"DisableRadioInvisible,omitempty" zomboid:"DisableRadioInvisible"`
 
 	// BanKickGlobalSound enables global sound on ban/kick
 	// +kubebuilder:default=true
 	// +optional
-	BanKickGlobalSound *bool `json:"BanKickGlobalSound,omitempty"`
+	BanKickGlobalSound *bool `json:"BanKickGlobalSound,omitempty" zomboid:"BanKickGlobalSound"`
 }
 
 var DefaultModeration = Moderation{
@@ -374,9 +399,10 @@ var DefaultModeration = Moderation{
 // including VAC and player visibility settings
 type Steam struct {
 	// SteamScoreboard controls visibility of Steam names/avatars. Can be "true" (visible to everyone), "false" (visible to no one), or "admin" (visible to only admins)
+	// +kubebuilder:validation:Enum="true";"false";admin
 	// +kubebuilder:default="true"
 	// +optional
-	SteamScoreboard *string `json:"SteamScoreboard,omitempty"`
+	SteamScoreboard *string `json:"SteamScoreboard,omitempty" zomboid:"SteamScoreboard,restart"`
 }
 
 var DefaultSteam = Steam{
@@ -389,41 +415,41 @@ type Communication struct {
 	// GlobalChat enables global chat
 	// +kubebuilder:default=true
 	// +optional
-	GlobalChat *bool `json:"GlobalChat,omitempty"`
+	GlobalChat *bool `json:"GlobalChat,omitempty" zomboid:"GlobalChat"`
 
 	// ChatStreams lists available chat streams
 	// +kubebuilder:default="s,r,a,w,y,sh,f,all"
 	// +optional
-	ChatStreams *string `json:"ChatStreams,omitempty"`
+	ChatStreams *string `json:"ChatStreams,omitempty" zomboid:"ChatStreams"`
 
 	// ServerWelcomeMessage is shown to players on login. Use <LINE> for newlines and <RGB:r,g,b> for colors.
 	// +kubebuilder:default="Welcome to Project Zomboid Multiplayer! <LINE> <LINE> To interact with the Chat panel: press Tab, T, or Enter. <LINE> <LINE> The Tab key will change the target stream of the message. <LINE> <LINE> Global Streams: /all <LINE> Local Streams: /say, /yell <LINE> Special Steams: /whisper, /safehouse, /faction. <LINE> <LINE> Press the Up arrow to cycle through your message history. Click the Gear icon to customize chat. <LINE> <LINE> Happy surviving!"
 	// +optional
-	ServerWelcomeMessage *string `json:"ServerWelcomeMessage,omitempty"`
+	ServerWelcomeMessage *string `json:"ServerWelcomeMessage,omitempty" zomboid:"ServerWelcomeMessage"`
 
 	// VoiceEnable enables VOIP
 	// +kubebuilder:default=true
 	// +optional
-	VoiceEnable *bool `json:"VoiceEnable,omitempty"`
+	VoiceEnable *bool `json:"VoiceEnable,omitempty" zomboid:"VoiceEnable,restart"`
 
 	// VoiceMinDistance is minimum VOIP audible distance
 	// +kubebuilder:validation:Minimum=0.00
 	// +kubebuilder:validation:Maximum=100000.00
 	// +kubebuilder:default=10.00
 	// +optional
-	VoiceMinDistance *float32 `json:"VoiceMinDistance,omitempty"`
+	VoiceMinDistance *float32 `json:"VoiceMinDistance,omitempty" zomboid:"VoiceMinDistance,restart"`
 
 	// VoiceMaxDistance is maximum VOIP audible distance
 	// +kubebuilder:validation:Minimum=0.00
 	// +kubebuilder:validation:Maximum=100000.00
 	// +kubebuilder:default=100.00
 	// +optional
-	VoiceMaxDistance *float32 `json:"VoiceMaxDistance,omitempty"`
+	VoiceMaxDistance *float32 `json:"VoiceMaxDistance,omitempty" zomboid:"VoiceMaxDistance,restart"`
 
 	// Voice3D enables directional VOIP audio
 	// +kubebuilder:default=true
 	// +optional
-	Voice3D *bool `json:"Voice3D,omitempty"`
+	Voice3D *bool `json:"Voice3D,omitempty" zomboid:"Voice3D,restart"`
 }
 
 var DefaultCommunication = Communication{
@@ -442,136 +468,136 @@ type Gameplay struct {
 	// PauseEmpty pauses time when no players online
 	// +kubebuilder:default=true
 	// +optional
-	PauseEmpty *bool `json:"PauseEmpty,omitempty"`
+	PauseEmpty *bool `json:"PauseEmpty,omitempty" zomboid:"PauseEmpty"`
 
 	// DisplayUserName shows player names
 	// +kubebuilder:default=true
 	// +optional
-	DisplayUserName *bool `json:"DisplayUserName,omitempty"`
+	DisplayUserName *bool `json:"DisplayUserName,omitempty" zomboid:"DisplayUserName"`
 
 	// ShowFirstAndLastName shows full player names
 	// +kubebuilder:default=false
 	// +optional
-	ShowFirstAndLastName *bool `json:"ShowFirstAndLastName,omitempty"`
+	ShowFirstAndLastName *bool `json:"ShowFirstAndLastName,omitempty" zomboid:"ShowFirstAndLastName"`
 
 	// SpawnPoint forces spawn location (x,y,z). Find coordinates at map.projectzomboid.com. Ignored when 0,0,0.
 	// +kubebuilder:default="0,0,0"
 	// +optional
-	SpawnPoint *string `json:"SpawnPoint,omitempty"`
+	SpawnPoint *string `json:"SpawnPoint,omitempty" zomboid:"SpawnPoint"`
 
 	// SpawnItems lists items given to new players. Example: Base.Axe,Base.Bag_BigHikingBag
 	// +optional
-	SpawnItems *string `json:"SpawnItems,omitempty"`
+	SpawnItems *string `json:"SpawnItems,omitempty" zomboid:"SpawnItems"`
 
 	// NoFire disables all forms of fire except campfires
 	// +kubebuilder:default=false
 	// +optional
-	NoFire *bool `json:"NoFire,omitempty"`
+	NoFire *bool `json:"NoFire,omitempty" zomboid:"NoFire"`
 
 	// AnnounceDeath broadcasts player deaths
 	// +kubebuilder:default=false
 	// +optional
-	AnnounceDeath *bool `json:"AnnounceDeath,omitempty"`
+	AnnounceDeath *bool `json:"AnnounceDeath,omitempty" zomboid:"AnnounceDeath"`
 
 	// MinutesPerPage is reading time per book page
 	// +kubebuilder:validation:Minimum=0.00
 	// +kubebuilder:validation:Maximum=60.00
 	// +kubebuilder:default=1.00
 	// +optional
-	MinutesPerPage *float32 `json:"MinutesPerPage,omitempty"`
+	MinutesPerPage *float32 `json:"MinutesPerPage,omitempty" zomboid:"MinutesPerPage"`
 
 	// AllowDestructionBySledgehammer enables sledgehammer destruction
 	// +kubebuilder:default=true
 	// +optional
-	AllowDestructionBySledgehammer *bool `json:"AllowDestructionBySledgehammer,omitempty"`
+	AllowDestructionBySledgehammer *bool `json:"AllowDestructionBySledgehammer,omitempty" zomboid:"AllowDestructionBySledgehammer"`
 
 	// SledgehammerOnlyInSafehouse restricts sledgehammer use to safehouses
 	// +kubebuilder:default=false
 	// +optional
-	SledgehammerOnlyInSafehouse *bool `json:"SledgehammerOnlyInSafehouse,omitempty"`
+	SledgehammerOnlyInSafehouse *bool `json:"SledgehammerOnlyInSafehouse,omitempty" zomboid:"SledgehammerOnlyInSafehouse"`
 
 	// SleepAllowed enables sleeping
 	// +kubebuilder:default=false
 	// +optional
-	SleepAllowed *bool `json:"SleepAllowed,omitempty"`
+	SleepAllowed *bool `json:"SleepAllowed,omitempty" zomboid:"SleepAllowed"`
 
 	// SleepNeeded requires sleeping. Ignored if SleepAllowed=false
 	// +kubebuilder:default=false
 	// +optional
-	SleepNeeded *bool `json:"SleepNeeded,omitempty"`
+	SleepNeeded *bool `json:"SleepNeeded,omitempty" zomboid:"SleepNeeded"`
 
 	// KnockedDownAllowed enables knock downs
 	// +kubebuilder:default=true
 	// +optional
-	KnockedDownAllowed *bool `json:"KnockedDownAllowed,omitempty"`
+	KnockedDownAllowed *bool `json:"KnockedDownAllowed,omitempty" zomboid:"KnockedDownAllowed"`
 
 	// SneakModeHideFromOtherPlayers enables sneaking from players
 	// +kubebuilder:default=true
 	// +optional
-	SneakModeHideFromOtherPlayers *bool `json:"SneakModeHideFromOtherPlayers,omitempty"`
+	SneakModeHideFromOtherPlayers *bool `json:"SneakModeHideFromOtherPlayers,omitempty" zomboid:"SneakModeHideFromOtherPlayers"`
 
 	// SpeedLimit caps movement speed
 	// +kubebuilder:validation:Minimum=10.00
 	// +kubebuilder:validation:Maximum=150.00
 	// +kubebuilder:default=70.00
 	// +optional
-	SpeedLimit *float32 `json:"SpeedLimit,omitempty"`
+	SpeedLimit *float32 `json:"SpeedLimit,omitempty" zomboid:"SpeedLimit"`
 
 	// PlayerRespawnWithSelf enables respawning at death location
 	// +kubebuilder:default=false
 	// +optional
-	PlayerRespawnWithSelf *bool `json:"PlayerRespawnWithSelf,omitempty"`
+	PlayerRespawnWithSelf *bool `json:"PlayerRespawnWithSelf,omitempty" zomboid:"PlayerRespawnWithSelf"`
 
 	// PlayerRespawnWithOther enables respawning at other players
 	// +kubebuilder:default=false
 	// +optional
-	PlayerRespawnWithOther *bool `json:"PlayerRespawnWithOther,omitempty"`
+	PlayerRespawnWithOther *bool `json:"PlayerRespawnWithOther,omitempty" zomboid:"PlayerRespawnWithOther"`
 
 	// FastForwardMultiplier affects sleep time passage
 	// +kubebuilder:validation:Minimum=1.00
 	// +kubebuilder:validation:Maximum=100.00
 	// +kubebuilder:default=40.00
 	// +optional
-	FastForwardMultiplier *float32 `json:"FastForwardMultiplier,omitempty"`
+	FastForwardMultiplier *float32 `json:"FastForwardMultiplier,omitempty" zomboid:"FastForwardMultiplier"`
 
 	// Controls display of remote players on the in-game map.1=Hidden 2=Friends 3=Everyone
 	// +kubebuilder:default=1
 	// +optional
-	MapRemotePlayerVisibility *int32 `json:"MapRemotePlayerVisibility,omitempty"`
+	MapRemotePlayerVisibility *int32 `json:"MapRemotePlayerVisibility,omitempty" zomboid:"MapRemotePlayerVisibility"`
 
 	// MouseOverToSeeDisplayName requires mouse hover to see player names
 	// +kubebuilder:default=true
 	// +optional
-	MouseOverToSeeDisplayName *bool `json:"MouseOverToSeeDisplayName,omitempty"`
+	MouseOverToSeeDisplayName *bool `json:"MouseOverToSeeDisplayName,omitempty" zomboid:"MouseOverToSeeDisplayName"`
 
 	// HidePlayersBehindYou prevents seeing players behind the camera
 	// +kubebuilder:default=true
 	// +optional
-	HidePlayersBehindYou *bool `json:"HidePlayersBehindYou,omitempty"`
+	HidePlayersBehindYou *bool `json:"HidePlayersBehindYou,omitempty" zomboid:"HidePlayersBehindYou"`
 
 	// CarEngineAttractionModifier affects how much noise cars make to attract zombies
 	// +kubebuilder:validation:Minimum=0.00
 	// +kubebuilder:validation:Maximum=10.00
 	// +kubebuilder:default=0.5
 	// +optional
-	CarEngineAttractionModifier *float32 `json:"CarEngineAttractionModifier,omitempty"`
+	CarEngineAttractionModifier *float32 `json:"CarEngineAttractionModifier,omitempty" zomboid:"CarEngineAttractionModifier"`
 
 	// PlayerBumpPlayer enables players pushing each other when walking into them
 	// +kubebuilder:default=false
 	// +optional
-	PlayerBumpPlayer *bool `json:"PlayerBumpPlayer,omitempty"`
+	PlayerBumpPlayer *bool `json:"PlayerBumpPlayer,omitempty" zomboid:"PlayerBumpPlayer"`
 
 	// BloodSplatLifespanDays sets how many days blood remains visible
 	// +kubebuilder:validation:Minimum=0
 	// +kubebuilder:validation:Maximum=365
 	// +kubebuilder:default=0
 	// +optional
-	BloodSplatLifespanDays *int32 `json:"BloodSplatLifespanDays,omitempty"`
+	BloodSplatLifespanDays *int32 `json:"BloodSplatLifespanDays,omitempty" zomboid:"BloodSplatLifespanDays"`
 
 	// RemovePlayerCorpsesOnCorpseRemoval removes player corpses when other corpses are cleaned up
 	// +kubebuilder:default=false
 	// +optional
-	RemovePlayerCorpsesOnCorpseRemoval *bool `json:"RemovePlayerCorpsesOnCorpseRemoval,omitempty"`
+	RemovePlayerCorpsesOnCorpseRemoval *bool `json:"RemovePlayerCorpsesOnCorpseRemoval,omitempty" zomboid:"RemovePlayerCorpsesOnCorpseRemoval"`
 }
 
 var DefaultGameplay = Gameplay{
@@ -607,50 +633,50 @@ type PVP struct {
 	// PVP enables player vs player combat
 	// +kubebuilder:default=true
 	// +optional
-	PVP *bool `json:"PVP,omitempty"`
+	PVP *bool `json:"PVP,omitempty" zomboid:"PVP"`
 
 	// SafetySystem enables PVP safety system. When false, players can hurt each other anytime if PVP enabled.
 	// +kubebuilder:default=true
 	// +optional
-	SafetySystem *bool `json:"SafetySystem,omitempty"`
+	SafetySystem *bool `json:"SafetySystem,omitempty" zomboid:"SafetySystem"`
 
 	// ShowSafety shows safety status with skull icon
 	// +kubebuilder:default=true
 	// +optional
-	ShowSafety *bool `json:"ShowSafety,omitempty"`
+	ShowSafety *bool `json:"ShowSafety,omitempty" zomboid:"ShowSafety"`
 
 	// SafetyToggleTimer is delay for toggling safety
 	// +kubebuilder:validation:Minimum=0
 	// +kubebuilder:validation:Maximum=1000
 	// +kubebuilder:default=2
 	// +optional
-	SafetyToggleTimer *int32 `json:"SafetyToggleTimer,omitempty"`
+	SafetyToggleTimer *int32 `json:"SafetyToggleTimer,omitempty" zomboid:"SafetyToggleTimer"`
 
 	// SafetyCooldownTimer is cooldown between safety toggles
 	// +kubebuilder:validation:Minimum=0
 	// +kubebuilder:validation:Maximum=1000
 	// +kubebuilder:default=3
 	// +optional
-	SafetyCooldownTimer *int32 `json:"SafetyCooldownTimer,omitempty"`
+	SafetyCooldownTimer *int32 `json:"SafetyCooldownTimer,omitempty" zomboid:"SafetyCooldownTimer"`
 
 	// PVPMeleeDamageModifier affects melee damage
 	// +kubebuilder:validation:Minimum=0.00
 	// +kubebuilder:validation:Maximum=500.00
 	// +kubebuilder:default=30.00
 	// +optional
-	PVPMeleeDamageModifier *float32 `json:"PVPMeleeDamageModifier,omitempty"`
+	PVPMeleeDamageModifier *float32 `json:"PVPMeleeDamageModifier,omitempty" zomboid:"PVPMeleeDamageModifier"`
 
 	// PVPFirearmDamageModifier affects firearm damage
 	// +kubebuilder:validation:Minimum=0.00
 	// +kubebuilder:validation:Maximum=500.00
 	// +kubebuilder:default=50.00
 	// +optional
-	PVPFirearmDamageModifier *float32 `json:"PVPFirearmDamageModifier,omitempty"`
+	PVPFirearmDamageModifier *float32 `json:"PVPFirearmDamageModifier,omitempty" zomboid:"PVPFirearmDamageModifier"`
 
 	// PVPMeleeWhileHitReaction enables hit reactions
 	// +kubebuilder:default=false
 	// +optional
-	PVPMeleeWhileHitReaction *bool `json:"PVPMeleeWhileHitReaction,omitempty"`
+	PVPMeleeWhileHitReaction *bool `json:"PVPMeleeWhileHitReaction,omitempty" zomboid:"PVPMeleeWhileHitReaction"`
 }
 
 var DefaultPVP = PVP{
@@ -670,56 +696,56 @@ type Safehouse struct {
 	// PlayerSafehouse enables player safehouses
 	// +kubebuilder:default=false
 	// +optional
-	PlayerSafehouse *bool `json:"PlayerSafehouse,omitempty"`
+	PlayerSafehouse *bool `json:"PlayerSafehouse,omitempty" zomboid:"PlayerSafehouse"`
 
 	// AdminSafehouse enables admin safehouses
 	// +kubebuilder:default=false
 	// +optional
-	AdminSafehouse *bool `json:"AdminSafehouse,omitempty"`
+	AdminSafehouse *bool `json:"AdminSafehouse,omitempty" zomboid:"AdminSafehouse"`
 
 	// SafehouseAllowTrepass allows entering others' safehouses
 	// +kubebuilder:default=true
 	// +optional
-	SafehouseAllowTrepass *bool `json:"SafehouseAllowTrepass,omitempty"`
+	SafehouseAllowTrepass *bool `json:"SafehouseAllowTrepass,omitempty" zomboid:"SafehouseAllowTrepass"`
 
 	// SafehouseAllowFire allows fire in safehouses
 	// +kubebuilder:default=true
 	// +optional
-	SafehouseAllowFire *bool `json:"SafehouseAllowFire,omitempty"`
+	SafehouseAllowFire *bool `json:"SafehouseAllowFire,omitempty" zomboid:"SafehouseAllowFire"`
 
 	// SafehouseAllowLoot allows looting in safehouses
 	// +kubebuilder:default=true
 	// +optional
-	SafehouseAllowLoot *bool `json:"SafehouseAllowLoot,omitempty"`
+	SafehouseAllowLoot *bool `json:"SafehouseAllowLoot,omitempty" zomboid:"SafehouseAllowLoot"`
 
 	// SafehouseAllowRespawn allows respawning in safehouses
 	// +kubebuilder:default=false
 	// +optional
-	SafehouseAllowRespawn *bool `json:"SafehouseAllowRespawn,omitempty"`
+	SafehouseAllowRespawn *bool `json:"SafehouseAllowRespawn,omitempty" zomboid:"SafehouseAllowRespawn"`
 
 	// SafehouseDaySurvivedToClaim is days before claiming
 	// +kubebuilder:validation:Minimum=0
 	// +kubebuilder:validation:Maximum=2147483647
 	// +kubebuilder:default=0
 	// +optional
-	SafehouseDaySurvivedToClaim *int32 `json:"SafehouseDaySurvivedToClaim,omitempty"`
+	SafehouseDaySurvivedToClaim *int32 `json:"SafehouseDaySurvivedToClaim,omitempty" zomboid:"SafehouseDaySurvivedToClaim"`
 
 	// SafeHouseRemovalTime is hours before removal when not visited
 	// +kubebuilder:validation:Minimum=0
 	// +kubebuilder:validation:Maximum=2147483647
 	// +kubebuilder:default=144
 	// +optional
-	SafeHouseRemovalTime *int32 `json:"SafeHouseRemovalTime,omitempty"`
+	SafeHouseRemovalTime *int32 `json:"SafeHouseRemovalTime,omitempty" zomboid:"SafeHouseRemovalTime"`
 
 	// SafehouseAllowNonResidential allows non-residential safehouses
 	// +kubebuilder:default=false
 	// +optional
-	SafehouseAllowNonResidential *bool `json:"SafehouseAllowNonResidential,omitempty"`
+	SafehouseAllowNonResidential *bool `json:"SafehouseAllowNonResidential,omitempty" zomboid:"SafehouseAllowNonResidential"`
 
 	// DisableSafehouseWhenPlayerConnected disables when owner online
 	// +kubebuilder:default=false
 	// +optional
-	DisableSafehouseWhenPlayerConnected *bool `json:"DisableSafehouseWhenPlayerConnected,omitempty"`
+	DisableSafehouseWhenPlayerConnected *bool `json:"DisableSafehouseWhenPlayerConnected,omitempty" zomboid:"DisableSafehouseWhenPlayerConnected"`
 }
 
 var DefaultSafehouse = Safehouse{
@@ -741,21 +767,21 @@ type Faction struct {
 	// Faction enables faction system
 	// +kubebuilder:default=true
 	// +optional
-	Faction *bool `json:"Faction,omitempty"`
+	Faction *bool `json:"Faction,omitempty" zomboid:"Faction"`
 
 	// FactionDaySurvivedToCreate is days before creation
 	// +kubebuilder:validation:Minimum=0
 	// +kubebuilder:validation:Maximum=2147483647
 	// +kubebuilder:default=0
 	// +optional
-	FactionDaySurvivedToCreate *int32 `json:"FactionDaySurvivedToCreate,omitempty"`
+	FactionDaySurvivedToCreate *int32 `json:"FactionDaySurvivedToCreate,omitempty" zomboid:"FactionDaySurvivedToCreate"`
 
 	// FactionPlayersRequiredForTag is players needed for tag
 	// +kubebuilder:validation:Minimum=1
 	// +kubebuilder:validation:Maximum=2147483647
 	// +kubebuilder:default=1
 	// +optional
-	FactionPlayersRequiredForTag *int32 `json:"FactionPlayersRequiredForTag,omitempty"`
+	FactionPlayersRequiredForTag *int32 `json:"FactionPlayersRequiredForTag,omitempty" zomboid:"FactionPlayersRequiredForTag"`
 }
 
 var DefaultFaction = Faction{
@@ -772,31 +798,31 @@ type Loot struct {
 	// +kubebuilder:validation:Maximum=2147483647
 	// +kubebuilder:default=0
 	// +optional
-	HoursForLootRespawn *int32 `json:"HoursForLootRespawn,omitempty"`
+	HoursForLootRespawn *int32 `json:"HoursForLootRespawn,omitempty" zomboid:"HoursForLootRespawn"`
 
 	// MaxItemsForLootRespawn is max items per respawn
 	// +kubebuilder:validation:Minimum=1
 	// +kubebuilder:validation:Maximum=2147483647
 	// +kubebuilder:default=4
 	// +optional
-	MaxItemsForLootRespawn *int32 `json:"MaxItemsForLootRespawn,omitempty"`
+	MaxItemsForLootRespawn *int32 `json:"MaxItemsForLootRespawn,omitempty" zomboid:"MaxItemsForLootRespawn"`
 
 	// ConstructionPreventsLootRespawn prevents respawn near construction
 	// +kubebuilder:default=true
 	// +optional
-	ConstructionPreventsLootRespawn *bool `json:"ConstructionPreventsLootRespawn,omitempty"`
+	ConstructionPreventsLootRespawn *bool `json:"ConstructionPreventsLootRespawn,omitempty" zomboid:"ConstructionPreventsLootRespawn"`
 
 	// ItemNumbersLimitPerContainer caps items per container. Includes small items like nails.
 	// +kubebuilder:validation:Minimum=0
 	// +kubebuilder:validation:Maximum=9000
 	// +kubebuilder:default=0
 	// +optional
-	ItemNumbersLimitPerContainer *int32 `json:"ItemNumbersLimitPerContainer,omitempty"`
+	ItemNumbersLimitPerContainer *int32 `json:"ItemNumbersLimitPerContainer,omitempty" zomboid:"ItemNumbersLimitPerContainer"`
 
 	// TrashDeleteAll enables complete trash deletion
 	// +kubebuilder:default=false
 	// +optional
-	TrashDeleteAll *bool `json:"TrashDeleteAll,omitempty"`
+	TrashDeleteAll *bool `json:"TrashDeleteAll,omitempty" zomboid:"TrashDeleteAll"`
 }
 
 var DefaultLoot = Loot{
@@ -812,128 +838,128 @@ type AntiCheat struct {
 	// DoLuaChecksum enables kicking clients with mismatched game files
 	// +kubebuilder:default=true
 	// +optional
-	DoLuaChecksum *bool `json:"DoLuaChecksum,omitempty"`
+	DoLuaChecksum *bool `json:"DoLuaChecksum,omitempty" zomboid:"DoLuaChecksum,restart"`
 
 	// KickFastPlayers enables kicking speed hackers. May be buggy - use with caution.
 	// +kubebuilder:default=false
 	// +optional
-	KickFastPlayers *bool `json:"KickFastPlayers,omitempty"`
+	KickFastPlayers *bool `json:"KickFastPlayers,omitempty" zomboid:"KickFastPlayers"`
 
 	// AntiCheatProtectionType1-24 enable different protections
 	// +kubebuilder:default=true
 	// +optional
-	AntiCheatProtectionType1 *bool `json:"AntiCheatProtectionType1,omitempty"`
+	AntiCheatProtectionType1 *bool `json:"AntiCheatProtectionType1,omitempty" zomboid:"AntiCheatProtectionType1,restart"`
 	// +kubebuilder:default=true
 	// +optional
-	AntiCheatProtectionType2 *bool `json:"AntiCheatProtectionType2,omitempty"`
+	AntiCheatProtectionType2 *bool `json:"AntiCheatProtectionType2,omitempty" zomboid:"AntiCheatProtectionType2,restart"`
 	// +kubebuilder:default=true
 	// +optional
-	AntiCheatProtectionType3 *bool `json:"AntiCheatProtectionType3,omitempty"`
+	AntiCheatProtectionType3 *bool `json:"AntiCheatProtectionType3,omitempty" zomboid:"AntiCheatProtectionType3,restart"`
 	// +kubebuilder:default=true
 	// +optional
-	AntiCheatProtectionType4 *bool `json:"AntiCheatProtectionType4,omitempty"`
+	AntiCheatProtectionType4 *bool `json:"AntiCheatProtectionType4,omitempty" zomboid:"AntiCheatProtectionType4,restart"`
 	// +kubebuilder:default=true
 	// +optional
-	AntiCheatProtectionType5 *bool `json:"AntiCheatProtectionType5,omitempty"`
+	AntiCheatProtectionType5 *bool `json:"AntiCheatProtectionType5,omitempty" zomboid:"AntiCheatProtectionType5,restart"`
 	// +kubebuilder:default=true
 	// +optional
-	AntiCheatProtectionType6 *bool `json:"AntiCheatProtectionType6,omitempty"`
+	AntiCheatProtectionType6 *bool `json:"AntiCheatProtectionType6,omitempty" zomboid:"AntiCheatProtectionType6,restart"`
 	// +kubebuilder:default=true
 	// +optional
-	AntiCheatProtectionType7 *bool `json:"AntiCheatProtectionType7,omitempty"`
+	AntiCheatProtectionType7 *bool `json:"AntiCheatProtectionType7,omitempty" zomboid:"AntiCheatProtectionType7,restart"`
 	// +kubebuilder:default=true
 	// +optional
-	AntiCheatProtectionType8 *bool `json:"AntiCheatProtectionType8,omitempty"`
+	AntiCheatProtectionType8 *bool `json:"AntiCheatProtectionType8,omitempty" zomboid:"AntiCheatProtectionType8,restart"`
 	// +kubebuilder:default=true
 	// +optional
-	AntiCheatProtectionType9 *bool `json:"AntiCheatProtectionType9,omitempty"`
+	AntiCheatProtectionType9 *bool `json:"AntiCheatProtectionType9,omitempty" zomboid:"AntiCheatProtectionType9,restart"`
 	// +kubebuilder:default=true
 	// +optional
-	AntiCheatProtectionType10 *bool `json:"AntiCheatProtectionType10,omitempty"`
+	AntiCheatProtectionType10 *bool `json:"AntiCheatProtectionType10,omitempty" zomboid:"AntiCheatProtectionType10,restart"`
 	// +kubebuilder:default=true
 	// +optional
-	AntiCheatProtectionType11 *bool `json:"AntiCheatProtectionType11,omitempty"`
+	AntiCheatProtectionType11 *bool `json:"AntiCheatProtectionType11,omitempty" zomboid:"AntiCheatProtectionType11,restart"`
 	// +kubebuilder:default=true
 	// +optional
-	AntiCheatProtectionType12 *bool `json:"AntiCheatProtectionType12,omitempty"`
+	AntiCheatProtectionType12 *bool `json:"AntiCheatProtectionType12,omitempty" zomboid:"AntiCheatProtectionType12,restart"`
 	// +kubebuilder:default=true
 	// +optional
-	AntiCheatProtectionType13 *bool `json:"AntiCheatProtectionType13,omitempty"`
+	AntiCheatProtectionType13 *bool `json:"AntiCheatProtectionType13,omitempty" zomboid:"AntiCheatProtectionType13,restart"`
 	// +kubebuilder:default=true
 	// +optional
-	AntiCheatProtectionType14 *bool `json:"AntiCheatProtectionType14,omitempty"`
+	AntiCheatProtectionType14 *bool `json:"AntiCheatProtectionType14,omitempty" zomboid:"AntiCheatProtectionType14,restart"`
 	// +kubebuilder:default=true
 	// +optional
-	AntiCheatProtectionType15 *bool `json:"AntiCheatProtectionType15,omitempty"`
+	AntiCheatProtectionType15 *bool `json:"AntiCheatProtectionType15,omitempty" zomboid:"AntiCheatProtectionType15,restart"`
 	// +kubebuilder:default=true
 	// +optional
-	AntiCheatProtectionType16 *bool `json:"AntiCheatProtectionType16,omitempty"`
+	AntiCheatProtectionType16 *bool `json:"AntiCheatProtectionType16,omitempty" zomboid:"AntiCheatProtectionType16,restart"`
 	// +kubebuilder:default=true
 	// +optional
-	AntiCheatProtectionType17 *bool `json:"AntiCheatProtectionType17,omitempty"`
+	AntiCheatProtectionType17 *bool `json:"AntiCheatProtectionType17,omitempty" zomboid:"AntiCheatProtectionType17,restart"`
 	// +kubebuilder:default=true
 	// +optional
-	AntiCheatProtectionType18 *bool `json:"AntiCheatProtectionType18,omitempty"`
+	AntiCheatProtectionType18 *bool `json:"AntiCheatProtectionType18,omitempty" zomboid:"AntiCheatProtectionType18,restart"`
 	// +kubebuilder:default=true
 	// +optional
-	AntiCheatProtectionType19 *bool `json:"AntiCheatProtectionType19,omitempty"`
+	AntiCheatProtectionType19 *bool `json:"AntiCheatProtectionType19,omitempty" zomboid:"AntiCheatProtectionType19,restart"`
 	// +kubebuilder:default=true
 	// +optional
-	AntiCheatProtectionType20 *bool `json:"AntiCheatProtectionType20,omitempty"`
+	AntiCheatProtectionType20 *bool `json:"AntiCheatProtectionType20,omitempty" zomboid:"AntiCheatProtectionType20,restart"`
 	// +kubebuilder:default=true
 	// +optional
-	AntiCheatProtectionType21 *bool `json:"AntiCheatProtectionType21,omitempty"`
+	AntiCheatProtectionType21 *bool `json:"AntiCheatProtectionType21,omitempty" zomboid:"AntiCheatProtectionType21,restart"`
 	// +kubebuilder:default=true
 	// +optional
-	AntiCheatProtectionType22 *bool `json:"AntiCheatProtectionType22,omitempty"`
+	AntiCheatProtectionType22 *bool `json:"AntiCheatProtectionType22,omitempty" zomboid:"AntiCheatProtectionType22,restart"`
 	// +kubebuilder:default=true
 	// +optional
-	AntiCheatProtectionType23 *bool `json:"AntiCheatProtectionType23,omitempty"`
+	AntiCheatProtectionType23 *bool `json:"AntiCheatProtectionType23,omitempty" zomboid:"AntiCheatProtectionType23,restart"`
 	// +kubebuilder:default=true
 	// +optional
-	AntiCheatProtectionType24 *bool `json:"AntiCheatProtectionType24,omitempty"`
+	AntiCheatProtectionType24 *bool `json:"AntiCheatProtectionType24,omitempty" zomboid:"AntiCheatProtectionType24,restart"`
 
 	// Protection type threshold multipliers
 	// +kubebuilder:validation:Minimum=1.00
 	// +kubebuilder:validation:Maximum=10.00
 	// +kubebuilder:default=3.00
 	// +optional
-	AntiCheatProtectionType2ThresholdMultiplier *float32 `json:"AntiCheatProtectionType2ThresholdMultiplier,omitempty"`
+	AntiCheatProtectionType2ThresholdMultiplier *float32 `json:"AntiCheatProtectionType2ThresholdMultiplier,omitempty" zomboid:"AntiCheatProtectionType2ThresholdMultiplier,restart"`
 	// +kubebuilder:validation:Minimum=1.00
 	// +kubebuilder:validation:Maximum=10.00
 	// +kubebuilder:default=1.00
 	// +optional
-	AntiCheatProtectionType3ThresholdMultiplier *float32 `json:"AntiCheatProtectionType3ThresholdMultiplier,omitempty"`
+	AntiCheatProtectionType3ThresholdMultiplier *float32 `json:"AntiCheatProtectionType3ThresholdMultiplier,omitempty" zomboid:"AntiCheatProtectionType3ThresholdMultiplier,restart"`
 	// +kubebuilder:validation:Minimum=1.00
 	// +kubebuilder:validation:Maximum=10.00
 	// +kubebuilder:default=1.00
 	// +optional
-	AntiCheatProtectionType4ThresholdMultiplier *float32 `json:"AntiCheatProtectionType4ThresholdMultiplier,omitempty"`
+	AntiCheatProtectionType4ThresholdMultiplier *float32 `json:"AntiCheatProtectionType4ThresholdMultiplier,omitempty" zomboid:"AntiCheatProtectionType4ThresholdMultiplier,restart"`
 	// +kubebuilder:validation:Minimum=1.00
 	// +kubebuilder:validation:Maximum=10.00
 	// +kubebuilder:default=1.00
 	// +optional
-	AntiCheatProtectionType9ThresholdMultiplier *float32 `json:"AntiCheatProtectionType9ThresholdMultiplier,omitempty"`
+	AntiCheatProtectionType9ThresholdMultiplier *float32 `json:"AntiCheatProtectionType9ThresholdMultiplier,omitempty" zomboid:"AntiCheatProtectionType9ThresholdMultiplier,restart"`
 	// +kubebuilder:validation:Minimum=1.00
 	// +kubebuilder:validation:Maximum=10.00
 	// +kubebuilder:default=1.00
 	// +optional
-	AntiCheatProtectionType15ThresholdMultiplier *float32 `json:"AntiCheatProtectionType15ThresholdMultiplier,omitempty"`
+	AntiCheatProtectionType15ThresholdMultiplier *float32 `json:"AntiCheatProtectionType15ThresholdMultiplier,omitempty" zomboid:"AntiCheatProtectionType15ThresholdMultiplier,restart"`
 	// +kubebuilder:validation:Minimum=1.00
 	// +kubebuilder:validation:Maximum=10.00
 	// +kubebuilder:default=1.00
 	// +optional
-	AntiCheatProtectionType20ThresholdMultiplier *float32 `json:"AntiCheatProtectionType20ThresholdMultiplier,omitempty"`
+	AntiCheatProtectionType20ThresholdMultiplier *float32 `json:"AntiCheatProtectionType20ThresholdMultiplier,omitempty" zomboid:"AntiCheatProtectionType20ThresholdMultiplier,restart"`
 	// +kubebuilder:validation:Minimum=1.00
 	// +kubebuilder:validation:Maximum=10.00
 	// +kubebuilder:default=1.00
 	// +optional
-	AntiCheatProtectionType22ThresholdMultiplier *float32 `json:"AntiCheatProtectionType22ThresholdMultiplier,omitempty"`
+	AntiCheatProtectionType22ThresholdMultiplier *float32 `json:"AntiCheatProtectionType22ThresholdMultiplier,omitempty" zomboid:"AntiCheatProtectionType22ThresholdMultiplier,restart"`
 	// +kubebuilder:validation:Minimum=1.00
 	// +kubebuilder:validation:Maximum=10.00
 	// +kubebuilder:default=6.00
 	// +optional
-	AntiCheatProtectionType24ThresholdMultiplier *float32 `json:"AntiCheatProtectionType24ThresholdMultiplier,omitempty"`
+	AntiCheatProtectionType24ThresholdMultiplier *float32 `json:"AntiCheatProtectionType24ThresholdMultiplier,omitempty" zomboid:"AntiCheatProtectionType24ThresholdMultiplier,restart"`
 }
 
 var DefaultAntiCheat = AntiCheat{
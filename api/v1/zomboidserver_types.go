@@ -37,6 +37,10 @@ type ZomboidServerSpec struct {
 	// +optional
 	Users []User `json:"users,omitempty"`
 
+	// RCON configures the server's remote console
+	// +optional
+	RCON *RCON `json:"rcon,omitempty"`
+
 	// Password is required for clients to join.
 	// +optional
 	Password *corev1.SecretKeySelector `json:"password,omitempty"`
@@ -49,11 +53,133 @@ type ZomboidServerSpec struct {
 	// +optional
 	Settings ZomboidSettings `json:"settings,omitempty"`
 
+	// SettingsFrom layers settings profiles under spec.settings.  Profiles
+	// are applied in order, each overriding the settings the ones before it
+	// set, and spec.settings overrides them all.  Workshop mods are merged by
+	// mod ID and extra settings by name.
+	// +optional
+	SettingsFrom []SettingsProfileReference `json:"settingsFrom,omitempty"`
+
+	// SettingsPolicy controls what the operator does when the server's
+	// settings drift from spec.settings, such as after an admin's in-game
+	// changeoption.  Enforce changes every setting back to the spec, with
+	// unset settings meaning their defaults.  Adopt only manages the settings
+	// set in the spec, and leaves the rest as they are on the server.
+	// ObserveOnly never changes the server's settings.  Whatever the policy,
+	// the settings that differ from the spec are listed in status.settingsDrift.
+	// +kubebuilder:validation:Enum=Enforce;ObserveOnly;Adopt
+	// +kubebuilder:default=Enforce
+	// +optional
+	SettingsPolicy SettingsPolicy `json:"settingsPolicy,omitempty"`
+
+	// RestartPolicy controls when the operator restarts the server to pick up
+	// settings that only take effect when it starts, such as Map, Mods or
+	// Public.  Immediate restarts as soon as they change, WhenEmpty waits for
+	// the last player to leave, and Manual leaves the restart to an admin.
+	// Until the server restarts they're listed in status.pendingRestart.
+	// +kubebuilder:validation:Enum=Immediate;WhenEmpty;Manual
+	// +kubebuilder:default=Immediate
+	// +optional
+	RestartPolicy RestartPolicy `json:"restartPolicy,omitempty"`
+
+	// RestartWarning is how long connected players are warned before the
+	// operator restarts the server under the Immediate policy.  The world is
+	// saved as the server shuts down either way.
+	// +optional
+	RestartWarning *metav1.Duration `json:"restartWarning,omitempty"`
+
+	// WorkshopUpdates configures checking the Steam Workshop for updates to
+	// the server's workshop items
+	// +optional
+	WorkshopUpdates *WorkshopUpdates `json:"workshopUpdates,omitempty"`
+
+	// WorkshopDependencies controls what the operator does about workshop
+	// items the server's mods require but its settings don't list.  Report
+	// lists them in the WorkshopDependenciesMet condition, and Add also loads
+	// them, with every mod they contain.
+	// +kubebuilder:validation:Enum=Report;Add
+	// +kubebuilder:default=Report
+	// +optional
+	WorkshopDependencies WorkshopDependencyPolicy `json:"workshopDependencies,omitempty"`
+
 	// Discord contains the Discord configuration
 	// +optional
 	Discord *Discord `json:"discord,omitempty"`
+
+	// NetworkPolicy configures generated NetworkPolicies that restrict access
+	// to the server's RCON, ws4sqlite and metrics ports
+	// +optional
+	NetworkPolicy *NetworkPolicy `json:"networkPolicy,omitempty"`
+
+	// SettingsConfigMap configures a generated ConfigMap holding the server's
+	// effective server.ini
+	// +optional
+	SettingsConfigMap *SettingsConfigMap `json:"settingsConfigMap,omitempty"`
+
+	// Spawn configures the spawn regions new characters choose from, which
+	// the operator keeps in the server's spawnregions.lua.  When unset, the
+	// server manages the file itself.
+	// +optional
+	Spawn *Spawn `json:"spawn,omitempty"`
+}
+
+// SettingsProfileReference refers to a ZomboidSettingsProfile in the
+// server's namespace, or to a ClusterZomboidSettingsProfile
+type SettingsProfileReference struct {
+	// Kind is the kind of the profile
+	// +kubebuilder:validation:Enum=ZomboidSettingsProfile;ClusterZomboidSettingsProfile
+	// +kubebuilder:default=ZomboidSettingsProfile
+	// +optional
+	Kind string `json:"kind,omitempty"`
+
+	// Name is the name of the profile
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+}
+
+// SettingsPolicy is what the operator does when a server's settings drift
+// from its spec
+type SettingsPolicy string
+
+const (
+	SettingsPolicyEnforce     SettingsPolicy = "Enforce"
+	SettingsPolicyObserveOnly SettingsPolicy = "ObserveOnly"
+	SettingsPolicyAdopt       SettingsPolicy = "Adopt"
+)
+
+// RestartPolicy is when the operator restarts a server to pick up changes
+// that need one
+type RestartPolicy string
+
+const (
+	RestartPolicyImmediate RestartPolicy = "Immediate"
+	RestartPolicyWhenEmpty RestartPolicy = "WhenEmpty"
+	RestartPolicyManual    RestartPolicy = "Manual"
+)
+
+// WorkshopUpdates controls checking the Steam Workshop for updates to a
+// server's workshop items.  Clients that download an update can't join a
+// server still running the previous version, so the operator restarts the
+// server to download updates as spec.restartPolicy allows.
+type WorkshopUpdates struct {
+	// Enabled checks for updates to the workshop items of the server's mods
+	Enabled bool `json:"enabled"`
+
+	// Interval is how often to check for updates
+	// +kubebuilder:default="15m"
+	// +optional
+	Interval *metav1.Duration `json:"interval,omitempty"`
 }
 
+// WorkshopDependencyPolicy is what the operator does about the workshop
+// items a server's mods require
+type WorkshopDependencyPolicy string
+
+const (
+	WorkshopDependenciesReport WorkshopDependencyPolicy = "Report"
+	WorkshopDependenciesAdd    WorkshopDependencyPolicy = "Add"
+)
+
 // Storage defines the persistent storage configuration for the Zomboid server.
 type Storage struct {
 	// StorageClassName is the name of the storage class to use for the PVC, if
@@ -93,6 +219,16 @@ type Administrator struct {
 	Password corev1.SecretKeySelector `json:"password"`
 }
 
+// RCON configures access to the server's remote console, which the operator
+// and the metrics sidecar use to manage the server.
+type RCON struct {
+	// Password is a reference to a secret key containing the RCON password.
+	// If not set, the operator generates a random password and stores it in
+	// a Secret named <server>-rcon.  Changing the password restarts the server.
+	// +optional
+	Password *corev1.SecretKeySelector `json:"password,omitempty"`
+}
+
 // Discord enables and configures integration with Discord,
 // allowing server chat to be bridged with a Discord channel
 type Discord struct {
@@ -109,6 +245,109 @@ type Discord struct {
 	DiscordChannelID *corev1.SecretKeySelector `json:"DiscordChannelID,omitempty"`
 }
 
+// NetworkPolicy controls the NetworkPolicy generated for a server.  When
+// enabled, the game ports stay reachable from anywhere, while RCON, ws4sqlite
+// and metrics are only reachable from the operator and from Prometheus.
+type NetworkPolicy struct {
+	// Enabled turns on generation of the NetworkPolicy
+	Enabled bool `json:"enabled"`
+
+	// OperatorNamespace is the namespace the operator runs in
+	// +kubebuilder:default=zomboid-system
+	// +optional
+	OperatorNamespace string `json:"operatorNamespace,omitempty"`
+
+	// OperatorPodSelector selects the operator's pods within OperatorNamespace.
+	// NetworkPolicies cannot select by service account, so this should match
+	// the labels of the operator's Deployment.  Defaults to
+	// control-plane=controller-manager.
+	// +optional
+	OperatorPodSelector *metav1.LabelSelector `json:"operatorPodSelector,omitempty"`
+
+	// PrometheusNamespaceSelector selects the namespaces allowed to scrape the
+	// metrics port.  Defaults to namespaces labeled metrics=enabled.
+	// +optional
+	PrometheusNamespaceSelector *metav1.LabelSelector `json:"prometheusNamespaceSelector,omitempty"`
+
+	// PrometheusPodSelector further restricts which pods in the selected
+	// namespaces may scrape the metrics port.  Defaults to all pods.
+	// +optional
+	PrometheusPodSelector *metav1.LabelSelector `json:"prometheusPodSelector,omitempty"`
+}
+
+// SettingsConfigMap controls the ConfigMap generated with a server's
+// effective server.ini, for support requests or to run the same world outside
+// Kubernetes.  The server.ini combines the settings last observed on the
+// server with the changes the operator is making, and leaves out passwords.
+type SettingsConfigMap struct {
+	// Enabled keeps a ConfigMap named <server>-settings with the server.ini in
+	// its <server>.ini key
+	Enabled bool `json:"enabled"`
+}
+
+// Spawn configures the spawn regions of a server.  The server only reads
+// them as it starts, so changes wait for a restart as spec.restartPolicy
+// allows.
+type Spawn struct {
+	// Regions are the spawn regions listed before those of the maps
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	Regions []SpawnRegion `json:"regions,omitempty"`
+
+	// MapRegions adds a spawn region for every map in the Map setting that
+	// none of the regions already cover, with the map's own spawnpoints.lua,
+	// and the vanilla regions for Muldraugh, KY.  Turn it off when a map,
+	// such as an addition to the vanilla map, has no spawn points.
+	// +kubebuilder:default=true
+	// +optional
+	MapRegions *bool `json:"mapRegions,omitempty"`
+}
+
+// SpawnRegion is a spawn region players can choose when creating a
+// character, read either from a spawnpoints.lua file or from explicit points
+type SpawnRegion struct {
+	// Name is the name shown to players
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// File is the spawnpoints.lua of the region, relative to the game or a
+	// mod's directory, such as media/maps/Riverside, KY/spawnpoints.lua
+	// +optional
+	File *string `json:"file,omitempty"`
+
+	// Points are the region's spawn points by profession, such as
+	// unemployed, fireofficer or parkranger.  Professions without their own
+	// points use those of unemployed.
+	// +optional
+	Points map[string][]SpawnPoint `json:"points,omitempty"`
+}
+
+// SpawnPoint is a spawn location, as a cell of the map and a square within it
+type SpawnPoint struct {
+	// WorldX is the cell's X coordinate
+	WorldX int32 `json:"worldX"`
+
+	// WorldY is the cell's Y coordinate
+	WorldY int32 `json:"worldY"`
+
+	// PosX is the square's X coordinate within the cell
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=299
+	PosX int32 `json:"posX"`
+
+	// PosY is the square's Y coordinate within the cell
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=299
+	PosY int32 `json:"posY"`
+
+	// PosZ is the floor, 0 being the ground floor
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=7
+	// +optional
+	PosZ int32 `json:"posZ,omitempty"`
+}
+
 // ZomboidServerStatus defines the observed state of ZomboidServer.
 type ZomboidServerStatus struct {
 	// Ready indicates whether the server is ready to accept players
@@ -122,6 +361,57 @@ type ZomboidServerStatus struct {
 	// +optional
 	Settings *ZomboidSettings `json:"settings,omitempty"`
 
+	// ResolvedSettings are the settings the operator manages the server
+	// with, once the profiles in spec.settingsFrom are layered under
+	// spec.settings.  Only set when the server refers to profiles.
+	// +optional
+	ResolvedSettings *ZomboidSettings `json:"resolvedSettings,omitempty"`
+
+	// SettingsDrift lists the settings whose value on the server differs
+	// from the spec, or from their defaults when unset in the spec.  Under
+	// the Enforce policy it only lists settings the operator failed to change.
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	SettingsDrift []SettingDrift `json:"settingsDrift,omitempty"`
+
+	// PendingRestart lists the settings changed on the running server that
+	// only take effect once it restarts
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	PendingRestart []PendingRestartSetting `json:"pendingRestart,omitempty"`
+
+	// RestartAt is when the operator restarts the server, once the connected
+	// players have been warned as spec.restartWarning asks
+	// +optional
+	RestartAt *metav1.Time `json:"restartAt,omitempty"`
+
+	// WorkshopItems are the installed and available versions of the
+	// server's workshop items, when spec.workshopUpdates is enabled
+	// +optional
+	// +listType=map
+	// +listMapKey=workshopID
+	WorkshopItems []WorkshopItemStatus `json:"workshopItems,omitempty"`
+
+	// WorkshopLastChecked is when the operator last checked the Steam
+	// Workshop for updates
+	// +optional
+	WorkshopLastChecked *metav1.Time `json:"workshopLastChecked,omitempty"`
+
+	// WorkshopMods describes the workshop items of the server's mods, and
+	// the items they require, as published on the Steam Workshop and as
+	// described by the mod.info files of the downloaded items
+	// +optional
+	// +listType=map
+	// +listMapKey=workshopID
+	WorkshopMods []WorkshopModStatus `json:"workshopMods,omitempty"`
+
+	// WorkshopModsResolved is when the operator last resolved
+	// status.workshopMods, or last failed to
+	// +optional
+	WorkshopModsResolved *metav1.Time `json:"workshopModsResolved,omitempty"`
+
 	// Allowlist contains the server's current allowlist
 	// +optional
 	Allowlist []AllowlistUser `json:"allowlist,omitempty"`
@@ -171,6 +461,93 @@ type AllowlistUser struct {
 	LastConnection *string `json:"lastConnection,omitempty"`
 }
 
+// SettingDrift is a setting whose value on the server differs from the spec
+type SettingDrift struct {
+	// Name is the setting's name in server.ini
+	Name string `json:"name"`
+
+	// Desired is the value in the spec, or the default when it's unset
+	Desired string `json:"desired"`
+
+	// Observed is the value on the server
+	Observed string `json:"observed"`
+}
+
+// PendingRestartSetting is a setting changed on a running server that it
+// only reads when it starts
+type PendingRestartSetting struct {
+	// Name is the setting's name in server.ini
+	Name string `json:"name"`
+
+	// Value is the value the setting takes once the server restarts
+	Value string `json:"value"`
+
+	// ChangedAt is when the operator changed the setting
+	ChangedAt metav1.Time `json:"changedAt"`
+}
+
+// WorkshopItemStatus compares the installed version of a workshop item with
+// the one available on the Steam Workshop, each identified by when it was
+// published
+type WorkshopItemStatus struct {
+	// WorkshopID is the item's Steam Workshop ID
+	WorkshopID string `json:"workshopID"`
+
+	// Installed is when the version installed on the server was published
+	// +optional
+	Installed *metav1.Time `json:"installed,omitempty"`
+
+	// Available is when the latest version on the Steam Workshop was
+	// published
+	// +optional
+	Available *metav1.Time `json:"available,omitempty"`
+}
+
+// WorkshopModStatus describes a workshop item a server loads or that its
+// mods require
+type WorkshopModStatus struct {
+	// WorkshopID is the item's Steam Workshop ID
+	WorkshopID string `json:"workshopID"`
+
+	// Title is the item's title on the Steam Workshop
+	// +optional
+	Title string `json:"title,omitempty"`
+
+	// Size is the size of the item's files in bytes
+	// +optional
+	Size int64 `json:"size,omitempty"`
+
+	// Mods are the mods the downloaded item contains.  Workshop mods
+	// without a modID load all of them.
+	// +optional
+	Mods []ResolvedMod `json:"mods,omitempty"`
+
+	// RequiredItems are the workshop IDs of the items the item's page lists
+	// as required, which the operator only learns with a Steam Web API key
+	// +optional
+	RequiredItems []string `json:"requiredItems,omitempty"`
+
+	// RequiredBy lists the workshop IDs of the items requiring this one,
+	// when the server's settings don't list it themselves.  Such items are
+	// only loaded when spec.workshopDependencies is Add.
+	// +optional
+	RequiredBy []string `json:"requiredBy,omitempty"`
+}
+
+// ResolvedMod is a mod as described by the mod.info in a workshop item
+type ResolvedMod struct {
+	// ID is the ID the server loads the mod by
+	ID string `json:"id"`
+
+	// Name is the mod's display name
+	// +optional
+	Name string `json:"name,omitempty"`
+
+	// Requires are the IDs of the mods it needs loaded with it
+	// +optional
+	Requires []string `json:"requires,omitempty"`
+}
+
 type ConnectedPlayer struct {
 	Username string `json:"username"`
 }
@@ -181,6 +558,24 @@ const (
 	TypeReadyForPlayers = "ReadyForPlayers"
 	// TypeInfrastructureReady indicates whether all required infrastructure components exist
 	TypeInfrastructureReady = "InfrastructureReady"
+	// TypeRCONReachable indicates whether the operator can reach the server over RCON
+	TypeRCONReachable = "RCONReachable"
+	// TypeSettingsSynced indicates whether the server's settings match the spec
+	TypeSettingsSynced = "SettingsSynced"
+	// TypeModsReady indicates whether the server has loaded the declared mods
+	TypeModsReady = "ModsReady"
+	// TypeMapsInstalled indicates whether the folders of the maps the server loads are installed
+	TypeMapsInstalled = "MapsInstalled"
+	// TypeWorkshopUpToDate indicates whether the server runs the latest versions of its workshop items
+	TypeWorkshopUpToDate = "WorkshopUpToDate"
+	// TypeWorkshopDependenciesMet indicates whether the server loads the mods its workshop mods require
+	TypeWorkshopDependenciesMet = "WorkshopDependenciesMet"
+	// TypeDatabaseReachable indicates whether the operator can read the server's player database
+	TypeDatabaseReachable = "DatabaseReachable"
+	// TypeUsersSynced indicates whether the server's allowlist matches the declared users
+	TypeUsersSynced = "UsersSynced"
+	// TypeBackupsConfigured indicates whether the server keeps backups, and whether they are copied off the cluster
+	TypeBackupsConfigured = "BackupsConfigured"
 )
 
 // Condition Reasons
@@ -194,10 +589,67 @@ const (
 	ReasonMissingRCONService   = "MissingRCONService"
 	ReasonMissingGameService   = "MissingGameService"
 	ReasonMissingSQLiteService = "MissingSQLiteService"
+	ReasonMissingNetworkPolicy = "MissingNetworkPolicy"
+	ReasonMissingConfigMap     = "MissingConfigMap"
+
+	ReasonMissingSQLiteCredentials = "MissingSQLiteCredentials"
+	ReasonMissingRCONPassword      = "MissingRCONPassword"
+
+	ReasonRCONConnected   = "RCONConnected"
+	ReasonRCONUnreachable = "RCONUnreachable"
+
+	ReasonSettingsInSync         = "SettingsInSync"
+	ReasonSettingsApplied        = "SettingsApplied"
+	ReasonSettingsNotObserved    = "SettingsNotObserved"
+	ReasonSettingsUpdateFailed   = "SettingsUpdateFailed"
+	ReasonSettingsDrifted        = "SettingsDrifted"
+	ReasonSettingsProfileMissing = "SettingsProfileMissing"
+	ReasonSettingsPendingRestart = "SettingsPendingRestart"
+	ReasonRestartFailed          = "RestartFailed"
+
+	ReasonModsLoaded         = "ModsLoaded"
+	ReasonModsRestarting     = "ModsRestarting"
+	ReasonModsRestartFailed  = "ModsRestartFailed"
+	ReasonModsPendingRestart = "ModsPendingRestart"
+	ReasonModsDrifted        = "ModsDrifted"
+
+	ReasonVanillaMapOnly = "VanillaMapOnly"
+	ReasonMapsFound      = "MapsFound"
+	ReasonMapsMissing    = "MapsMissing"
+	ReasonMapsNotChecked = "MapsNotChecked"
+
+	ReasonWorkshopUpToDate   = "WorkshopUpToDate"
+	ReasonWorkshopOutdated   = "WorkshopOutdated"
+	ReasonWorkshopNotChecked = "WorkshopNotChecked"
+	ReasonRestartScheduled   = "RestartScheduled"
+
+	ReasonDependenciesMet         = "DependenciesMet"
+	ReasonDependenciesMissing     = "DependenciesMissing"
+	ReasonWorkshopModsNotResolved = "WorkshopModsNotResolved"
+
+	ReasonDatabaseConnected   = "DatabaseConnected"
+	ReasonDatabaseUnreachable = "DatabaseUnreachable"
+
+	ReasonUsersInSync       = "UsersInSync"
+	ReasonUserSecretMissing = "UserSecretMissing"
+	ReasonUsersUpdateFailed = "UsersUpdateFailed"
+
+	ReasonBackupsDisabled       = "BackupsDisabled"
+	ReasonBackupVolumeOnly      = "BackupVolumeOnly"
+	ReasonBackupPlansConfigured = "BackupPlansConfigured"
 )
 
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Version",type=string,JSONPath=`.spec.version`
+// +kubebuilder:printcolumn:name="Ready",type=boolean,JSONPath=`.status.ready`
+// +kubebuilder:printcolumn:name="RCON",type=string,JSONPath=`.status.conditions[?(@.type=="RCONReachable")].status`
+// +kubebuilder:printcolumn:name="Settings",type=string,JSONPath=`.status.conditions[?(@.type=="SettingsSynced")].status`
+// +kubebuilder:printcolumn:name="Users",type=string,JSONPath=`.status.conditions[?(@.type=="UsersSynced")].status`
+// +kubebuilder:printcolumn:name="Mods",type=string,JSONPath=`.status.conditions[?(@.type=="ModsReady")].status`,priority=1
+// +kubebuilder:printcolumn:name="Database",type=string,JSONPath=`.status.conditions[?(@.type=="DatabaseReachable")].status`,priority=1
+// +kubebuilder:printcolumn:name="Backups",type=string,JSONPath=`.status.conditions[?(@.type=="BackupsConfigured")].reason`,priority=1
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
 
 // ZomboidServer is the Schema for the zomboidservers API.
 type ZomboidServer struct {
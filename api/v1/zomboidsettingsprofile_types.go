@@ -0,0 +1,65 @@
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ZomboidSettingsProfileSpec defines settings shared by the ZomboidServers
+// that reference the profile
+type ZomboidSettingsProfileSpec struct {
+	// Settings are the shared settings, including workshop mods and extra
+	// settings.  Settings left unset fall through to the next layer.
+	// +optional
+	Settings ZomboidSettings `json:"settings,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:shortName=zsp
+
+// ZomboidSettingsProfile is the Schema for the zomboidsettingsprofiles API.
+// It holds settings that ZomboidServers in the same namespace layer under
+// their own through spec.settingsFrom.
+type ZomboidSettingsProfile struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec ZomboidSettingsProfileSpec `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ZomboidSettingsProfileList contains a list of ZomboidSettingsProfile.
+type ZomboidSettingsProfileList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ZomboidSettingsProfile `json:"items"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Cluster,shortName=czsp
+
+// ClusterZomboidSettingsProfile is the Schema for the
+// clusterzomboidsettingsprofiles API.  It holds settings that ZomboidServers
+// in any namespace layer under their own through spec.settingsFrom.
+type ClusterZomboidSettingsProfile struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec ZomboidSettingsProfileSpec `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ClusterZomboidSettingsProfileList contains a list of ClusterZomboidSettingsProfile.
+type ClusterZomboidSettingsProfileList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ClusterZomboidSettingsProfile `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(
+		&ZomboidSettingsProfile{}, &ZomboidSettingsProfileList{},
+		&ClusterZomboidSettingsProfile{}, &ClusterZomboidSettingsProfileList{},
+	)
+}
@@ -10,6 +10,21 @@ import (
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AddItemAction) DeepCopyInto(out *AddItemAction) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AddItemAction.
+func (in *AddItemAction) DeepCopy() *AddItemAction {
+	if in == nil {
+		return nil
+	}
+	out := new(AddItemAction)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Administrator) DeepCopyInto(out *Administrator) {
 	*out = *in
@@ -415,6 +430,64 @@ func (in *Backups) DeepCopy() *Backups {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterZomboidSettingsProfile) DeepCopyInto(out *ClusterZomboidSettingsProfile) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterZomboidSettingsProfile.
+func (in *ClusterZomboidSettingsProfile) DeepCopy() *ClusterZomboidSettingsProfile {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterZomboidSettingsProfile)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterZomboidSettingsProfile) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterZomboidSettingsProfileList) DeepCopyInto(out *ClusterZomboidSettingsProfileList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ClusterZomboidSettingsProfile, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterZomboidSettingsProfileList.
+func (in *ClusterZomboidSettingsProfileList) DeepCopy() *ClusterZomboidSettingsProfileList {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterZomboidSettingsProfileList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterZomboidSettingsProfileList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Communication) DeepCopyInto(out *Communication) {
 	*out = *in
@@ -752,6 +825,21 @@ func (in *Identity) DeepCopy() *Identity {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KickAction) DeepCopyInto(out *KickAction) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KickAction.
+func (in *KickAction) DeepCopy() *KickAction {
+	if in == nil {
+		return nil
+	}
+	out := new(KickAction)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Logging) DeepCopyInto(out *Logging) {
 	*out = *in
@@ -917,6 +1005,36 @@ func (in *Mods) DeepCopy() *Mods {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkPolicy) DeepCopyInto(out *NetworkPolicy) {
+	*out = *in
+	if in.OperatorPodSelector != nil {
+		in, out := &in.OperatorPodSelector, &out.OperatorPodSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PrometheusNamespaceSelector != nil {
+		in, out := &in.PrometheusNamespaceSelector, &out.PrometheusNamespaceSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PrometheusPodSelector != nil {
+		in, out := &in.PrometheusPodSelector, &out.PrometheusPodSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkPolicy.
+func (in *NetworkPolicy) DeepCopy() *NetworkPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PVP) DeepCopyInto(out *PVP) {
 	*out = *in
@@ -972,6 +1090,22 @@ func (in *PVP) DeepCopy() *PVP {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PendingRestartSetting) DeepCopyInto(out *PendingRestartSetting) {
+	*out = *in
+	in.ChangedAt.DeepCopyInto(&out.ChangedAt)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PendingRestartSetting.
+func (in *PendingRestartSetting) DeepCopy() *PendingRestartSetting {
+	if in == nil {
+		return nil
+	}
+	out := new(PendingRestartSetting)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Player) DeepCopyInto(out *Player) {
 	*out = *in
@@ -1042,6 +1176,46 @@ func (in *Player) DeepCopy() *Player {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RCON) DeepCopyInto(out *RCON) {
+	*out = *in
+	if in.Password != nil {
+		in, out := &in.Password, &out.Password
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RCON.
+func (in *RCON) DeepCopy() *RCON {
+	if in == nil {
+		return nil
+	}
+	out := new(RCON)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResolvedMod) DeepCopyInto(out *ResolvedMod) {
+	*out = *in
+	if in.Requires != nil {
+		in, out := &in.Requires, &out.Requires
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResolvedMod.
+func (in *ResolvedMod) DeepCopy() *ResolvedMod {
+	if in == nil {
+		return nil
+	}
+	out := new(ResolvedMod)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *S3) DeepCopyInto(out *S3) {
 	*out = *in
@@ -1132,6 +1306,144 @@ func (in *Safehouse) DeepCopy() *Safehouse {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServerMessageAction) DeepCopyInto(out *ServerMessageAction) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServerMessageAction.
+func (in *ServerMessageAction) DeepCopy() *ServerMessageAction {
+	if in == nil {
+		return nil
+	}
+	out := new(ServerMessageAction)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SettingDrift) DeepCopyInto(out *SettingDrift) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SettingDrift.
+func (in *SettingDrift) DeepCopy() *SettingDrift {
+	if in == nil {
+		return nil
+	}
+	out := new(SettingDrift)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SettingsConfigMap) DeepCopyInto(out *SettingsConfigMap) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SettingsConfigMap.
+func (in *SettingsConfigMap) DeepCopy() *SettingsConfigMap {
+	if in == nil {
+		return nil
+	}
+	out := new(SettingsConfigMap)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SettingsProfileReference) DeepCopyInto(out *SettingsProfileReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SettingsProfileReference.
+func (in *SettingsProfileReference) DeepCopy() *SettingsProfileReference {
+	if in == nil {
+		return nil
+	}
+	out := new(SettingsProfileReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Spawn) DeepCopyInto(out *Spawn) {
+	*out = *in
+	if in.Regions != nil {
+		in, out := &in.Regions, &out.Regions
+		*out = make([]SpawnRegion, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.MapRegions != nil {
+		in, out := &in.MapRegions, &out.MapRegions
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Spawn.
+func (in *Spawn) DeepCopy() *Spawn {
+	if in == nil {
+		return nil
+	}
+	out := new(Spawn)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SpawnPoint) DeepCopyInto(out *SpawnPoint) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SpawnPoint.
+func (in *SpawnPoint) DeepCopy() *SpawnPoint {
+	if in == nil {
+		return nil
+	}
+	out := new(SpawnPoint)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SpawnRegion) DeepCopyInto(out *SpawnRegion) {
+	*out = *in
+	if in.File != nil {
+		in, out := &in.File, &out.File
+		*out = new(string)
+		**out = **in
+	}
+	if in.Points != nil {
+		in, out := &in.Points, &out.Points
+		*out = make(map[string][]SpawnPoint, len(*in))
+		for key, val := range *in {
+			var outVal []SpawnPoint
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				inVal := (*in)[key]
+				in, out := &inVal, &outVal
+				*out = make([]SpawnPoint, len(*in))
+				copy(*out, *in)
+			}
+			(*out)[key] = outVal
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SpawnRegion.
+func (in *SpawnRegion) DeepCopy() *SpawnRegion {
+	if in == nil {
+		return nil
+	}
+	out := new(SpawnRegion)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Steam) DeepCopyInto(out *Steam) {
 	*out = *in
@@ -1178,6 +1490,21 @@ func (in *Storage) DeepCopy() *Storage {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TeleportAction) DeepCopyInto(out *TeleportAction) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TeleportAction.
+func (in *TeleportAction) DeepCopy() *TeleportAction {
+	if in == nil {
+		return nil
+	}
+	out := new(TeleportAction)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *User) DeepCopyInto(out *User) {
 	*out = *in
@@ -1198,6 +1525,29 @@ func (in *User) DeepCopy() *User {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkshopItemStatus) DeepCopyInto(out *WorkshopItemStatus) {
+	*out = *in
+	if in.Installed != nil {
+		in, out := &in.Installed, &out.Installed
+		*out = (*in).DeepCopy()
+	}
+	if in.Available != nil {
+		in, out := &in.Available, &out.Available
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkshopItemStatus.
+func (in *WorkshopItemStatus) DeepCopy() *WorkshopItemStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkshopItemStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *WorkshopMod) DeepCopyInto(out *WorkshopMod) {
 	*out = *in
@@ -1223,12 +1573,64 @@ func (in *WorkshopMod) DeepCopy() *WorkshopMod {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkshopModStatus) DeepCopyInto(out *WorkshopModStatus) {
+	*out = *in
+	if in.Mods != nil {
+		in, out := &in.Mods, &out.Mods
+		*out = make([]ResolvedMod, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.RequiredItems != nil {
+		in, out := &in.RequiredItems, &out.RequiredItems
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.RequiredBy != nil {
+		in, out := &in.RequiredBy, &out.RequiredBy
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkshopModStatus.
+func (in *WorkshopModStatus) DeepCopy() *WorkshopModStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkshopModStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkshopUpdates) DeepCopyInto(out *WorkshopUpdates) {
+	*out = *in
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkshopUpdates.
+func (in *WorkshopUpdates) DeepCopy() *WorkshopUpdates {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkshopUpdates)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ZomboidBackupPlan) DeepCopyInto(out *ZomboidBackupPlan) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
+	in.Spec.DeepCopyInto(&out.Spec)
 	in.Status.DeepCopyInto(&out.Status)
 }
 
@@ -1287,6 +1689,11 @@ func (in *ZomboidBackupPlanSpec) DeepCopyInto(out *ZomboidBackupPlanSpec) {
 	*out = *in
 	out.Server = in.Server
 	out.Destination = in.Destination
+	if in.TimeZone != nil {
+		in, out := &in.TimeZone, &out.TimeZone
+		*out = new(string)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ZomboidBackupPlanSpec.
@@ -1325,6 +1732,120 @@ func (in *ZomboidBackupPlanStatus) DeepCopy() *ZomboidBackupPlanStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ZomboidCommand) DeepCopyInto(out *ZomboidCommand) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ZomboidCommand.
+func (in *ZomboidCommand) DeepCopy() *ZomboidCommand {
+	if in == nil {
+		return nil
+	}
+	out := new(ZomboidCommand)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ZomboidCommand) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ZomboidCommandList) DeepCopyInto(out *ZomboidCommandList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ZomboidCommand, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ZomboidCommandList.
+func (in *ZomboidCommandList) DeepCopy() *ZomboidCommandList {
+	if in == nil {
+		return nil
+	}
+	out := new(ZomboidCommandList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ZomboidCommandList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ZomboidCommandSpec) DeepCopyInto(out *ZomboidCommandSpec) {
+	*out = *in
+	out.Server = in.Server
+	if in.Kick != nil {
+		in, out := &in.Kick, &out.Kick
+		*out = new(KickAction)
+		**out = **in
+	}
+	if in.Teleport != nil {
+		in, out := &in.Teleport, &out.Teleport
+		*out = new(TeleportAction)
+		**out = **in
+	}
+	if in.AddItem != nil {
+		in, out := &in.AddItem, &out.AddItem
+		*out = new(AddItemAction)
+		**out = **in
+	}
+	if in.ServerMessage != nil {
+		in, out := &in.ServerMessage, &out.ServerMessage
+		*out = new(ServerMessageAction)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ZomboidCommandSpec.
+func (in *ZomboidCommandSpec) DeepCopy() *ZomboidCommandSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ZomboidCommandSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ZomboidCommandStatus) DeepCopyInto(out *ZomboidCommandStatus) {
+	*out = *in
+	if in.ExecutedAt != nil {
+		in, out := &in.ExecutedAt, &out.ExecutedAt
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ZomboidCommandStatus.
+func (in *ZomboidCommandStatus) DeepCopy() *ZomboidCommandStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ZomboidCommandStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ZomboidServer) DeepCopyInto(out *ZomboidServer) {
 	*out = *in
@@ -1408,6 +1929,11 @@ func (in *ZomboidServerSpec) DeepCopyInto(out *ZomboidServerSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.RCON != nil {
+		in, out := &in.RCON, &out.RCON
+		*out = new(RCON)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Password != nil {
 		in, out := &in.Password, &out.Password
 		*out = new(corev1.SecretKeySelector)
@@ -1419,11 +1945,41 @@ func (in *ZomboidServerSpec) DeepCopyInto(out *ZomboidServerSpec) {
 		**out = **in
 	}
 	in.Settings.DeepCopyInto(&out.Settings)
+	if in.SettingsFrom != nil {
+		in, out := &in.SettingsFrom, &out.SettingsFrom
+		*out = make([]SettingsProfileReference, len(*in))
+		copy(*out, *in)
+	}
+	if in.RestartWarning != nil {
+		in, out := &in.RestartWarning, &out.RestartWarning
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.WorkshopUpdates != nil {
+		in, out := &in.WorkshopUpdates, &out.WorkshopUpdates
+		*out = new(WorkshopUpdates)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Discord != nil {
 		in, out := &in.Discord, &out.Discord
 		*out = new(Discord)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.NetworkPolicy != nil {
+		in, out := &in.NetworkPolicy, &out.NetworkPolicy
+		*out = new(NetworkPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SettingsConfigMap != nil {
+		in, out := &in.SettingsConfigMap, &out.SettingsConfigMap
+		*out = new(SettingsConfigMap)
+		**out = **in
+	}
+	if in.Spawn != nil {
+		in, out := &in.Spawn, &out.Spawn
+		*out = new(Spawn)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ZomboidServerSpec.
@@ -1448,6 +2004,49 @@ func (in *ZomboidServerStatus) DeepCopyInto(out *ZomboidServerStatus) {
 		*out = new(ZomboidSettings)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.ResolvedSettings != nil {
+		in, out := &in.ResolvedSettings, &out.ResolvedSettings
+		*out = new(ZomboidSettings)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SettingsDrift != nil {
+		in, out := &in.SettingsDrift, &out.SettingsDrift
+		*out = make([]SettingDrift, len(*in))
+		copy(*out, *in)
+	}
+	if in.PendingRestart != nil {
+		in, out := &in.PendingRestart, &out.PendingRestart
+		*out = make([]PendingRestartSetting, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.RestartAt != nil {
+		in, out := &in.RestartAt, &out.RestartAt
+		*out = (*in).DeepCopy()
+	}
+	if in.WorkshopItems != nil {
+		in, out := &in.WorkshopItems, &out.WorkshopItems
+		*out = make([]WorkshopItemStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.WorkshopLastChecked != nil {
+		in, out := &in.WorkshopLastChecked, &out.WorkshopLastChecked
+		*out = (*in).DeepCopy()
+	}
+	if in.WorkshopMods != nil {
+		in, out := &in.WorkshopMods, &out.WorkshopMods
+		*out = make([]WorkshopModStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.WorkshopModsResolved != nil {
+		in, out := &in.WorkshopModsResolved, &out.WorkshopModsResolved
+		*out = (*in).DeepCopy()
+	}
 	if in.Allowlist != nil {
 		in, out := &in.Allowlist, &out.Allowlist
 		*out = make([]AllowlistUser, len(*in))
@@ -1485,6 +2084,11 @@ func (in *ZomboidSettings) DeepCopyInto(out *ZomboidSettings) {
 	in.Identity.DeepCopyInto(&out.Identity)
 	in.Player.DeepCopyInto(&out.Player)
 	in.Map.DeepCopyInto(&out.Map)
+	if in.Maps != nil {
+		in, out := &in.Maps, &out.Maps
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	in.Mods.DeepCopyInto(&out.Mods)
 	if in.WorkshopMods != nil {
 		in, out := &in.WorkshopMods, &out.WorkshopMods
@@ -1504,6 +2108,13 @@ func (in *ZomboidSettings) DeepCopyInto(out *ZomboidSettings) {
 	in.Safehouse.DeepCopyInto(&out.Safehouse)
 	in.Faction.DeepCopyInto(&out.Faction)
 	in.AntiCheat.DeepCopyInto(&out.AntiCheat)
+	if in.Extra != nil {
+		in, out := &in.Extra, &out.Extra
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ZomboidSettings.
@@ -1515,3 +2126,77 @@ func (in *ZomboidSettings) DeepCopy() *ZomboidSettings {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ZomboidSettingsProfile) DeepCopyInto(out *ZomboidSettingsProfile) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ZomboidSettingsProfile.
+func (in *ZomboidSettingsProfile) DeepCopy() *ZomboidSettingsProfile {
+	if in == nil {
+		return nil
+	}
+	out := new(ZomboidSettingsProfile)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ZomboidSettingsProfile) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ZomboidSettingsProfileList) DeepCopyInto(out *ZomboidSettingsProfileList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ZomboidSettingsProfile, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ZomboidSettingsProfileList.
+func (in *ZomboidSettingsProfileList) DeepCopy() *ZomboidSettingsProfileList {
+	if in == nil {
+		return nil
+	}
+	out := new(ZomboidSettingsProfileList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ZomboidSettingsProfileList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ZomboidSettingsProfileSpec) DeepCopyInto(out *ZomboidSettingsProfileSpec) {
+	*out = *in
+	in.Settings.DeepCopyInto(&out.Settings)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ZomboidSettingsProfileSpec.
+func (in *ZomboidSettingsProfileSpec) DeepCopy() *ZomboidSettingsProfileSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ZomboidSettingsProfileSpec)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -4,7 +4,9 @@ import (
 	"context"
 	"crypto/tls"
 	"flag"
+	"fmt"
 	"os"
+	"strings"
 
 	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
 	// to ensure that exec-entrypoint and run can make use of them.
@@ -23,8 +25,13 @@ import (
 	zomboidhostv1 "github.com/zomboidhost/zomboid-operator/api/v1"
 	zomboidv1 "github.com/zomboidhost/zomboid-operator/api/v1"
 	zomboidzomboidhostv1 "github.com/zomboidhost/zomboid-operator/api/v1"
+	"github.com/zomboidhost/zomboid-operator/internal/adminapi"
+	"github.com/zomboidhost/zomboid-operator/internal/console"
 	"github.com/zomboidhost/zomboid-operator/internal/controller"
+	"github.com/zomboidhost/zomboid-operator/internal/convert"
 	"github.com/zomboidhost/zomboid-operator/internal/metrics"
+	webhookzomboidv1 "github.com/zomboidhost/zomboid-operator/internal/webhook/v1"
+	"github.com/zomboidhost/zomboid-operator/internal/workshop"
 	// +kubebuilder:scaffold:imports
 )
 
@@ -53,12 +60,33 @@ func main() {
 		return
 	}
 
+	if len(os.Args) > 1 && os.Args[1] == "console" {
+		if err := console.Run(context.Background(), os.Args[2:]); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	if len(os.Args) > 1 && os.Args[1] == "convert" {
+		if err := convert.Run(os.Args[2:], os.Stdout, os.Stderr); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	var metricsAddr string
 	var enableLeaderElection bool
 	var probeAddr string
 	var secureMetrics bool
 	var enableHTTP2 bool
 	var tlsOpts []func(*tls.Config)
+	var adminAPIAddr, adminAPITokensFile, adminAPICertFile, adminAPIKeyFile string
+	var adminAPIInsecure bool
+	var oidcOptions adminapi.OIDCOptions
+	var oidcAdminGroups, oidcModeratorGroups, oidcGroupScopes string
+	var workshopEndpoint, workshopAPIKeyFile string
 	flag.StringVar(&metricsAddr, "metrics-bind-address", "0", "The address the metrics endpoint binds to. "+
 		"Use :8443 for HTTPS or :8080 for HTTP, or leave as 0 to disable the metrics service.")
 	flag.StringVar(&probeAddr, "health-probe-bind-address", ":8081", "The address the probe endpoint binds to.")
@@ -69,6 +97,31 @@ func main() {
 		"If set, the metrics endpoint is served securely via HTTPS. Use --metrics-secure=false to use HTTP instead.")
 	flag.BoolVar(&enableHTTP2, "enable-http2", false,
 		"If set, HTTP/2 will be enabled for the metrics and webhook servers")
+	flag.StringVar(&adminAPIAddr, "admin-api-bind-address", "0", "The address the admin API binds to, "+
+		"or leave as 0 to disable the admin API.")
+	flag.StringVar(&adminAPITokensFile, "admin-api-tokens-file", "",
+		"A CSV file of token,username,role lines granting access to the admin API, each optionally followed "+
+			"by semicolon-separated namespaces and namespace/name servers the token is limited to.")
+	flag.StringVar(&adminAPICertFile, "admin-api-tls-cert-file", "", "The certificate the admin API is served over TLS with.")
+	flag.StringVar(&adminAPIKeyFile, "admin-api-tls-key-file", "", "The key for --admin-api-tls-cert-file.")
+	flag.BoolVar(&adminAPIInsecure, "admin-api-insecure", false,
+		"If set, the admin API is served over plain HTTP without a TLS certificate, e.g. behind a TLS-terminating proxy.")
+	flag.StringVar(&oidcOptions.IssuerURL, "admin-api-oidc-issuer-url", "",
+		"If set, the admin API accepts ID tokens from this OIDC issuer.")
+	flag.StringVar(&oidcOptions.ClientID, "admin-api-oidc-client-id", "", "The client ID ID tokens must be issued for.")
+	flag.StringVar(&oidcOptions.UsernameClaim, "admin-api-oidc-username-claim", "sub", "The ID token claim that names the user.")
+	flag.StringVar(&oidcOptions.GroupsClaim, "admin-api-oidc-groups-claim", "groups", "The ID token claim listing the user's groups.")
+	flag.StringVar(&oidcAdminGroups, "admin-api-oidc-admin-groups", "",
+		"Comma-separated OIDC groups whose members are admin API admins.")
+	flag.StringVar(&oidcModeratorGroups, "admin-api-oidc-moderator-groups", "",
+		"Comma-separated OIDC groups whose members are admin API moderators.")
+	flag.StringVar(&oidcGroupScopes, "admin-api-oidc-group-scopes", "",
+		"Comma-separated group=scope;scope pairs limiting the role an OIDC group grants to some namespaces and "+
+			"namespace/name servers. Once set, other callers are no longer viewers of every server.")
+	flag.StringVar(&workshopEndpoint, "workshop-endpoint", workshop.DefaultEndpoint,
+		"The Steam Web API GetPublishedFileDetails endpoint to check for workshop item updates with.")
+	flag.StringVar(&workshopAPIKeyFile, "workshop-api-key-file", "",
+		"If set, the Steam Web API key in this file is used to learn the workshop items each mod requires.")
 	opts := zap.Options{
 		Development: true,
 	}
@@ -144,23 +197,107 @@ func main() {
 		os.Exit(1)
 	}
 
-	if err = (&controller.ZomboidServerReconciler{
-		Client: mgr.GetClient(),
-		Scheme: mgr.GetScheme(),
-		Config: mgr.GetConfig(),
-	}).SetupWithManager(mgr); err != nil {
+	workshopClient := workshop.NewClient(workshopEndpoint)
+	if workshopAPIKeyFile != "" {
+		key, err := os.ReadFile(workshopAPIKeyFile)
+		if err != nil {
+			setupLog.Error(err, "unable to read the Steam Web API key")
+			os.Exit(1)
+		}
+		workshopClient.Key = strings.TrimSpace(string(key))
+	}
+
+	serverReconciler := &controller.ZomboidServerReconciler{
+		Client:   mgr.GetClient(),
+		Scheme:   mgr.GetScheme(),
+		Config:   mgr.GetConfig(),
+		Recorder: mgr.GetEventRecorderFor("zomboidserver-controller"),
+		Workshop: workshopClient,
+	}
+	if err = serverReconciler.SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "ZomboidServer")
 		os.Exit(1)
 	}
 	if err = (&controller.ZomboidBackupPlanReconciler{
-		Client: mgr.GetClient(),
-		Scheme: mgr.GetScheme(),
+		Client:   mgr.GetClient(),
+		Scheme:   mgr.GetScheme(),
+		Recorder: mgr.GetEventRecorderFor("zomboidbackupplan-controller"),
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "ZomboidBackupPlan")
 		os.Exit(1)
 	}
+	if err = (&controller.ZomboidCommandReconciler{
+		Client:  mgr.GetClient(),
+		Scheme:  mgr.GetScheme(),
+		Servers: serverReconciler,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "ZomboidCommand")
+		os.Exit(1)
+	}
+	// nolint:goconst
+	if os.Getenv("ENABLE_WEBHOOKS") != "false" {
+		if err = webhookzomboidv1.SetupZomboidServerWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "ZomboidServer")
+			os.Exit(1)
+		}
+		if err = webhookzomboidv1.SetupZomboidBackupPlanWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "ZomboidBackupPlan")
+			os.Exit(1)
+		}
+		if err = webhookzomboidv1.SetupBackupDestinationWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "BackupDestination")
+			os.Exit(1)
+		}
+		if err = webhookzomboidv1.SetupZomboidSettingsProfileWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "ZomboidSettingsProfile")
+			os.Exit(1)
+		}
+	}
 	// +kubebuilder:scaffold:builder
 
+	if adminAPIAddr != "" && adminAPIAddr != "0" {
+		var authenticators []adminapi.Authenticator
+		if adminAPITokensFile != "" {
+			tokens, err := adminapi.LoadTokenFile(adminAPITokensFile)
+			if err != nil {
+				setupLog.Error(err, "unable to load admin API tokens")
+				os.Exit(1)
+			}
+			authenticators = append(authenticators, tokens)
+		}
+		if oidcOptions.IssuerURL != "" {
+			oidcOptions.AdminGroups = splitList(oidcAdminGroups)
+			oidcOptions.ModeratorGroups = splitList(oidcModeratorGroups)
+			groupScopes, err := adminapi.ParseGroupScopes(oidcGroupScopes)
+			if err != nil {
+				setupLog.Error(err, "invalid --admin-api-oidc-group-scopes")
+				os.Exit(1)
+			}
+			oidcOptions.GroupScopes = groupScopes
+			oidc, err := adminapi.NewOIDCAuthenticator(context.Background(), oidcOptions)
+			if err != nil {
+				setupLog.Error(err, "unable to set up admin API OIDC authentication")
+				os.Exit(1)
+			}
+			authenticators = append(authenticators, oidc)
+		}
+		if len(authenticators) == 0 {
+			setupLog.Error(nil, "the admin API needs --admin-api-tokens-file or --admin-api-oidc-issuer-url")
+			os.Exit(1)
+		}
+
+		if err := mgr.Add(adminapi.NewServer(mgr.GetClient(), serverReconciler, adminapi.Options{
+			BindAddress:    adminAPIAddr,
+			CertFile:       adminAPICertFile,
+			KeyFile:        adminAPIKeyFile,
+			Insecure:       adminAPIInsecure,
+			Authenticators: authenticators,
+		})); err != nil {
+			setupLog.Error(err, "unable to set up admin API")
+			os.Exit(1)
+		}
+	}
+
 	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
 		setupLog.Error(err, "unable to set up health check")
 		os.Exit(1)
@@ -176,3 +313,14 @@ func main() {
 		os.Exit(1)
 	}
 }
+
+// splitList splits a comma-separated flag value
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
@@ -0,0 +1,334 @@
+package adminapi
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/coreos/go-oidc/v3/oidc"
+)
+
+// Role is what a caller of the API is allowed to do.  Each role can do
+// everything the roles before it can.
+type Role int
+
+const (
+	// RoleViewer can read servers' status, players, allowlists and settings
+	RoleViewer Role = iota + 1
+	// RoleModerator can also broadcast messages, and kick and ban players
+	RoleModerator
+	// RoleAdmin can also restart servers and run their backups
+	RoleAdmin
+)
+
+func (r Role) String() string {
+	switch r {
+	case RoleViewer:
+		return "viewer"
+	case RoleModerator:
+		return "moderator"
+	case RoleAdmin:
+		return "admin"
+	case 0:
+		return "none"
+	}
+	return fmt.Sprintf("Role(%d)", int(r))
+}
+
+// ParseRole parses a role by name
+func ParseRole(name string) (Role, error) {
+	for _, role := range []Role{RoleViewer, RoleModerator, RoleAdmin} {
+		if strings.EqualFold(name, role.String()) {
+			return role, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown role %q", name)
+}
+
+// Identity is an authenticated caller of the API
+type Identity struct {
+	Username string
+	// Grants are the roles the caller has, each on the servers in its scope
+	Grants []Grant
+}
+
+// Grant is a role on the servers in a scope: a namespace, a namespace/name
+// server, or every server if the scope is empty
+type Grant struct {
+	Role  Role
+	Scope string
+}
+
+// RoleFor returns the best role the caller has on a server, or on any server
+// in the namespace if name is empty
+func (i *Identity) RoleFor(namespace, name string) Role {
+	var role Role
+	for _, grant := range i.Grants {
+		scopeNamespace, scopeName, _ := strings.Cut(grant.Scope, "/")
+		if grant.Scope != "" && (scopeNamespace != namespace || (name != "" && scopeName != "" && scopeName != name)) {
+			continue
+		}
+		if grant.Role > role {
+			role = grant.Role
+		}
+	}
+	return role
+}
+
+// ParseScopes parses a semicolon-separated list of namespaces and
+// namespace/name servers
+func ParseScopes(value string) ([]string, error) {
+	var scopes []string
+	for _, scope := range strings.Split(value, ";") {
+		if scope = strings.TrimSpace(scope); scope == "" {
+			continue
+		}
+		namespace, name, found := strings.Cut(scope, "/")
+		if namespace == "" || (found && (name == "" || strings.Contains(name, "/"))) {
+			return nil, fmt.Errorf("invalid scope %q, expected a namespace or namespace/name", scope)
+		}
+		scopes = append(scopes, scope)
+	}
+	return scopes, nil
+}
+
+// grants gives a role on each of scopes, or on every server if there are none
+func grants(role Role, scopes []string) []Grant {
+	if len(scopes) == 0 {
+		return []Grant{{Role: role}}
+	}
+	var result []Grant
+	for _, scope := range scopes {
+		result = append(result, Grant{Role: role, Scope: scope})
+	}
+	return result
+}
+
+// ErrNoCredentials is returned by an Authenticator when a request carries no
+// credentials it understands, so that the next one can be tried
+var ErrNoCredentials = errors.New("no credentials")
+
+// Authenticator works out who made a request
+type Authenticator interface {
+	Authenticate(r *http.Request) (*Identity, error)
+}
+
+// bearerToken returns the bearer token a request was made with
+func bearerToken(r *http.Request) (string, bool) {
+	scheme, token, ok := strings.Cut(r.Header.Get("Authorization"), " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") || token == "" {
+		return "", false
+	}
+	return strings.TrimSpace(token), true
+}
+
+// TokenAuthenticator authenticates requests by static bearer tokens
+type TokenAuthenticator struct {
+	// identities are keyed by the SHA-256 of their token, so that looking
+	// one up doesn't leak how much of a token was right
+	identities map[[sha256.Size]byte]Identity
+}
+
+// LoadTokenFile reads static tokens from a CSV file with one
+// token,username,role line per token, in the spirit of the Kubernetes API
+// server's --token-auth-file.  A fourth field of semicolon-separated
+// namespaces and namespace/name servers limits a token to those servers.
+func LoadTokenFile(path string) (*TokenAuthenticator, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open tokens file: %w", err)
+	}
+	defer file.Close()
+
+	return ReadTokens(file)
+}
+
+// ReadTokens reads static tokens in the format of LoadTokenFile
+func ReadTokens(r io.Reader) (*TokenAuthenticator, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.Comment = '#'
+	reader.TrimLeadingSpace = true
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read tokens: %w", err)
+	}
+
+	authenticator := &TokenAuthenticator{identities: map[[sha256.Size]byte]Identity{}}
+	for _, record := range records {
+		if len(record) != 3 && len(record) != 4 {
+			return nil, fmt.Errorf("tokens must be token,username,role lines, optionally followed by scopes")
+		}
+		token, username := record[0], record[1]
+		if token == "" || username == "" {
+			return nil, fmt.Errorf("tokens must have a token and a username")
+		}
+		role, err := ParseRole(record[2])
+		if err != nil {
+			return nil, fmt.Errorf("invalid role for %s: %w", username, err)
+		}
+		var scopes []string
+		if len(record) == 4 {
+			if scopes, err = ParseScopes(record[3]); err != nil {
+				return nil, fmt.Errorf("invalid scopes for %s: %w", username, err)
+			}
+			if len(scopes) == 0 {
+				return nil, fmt.Errorf("invalid scopes for %s: leave the field out to grant every server", username)
+			}
+		}
+		authenticator.identities[sha256.Sum256([]byte(token))] = Identity{Username: username, Grants: grants(role, scopes)}
+	}
+	return authenticator, nil
+}
+
+// Authenticate implements Authenticator
+func (a *TokenAuthenticator) Authenticate(r *http.Request) (*Identity, error) {
+	token, ok := bearerToken(r)
+	if !ok {
+		return nil, ErrNoCredentials
+	}
+
+	identity, ok := a.identities[sha256.Sum256([]byte(token))]
+	if !ok {
+		return nil, ErrNoCredentials
+	}
+	return &identity, nil
+}
+
+// OIDCOptions configure an OIDCAuthenticator
+type OIDCOptions struct {
+	IssuerURL string
+	ClientID  string
+
+	// UsernameClaim is the claim that names the caller, "sub" by default
+	UsernameClaim string
+	// GroupsClaim is the claim listing the caller's groups, "groups" by default
+	GroupsClaim string
+
+	// AdminGroups and ModeratorGroups grant those roles to their members.
+	// Anyone else with a valid token is a viewer, unless GroupScopes is set.
+	AdminGroups     []string
+	ModeratorGroups []string
+
+	// GroupScopes limits the role each group grants to some namespaces and
+	// namespace/name servers.  Groups listed here that aren't admin or
+	// moderator groups grant the viewer role, and once it's set, a valid
+	// token alone grants nothing.
+	GroupScopes map[string][]string
+}
+
+// OIDCAuthenticator authenticates requests by OIDC ID tokens
+type OIDCAuthenticator struct {
+	options  OIDCOptions
+	verifier *oidc.IDTokenVerifier
+}
+
+// NewOIDCAuthenticator discovers an OIDC issuer and returns an authenticator
+// for the ID tokens it issues
+func NewOIDCAuthenticator(ctx context.Context, options OIDCOptions) (*OIDCAuthenticator, error) {
+	provider, err := oidc.NewProvider(ctx, options.IssuerURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to discover OIDC issuer: %w", err)
+	}
+
+	return &OIDCAuthenticator{
+		options:  options,
+		verifier: provider.Verifier(&oidc.Config{ClientID: options.ClientID}),
+	}, nil
+}
+
+// Authenticate implements Authenticator
+func (a *OIDCAuthenticator) Authenticate(r *http.Request) (*Identity, error) {
+	raw, ok := bearerToken(r)
+	if !ok {
+		return nil, ErrNoCredentials
+	}
+
+	token, err := a.verifier.Verify(r.Context(), raw)
+	if err != nil {
+		return nil, ErrNoCredentials
+	}
+
+	claims := map[string]interface{}{}
+	if err := token.Claims(&claims); err != nil {
+		return nil, fmt.Errorf("failed to read token claims: %w", err)
+	}
+	return a.identity(claims)
+}
+
+// identity maps an ID token's claims to an Identity
+func (a *OIDCAuthenticator) identity(claims map[string]interface{}) (*Identity, error) {
+	usernameClaim := a.options.UsernameClaim
+	if usernameClaim == "" {
+		usernameClaim = "sub"
+	}
+	groupsClaim := a.options.GroupsClaim
+	if groupsClaim == "" {
+		groupsClaim = "groups"
+	}
+
+	username, _ := claims[usernameClaim].(string)
+	if username == "" {
+		return nil, fmt.Errorf("token has no %s claim", usernameClaim)
+	}
+
+	identity := &Identity{Username: username}
+	if len(a.options.GroupScopes) == 0 {
+		identity.Grants = append(identity.Grants, Grant{Role: RoleViewer})
+	}
+	groups, _ := claims[groupsClaim].([]interface{})
+	for _, group := range groups {
+		name, _ := group.(string)
+		scopes, scoped := a.options.GroupScopes[name]
+		switch {
+		case contains(a.options.AdminGroups, name):
+			identity.Grants = append(identity.Grants, grants(RoleAdmin, scopes)...)
+		case contains(a.options.ModeratorGroups, name):
+			identity.Grants = append(identity.Grants, grants(RoleModerator, scopes)...)
+		case scoped:
+			identity.Grants = append(identity.Grants, grants(RoleViewer, scopes)...)
+		}
+	}
+	return identity, nil
+}
+
+// ParseGroupScopes parses comma-separated group=scopes pairs, where scopes
+// are as read by ParseScopes
+func ParseGroupScopes(value string) (map[string][]string, error) {
+	groupScopes := map[string][]string{}
+	for _, pair := range strings.Split(value, ",") {
+		if pair = strings.TrimSpace(pair); pair == "" {
+			continue
+		}
+		group, list, ok := strings.Cut(pair, "=")
+		if !ok || strings.TrimSpace(group) == "" {
+			return nil, fmt.Errorf("invalid group scopes %q, expected group=scope;scope", pair)
+		}
+		scopes, err := ParseScopes(list)
+		if err != nil {
+			return nil, err
+		}
+		if len(scopes) == 0 {
+			return nil, fmt.Errorf("group %s has no scopes", group)
+		}
+		group = strings.TrimSpace(group)
+		groupScopes[group] = append(groupScopes[group], scopes...)
+	}
+	return groupScopes, nil
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
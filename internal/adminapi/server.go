@@ -0,0 +1,526 @@
+// Package adminapi serves an authenticated HTTP API for administering
+// ZomboidServers from outside the cluster, for dashboards and chat bots that
+// shouldn't be given Kubernetes credentials.
+package adminapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	zomboidv1 "github.com/zomboidhost/zomboid-operator/api/v1"
+	"github.com/zomboidhost/zomboid-operator/internal/controller"
+	"github.com/zomboidhost/zomboid-operator/internal/players"
+	"github.com/zomboidhost/zomboid-operator/internal/rcon"
+	"github.com/zomboidhost/zomboid-operator/internal/settings"
+)
+
+// Options configure a Server
+type Options struct {
+	// BindAddress is the address the API listens on
+	BindAddress string
+
+	// CertFile and KeyFile serve the API over TLS
+	CertFile string
+	KeyFile  string
+
+	// Insecure serves the API over plain HTTP when there's no certificate,
+	// for when TLS is terminated in front of it
+	Insecure bool
+
+	// Authenticators are tried in turn until one recognises a request
+	Authenticators []Authenticator
+}
+
+// Server is the admin API.  It's added to the manager as a Runnable, and acts
+// on servers through the ZomboidServer reconciler's own RCON sessions.
+type Server struct {
+	client  client.Client
+	servers *controller.ZomboidServerReconciler
+	options Options
+	audit   logr.Logger
+}
+
+func NewServer(c client.Client, servers *controller.ZomboidServerReconciler, options Options) *Server {
+	return &Server{
+		client:  c,
+		servers: servers,
+		options: options,
+		audit:   ctrl.Log.WithName("admin-api").WithName("audit"),
+	}
+}
+
+// Start implements manager.Runnable, serving the API until ctx is done.  It
+// refuses to send bearer tokens over plain HTTP unless told it's insecure.
+func (s *Server) Start(ctx context.Context) error {
+	serveTLS := s.options.CertFile != "" || s.options.KeyFile != ""
+	if serveTLS && (s.options.CertFile == "" || s.options.KeyFile == "") {
+		return fmt.Errorf("the admin API needs both a TLS certificate and key")
+	}
+	if !serveTLS {
+		if !s.options.Insecure {
+			return fmt.Errorf("the admin API needs a TLS certificate and key, or to be allowed to serve plain HTTP")
+		}
+		ctrl.Log.WithName("admin-api").Info("WARNING: serving the admin API over plain HTTP, so bearer tokens are sent in the clear")
+	}
+
+	server := &http.Server{
+		Addr:              s.options.BindAddress,
+		Handler:           s.Handler(),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	errs := make(chan error, 1)
+	go func() {
+		if serveTLS {
+			errs <- server.ListenAndServeTLS(s.options.CertFile, s.options.KeyFile)
+		} else {
+			errs <- server.ListenAndServe()
+		}
+	}()
+
+	select {
+	case err := <-errs:
+		return err
+	case <-ctx.Done():
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		return server.Shutdown(shutdownCtx)
+	}
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable.  Every
+// replica can serve the API, since RCON sessions are opened on demand.
+func (s *Server) NeedLeaderElection() bool {
+	return false
+}
+
+// Handler returns the API's routes
+func (s *Server) Handler() http.Handler {
+	const server = "/api/v1/namespaces/{namespace}/servers/{name}"
+
+	mux := http.NewServeMux()
+	mux.Handle("GET /api/v1/namespaces/{namespace}/servers", s.route(RoleViewer, s.listServers))
+	mux.Handle("GET "+server, s.route(RoleViewer, s.getServer))
+	mux.Handle("GET "+server+"/players", s.route(RoleViewer, s.getPlayers))
+	mux.Handle("GET "+server+"/allowlist", s.route(RoleViewer, s.getAllowlist))
+	mux.Handle("GET "+server+"/settings/diff", s.route(RoleViewer, s.getSettingsDiff))
+	mux.Handle("POST "+server+"/broadcast", s.route(RoleModerator, s.broadcast))
+	mux.Handle("POST "+server+"/kick", s.route(RoleModerator, s.kick))
+	mux.Handle("POST "+server+"/ban", s.route(RoleModerator, s.ban(true)))
+	mux.Handle("POST "+server+"/unban", s.route(RoleModerator, s.ban(false)))
+	mux.Handle("POST "+server+"/restart", s.route(RoleAdmin, s.restart))
+	mux.Handle("POST "+server+"/backup", s.route(RoleAdmin, s.backup))
+	return mux
+}
+
+// apiError is an error with the HTTP status it should be reported with
+type apiError struct {
+	status int
+	err    error
+}
+
+func (e *apiError) Error() string {
+	return e.err.Error()
+}
+
+func errorf(status int, format string, args ...interface{}) error {
+	return &apiError{status: status, err: fmt.Errorf(format, args...)}
+}
+
+// handlerFunc handles an authenticated request, returning the response body
+type handlerFunc func(r *http.Request, identity *Identity) (interface{}, error)
+
+// route authenticates and authorizes requests for a handler, then writes
+// its response and records the request in the audit log
+func (s *Server) route(role Role, handler handlerFunc) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		identity, err := s.authenticate(r)
+		if err != nil {
+			s.audit.Info("Unauthenticated request", "method", r.Method, "path", r.URL.Path, "remoteAddr", r.RemoteAddr)
+			w.Header().Set("WWW-Authenticate", `Bearer realm="zomboid-operator"`)
+			writeJSON(w, http.StatusUnauthorized, map[string]string{"error": err.Error()})
+			return
+		}
+
+		// Scopes are enforced here, so that handlers only ever see servers
+		// the caller may act on
+		granted := identity.RoleFor(r.PathValue("namespace"), r.PathValue("name"))
+		logger := s.audit.WithValues("user", identity.Username, "role", granted.String(),
+			"method", r.Method, "path", r.URL.Path, "remoteAddr", r.RemoteAddr)
+
+		if granted < role {
+			logger.Info("Forbidden request", "status", http.StatusForbidden)
+			writeJSON(w, http.StatusForbidden, map[string]string{
+				"error": fmt.Sprintf("%s requires the %s role", r.URL.Path, role),
+			})
+			return
+		}
+
+		body, err := handler(r, identity)
+		if err != nil {
+			status := http.StatusInternalServerError
+			var apiErr *apiError
+			switch {
+			case errors.As(err, &apiErr):
+				status = apiErr.status
+			case apierrors.IsNotFound(err):
+				status = http.StatusNotFound
+			case errors.Is(err, controller.ErrServerNotReady):
+				status = http.StatusConflict
+			}
+			logger.Info("Request failed", "status", status, "error", err.Error())
+			writeJSON(w, status, map[string]string{"error": err.Error()})
+			return
+		}
+
+		// Reads are too frequent to be worth auditing
+		if r.Method != http.MethodGet {
+			logger.Info("Request succeeded", "status", http.StatusOK)
+		}
+		writeJSON(w, http.StatusOK, body)
+	})
+}
+
+func (s *Server) authenticate(r *http.Request) (*Identity, error) {
+	for _, authenticator := range s.options.Authenticators {
+		identity, err := authenticator.Authenticate(r)
+		if errors.Is(err, ErrNoCredentials) {
+			continue
+		}
+		return identity, err
+	}
+	return nil, fmt.Errorf("a valid bearer token is required")
+}
+
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+// readJSON decodes a request's body into v
+func readJSON(r *http.Request, v interface{}) error {
+	decoder := json.NewDecoder(http.MaxBytesReader(nil, r.Body, 1<<20))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(v); err != nil {
+		return errorf(http.StatusBadRequest, "invalid request body: %w", err)
+	}
+	return nil
+}
+
+func (s *Server) lookupServer(r *http.Request) (*zomboidv1.ZomboidServer, error) {
+	zomboidServer := &zomboidv1.ZomboidServer{}
+	if err := s.client.Get(r.Context(), types.NamespacedName{
+		Name:      r.PathValue("name"),
+		Namespace: r.PathValue("namespace"),
+	}, zomboidServer); err != nil {
+		return nil, err
+	}
+	return zomboidServer, nil
+}
+
+// withRCON runs fn against a server's RCON session, reporting failures to
+// reach the server as a bad gateway
+func (s *Server) withRCON(r *http.Request, zomboidServer *zomboidv1.ZomboidServer, fn func(client rcon.Client) error) error {
+	err := s.servers.WithRCON(r.Context(), zomboidServer, fn)
+	if err != nil && !errors.Is(err, controller.ErrServerNotReady) {
+		return &apiError{status: http.StatusBadGateway, err: err}
+	}
+	return err
+}
+
+// ServerSummary is how a server is listed
+type ServerSummary struct {
+	Name             string `json:"name"`
+	Namespace        string `json:"namespace"`
+	Version          string `json:"version"`
+	Ready            bool   `json:"ready"`
+	Suspended        bool   `json:"suspended"`
+	ConnectedPlayers int    `json:"connectedPlayers"`
+}
+
+func summarize(zomboidServer *zomboidv1.ZomboidServer) ServerSummary {
+	return ServerSummary{
+		Name:             zomboidServer.Name,
+		Namespace:        zomboidServer.Namespace,
+		Version:          zomboidServer.Spec.Version,
+		Ready:            zomboidServer.Status.Ready,
+		Suspended:        zomboidServer.Spec.Suspended != nil && *zomboidServer.Spec.Suspended,
+		ConnectedPlayers: len(zomboidServer.Status.ConnectedPlayers),
+	}
+}
+
+// listServers lists the servers in a namespace the caller can view
+func (s *Server) listServers(r *http.Request, identity *Identity) (interface{}, error) {
+	zomboidServers := &zomboidv1.ZomboidServerList{}
+	if err := s.client.List(r.Context(), zomboidServers, client.InNamespace(r.PathValue("namespace"))); err != nil {
+		return nil, err
+	}
+
+	summaries := []ServerSummary{}
+	for i := range zomboidServers.Items {
+		zomboidServer := &zomboidServers.Items[i]
+		if identity.RoleFor(zomboidServer.Namespace, zomboidServer.Name) < RoleViewer {
+			continue
+		}
+		summaries = append(summaries, summarize(zomboidServer))
+	}
+	return summaries, nil
+}
+
+// ServerStatus is a server's summary along with its conditions
+type ServerStatus struct {
+	ServerSummary
+	Conditions interface{} `json:"conditions,omitempty"`
+}
+
+func (s *Server) getServer(r *http.Request, _ *Identity) (interface{}, error) {
+	zomboidServer, err := s.lookupServer(r)
+	if err != nil {
+		return nil, err
+	}
+	return ServerStatus{
+		ServerSummary: summarize(zomboidServer),
+		Conditions:    zomboidServer.Status.Conditions,
+	}, nil
+}
+
+func (s *Server) getPlayers(r *http.Request, _ *Identity) (interface{}, error) {
+	zomboidServer, err := s.lookupServer(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var connected []string
+	if err := s.withRCON(r, zomboidServer, func(client rcon.Client) (err error) {
+		connected, err = players.GetConnectedPlayers(r.Context(), client)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	result := []zomboidv1.ConnectedPlayer{}
+	for _, username := range connected {
+		result = append(result, zomboidv1.ConnectedPlayer{Username: username})
+	}
+	return result, nil
+}
+
+func (s *Server) getAllowlist(r *http.Request, _ *Identity) (interface{}, error) {
+	zomboidServer, err := s.lookupServer(r)
+	if err != nil {
+		return nil, err
+	}
+
+	allowlist := []zomboidv1.AllowlistUser{}
+	for _, user := range zomboidServer.Status.Allowlist {
+		user.HashedPassword = ""
+		allowlist = append(allowlist, user)
+	}
+	return allowlist, nil
+}
+
+// SettingChange is a setting the operator will change on a server
+type SettingChange struct {
+	Name     string `json:"name"`
+	Observed string `json:"observed"`
+	Desired  string `json:"desired"`
+}
+
+func (s *Server) getSettingsDiff(r *http.Request, _ *Identity) (interface{}, error) {
+	zomboidServer, err := s.lookupServer(r)
+	if err != nil {
+		return nil, err
+	}
+	if zomboidServer.Status.Settings == nil {
+		return nil, errorf(http.StatusConflict, "the operator hasn't observed the settings of %s yet", zomboidServer.Name)
+	}
+
+	observed := map[string]string{}
+	for _, value := range settings.Values(*zomboidServer.Status.Settings) {
+		observed[value[0]] = value[1]
+	}
+
+	changes := []SettingChange{}
+	for _, update := range settings.ManagedUpdates(zomboidServer.Spec.SettingsPolicy, settings.Desired(zomboidServer), *zomboidServer.Status.Settings) {
+		changes = append(changes, SettingChange{Name: update[0], Observed: observed[update[0]], Desired: update[1]})
+	}
+	return changes, nil
+}
+
+// BroadcastRequest is the body of a broadcast
+type BroadcastRequest struct {
+	Message string `json:"message"`
+}
+
+func (s *Server) broadcast(r *http.Request, _ *Identity) (interface{}, error) {
+	var request BroadcastRequest
+	if err := readJSON(r, &request); err != nil {
+		return nil, err
+	}
+	if request.Message == "" || strings.ContainsAny(request.Message, "\"\n") {
+		return nil, errorf(http.StatusBadRequest, "message must be a single line without double quotes")
+	}
+
+	zomboidServer, err := s.lookupServer(r)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.withRCON(r, zomboidServer, func(client rcon.Client) error {
+		return players.SendServerMessage(r.Context(), client, request.Message)
+	}); err != nil {
+		return nil, err
+	}
+	return map[string]string{"message": "Message sent"}, nil
+}
+
+// PlayerRequest is the body of a kick, ban or unban
+type PlayerRequest struct {
+	Username string `json:"username"`
+	Reason   string `json:"reason,omitempty"`
+}
+
+func readPlayerRequest(r *http.Request) (*PlayerRequest, error) {
+	var request PlayerRequest
+	if err := readJSON(r, &request); err != nil {
+		return nil, err
+	}
+	if request.Username == "" || strings.ContainsAny(request.Username+request.Reason, "\"\n") {
+		return nil, errorf(http.StatusBadRequest, "username is required, and can't contain double quotes")
+	}
+	return &request, nil
+}
+
+func (s *Server) kick(r *http.Request, _ *Identity) (interface{}, error) {
+	request, err := readPlayerRequest(r)
+	if err != nil {
+		return nil, err
+	}
+
+	zomboidServer, err := s.lookupServer(r)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.withRCON(r, zomboidServer, func(client rcon.Client) error {
+		return players.KickUser(r.Context(), client, request.Username, request.Reason)
+	}); err != nil {
+		return nil, err
+	}
+	return map[string]string{"message": fmt.Sprintf("Kicked %s", request.Username)}, nil
+}
+
+// ban bans or unbans a user.  Users declared in the server's spec are banned
+// through the spec, so the reconciler doesn't undo it.
+func (s *Server) ban(ban bool) handlerFunc {
+	verb := "Unbanned"
+	if ban {
+		verb = "Banned"
+	}
+
+	return func(r *http.Request, _ *Identity) (interface{}, error) {
+		request, err := readPlayerRequest(r)
+		if err != nil {
+			return nil, err
+		}
+
+		zomboidServer, err := s.lookupServer(r)
+		if err != nil {
+			return nil, err
+		}
+
+		for i, user := range zomboidServer.Spec.Users {
+			if user.Username != request.Username {
+				continue
+			}
+
+			patch := client.MergeFrom(zomboidServer.DeepCopy())
+			zomboidServer.Spec.Users[i].Banned = ban
+			if err := s.client.Patch(r.Context(), zomboidServer, patch); err != nil {
+				return nil, fmt.Errorf("failed to update ZomboidServer: %w", err)
+			}
+			return map[string]string{"message": fmt.Sprintf("%s %s in the ZomboidServer spec", verb, request.Username)}, nil
+		}
+
+		if err := s.withRCON(r, zomboidServer, func(client rcon.Client) error {
+			if ban {
+				return players.BanUser(r.Context(), client, request.Username)
+			}
+			return players.UnbanUser(r.Context(), client, request.Username)
+		}); err != nil {
+			return nil, err
+		}
+		return map[string]string{"message": fmt.Sprintf("%s %s", verb, request.Username)}, nil
+	}
+}
+
+func (s *Server) restart(r *http.Request, _ *Identity) (interface{}, error) {
+	zomboidServer, err := s.lookupServer(r)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.servers.Restart(r.Context(), zomboidServer); err != nil {
+		if errors.Is(err, controller.ErrServerNotReady) {
+			return nil, err
+		}
+		return nil, &apiError{status: http.StatusBadGateway, err: err}
+	}
+	return map[string]string{"message": fmt.Sprintf("Restarted %s", zomboidServer.Name)}, nil
+}
+
+// BackupRequest is the body of a backup.  Plan, if set, only runs that
+// ZomboidBackupPlan rather than all of the server's plans.
+type BackupRequest struct {
+	Plan string `json:"plan,omitempty"`
+}
+
+func (s *Server) backup(r *http.Request, _ *Identity) (interface{}, error) {
+	var request BackupRequest
+	if r.ContentLength != 0 {
+		if err := readJSON(r, &request); err != nil {
+			return nil, err
+		}
+	}
+
+	zomboidServer, err := s.lookupServer(r)
+	if err != nil {
+		return nil, err
+	}
+
+	backupPlans := &zomboidv1.ZomboidBackupPlanList{}
+	if err := s.client.List(r.Context(), backupPlans, client.InNamespace(zomboidServer.Namespace)); err != nil {
+		return nil, fmt.Errorf("failed to list ZomboidBackupPlans: %w", err)
+	}
+
+	jobs := []string{}
+	for i := range backupPlans.Items {
+		backupPlan := &backupPlans.Items[i]
+		if backupPlan.Spec.Server.Name != zomboidServer.Name || (request.Plan != "" && backupPlan.Name != request.Plan) {
+			continue
+		}
+
+		job, err := controller.RunBackupPlan(r.Context(), s.client, backupPlan)
+		if err != nil {
+			return nil, err
+		}
+		jobs = append(jobs, job.Name)
+	}
+
+	if len(jobs) == 0 {
+		return nil, errorf(http.StatusNotFound, "no ZomboidBackupPlans found for ZomboidServer %s", zomboidServer.Name)
+	}
+	return map[string][]string{"jobs": jobs}, nil
+}
@@ -0,0 +1,325 @@
+package adminapi
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	clientfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	zomboidv1 "github.com/zomboidhost/zomboid-operator/api/v1"
+	"github.com/zomboidhost/zomboid-operator/internal/controller"
+	"github.com/zomboidhost/zomboid-operator/internal/rcon"
+	"github.com/zomboidhost/zomboid-operator/internal/rcon/fake"
+)
+
+var _ = Describe("Admin API", func() {
+	const namespace = "games"
+	const serverPath = "/api/v1/namespaces/games/servers/test-server"
+
+	var (
+		ctx       context.Context
+		server    *fake.Server
+		k8sClient client.Client
+		recorder  *record.FakeRecorder
+		handler   http.Handler
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+
+		var err error
+		server, err = fake.NewServer("rcon-password")
+		Expect(err).NotTo(HaveOccurred())
+		DeferCleanup(server.Close)
+
+		scheme := runtime.NewScheme()
+		Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+		Expect(zomboidv1.AddToScheme(scheme)).To(Succeed())
+
+		k8sClient = clientfake.NewClientBuilder().WithScheme(scheme).WithObjects(
+			&zomboidv1.ZomboidServer{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-server", Namespace: namespace},
+				Spec: zomboidv1.ZomboidServerSpec{
+					Version: "latest",
+					Storage: zomboidv1.Storage{Request: resource.MustParse("10Gi")},
+					RCON: &zomboidv1.RCON{
+						Password: &corev1.SecretKeySelector{
+							LocalObjectReference: corev1.LocalObjectReference{Name: "passwords"},
+							Key:                  "rcon",
+						},
+					},
+					Users: []zomboidv1.User{{Username: "alice"}},
+					Settings: zomboidv1.ZomboidSettings{
+						Player: zomboidv1.Player{MaxPlayers: ptr.To(int32(16))},
+					},
+				},
+				Status: zomboidv1.ZomboidServerStatus{
+					Ready: true,
+					Allowlist: []zomboidv1.AllowlistUser{
+						{Username: "alice", AccessLevel: "player", HashedPassword: "$2a$12$secret"},
+					},
+					Settings: &zomboidv1.ZomboidSettings{
+						Player: zomboidv1.Player{MaxPlayers: ptr.To(int32(32))},
+					},
+				},
+			},
+			&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "passwords", Namespace: namespace},
+				Data:       map[string][]byte{"rcon": []byte("rcon-password")},
+			},
+		).Build()
+
+		recorder = record.NewFakeRecorder(10)
+		reconciler := &controller.ZomboidServerReconciler{
+			Client:   k8sClient,
+			Scheme:   scheme,
+			RCON:     rcon.NewManager(),
+			Recorder: recorder,
+			ServiceEndpoint: func(ctx context.Context, name, namespace string, port int) (string, int, func(), error) {
+				host, port, err := splitHostPort(server.RCONAddr())
+				return host, port, func() {}, err
+			},
+		}
+
+		tokens, err := ReadTokens(strings.NewReader(
+			"# token,username,role\n" +
+				"viewer-token,viewer,viewer\n" +
+				"moderator-token,moderator,moderator\n" +
+				"admin-token,admin,admin\n" +
+				"lobby-token,lobby,admin,lobby;games/other-server\n",
+		))
+		Expect(err).NotTo(HaveOccurred())
+
+		handler = NewServer(k8sClient, reconciler, Options{
+			Authenticators: []Authenticator{tokens},
+		}).Handler()
+	})
+
+	request := func(method, path, token, body string) (int, string) {
+		r := httptest.NewRequest(method, path, strings.NewReader(body))
+		if token != "" {
+			r.Header.Set("Authorization", "Bearer "+token)
+		}
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+		return w.Code, w.Body.String()
+	}
+
+	It("should require a valid token", func() {
+		status, _ := request("GET", serverPath, "", "")
+		Expect(status).To(Equal(http.StatusUnauthorized))
+
+		status, _ = request("GET", serverPath, "not-a-token", "")
+		Expect(status).To(Equal(http.StatusUnauthorized))
+
+		status, _ = request("GET", serverPath, "viewer-token", "")
+		Expect(status).To(Equal(http.StatusOK))
+	})
+
+	It("should limit actions by role", func() {
+		status, _ := request("POST", serverPath+"/kick", "viewer-token", `{"username": "bob"}`)
+		Expect(status).To(Equal(http.StatusForbidden))
+
+		status, _ = request("POST", serverPath+"/restart", "moderator-token", "")
+		Expect(status).To(Equal(http.StatusForbidden))
+		Expect(server.Restarts()).To(BeZero())
+	})
+
+	It("should limit tokens to their scopes", func() {
+		status, _ := request("GET", serverPath, "lobby-token", "")
+		Expect(status).To(Equal(http.StatusForbidden))
+
+		status, _ = request("POST", serverPath+"/restart", "lobby-token", "")
+		Expect(status).To(Equal(http.StatusForbidden))
+		Expect(server.Restarts()).To(BeZero())
+
+		status, body := request("GET", "/api/v1/namespaces/games/servers", "lobby-token", "")
+		Expect(status).To(Equal(http.StatusOK))
+		Expect(body).To(MatchJSON(`[]`))
+	})
+
+	It("should list servers and report their status", func() {
+		status, body := request("GET", "/api/v1/namespaces/games/servers", "viewer-token", "")
+		Expect(status).To(Equal(http.StatusOK))
+
+		var summaries []ServerSummary
+		Expect(json.Unmarshal([]byte(body), &summaries)).To(Succeed())
+		Expect(summaries).To(Equal([]ServerSummary{
+			{Name: "test-server", Namespace: namespace, Version: "latest", Ready: true},
+		}))
+
+		status, _ = request("GET", "/api/v1/namespaces/games/servers/missing", "viewer-token", "")
+		Expect(status).To(Equal(http.StatusNotFound))
+	})
+
+	It("should list connected players over RCON", func() {
+		server.ConnectPlayer("alice")
+
+		status, body := request("GET", serverPath+"/players", "viewer-token", "")
+		Expect(status).To(Equal(http.StatusOK))
+		Expect(body).To(MatchJSON(`[{"username": "alice"}]`))
+	})
+
+	It("should not reveal the allowlist's password hashes", func() {
+		status, body := request("GET", serverPath+"/allowlist", "viewer-token", "")
+		Expect(status).To(Equal(http.StatusOK))
+		Expect(body).To(ContainSubstring(`"alice"`))
+		Expect(body).NotTo(ContainSubstring("secret"))
+	})
+
+	It("should show the settings the operator will change", func() {
+		status, body := request("GET", serverPath+"/settings/diff", "viewer-token", "")
+		Expect(status).To(Equal(http.StatusOK))
+
+		var changes []SettingChange
+		Expect(json.Unmarshal([]byte(body), &changes)).To(Succeed())
+		Expect(changes).To(ContainElement(SettingChange{Name: "MaxPlayers", Observed: "32", Desired: "16"}))
+	})
+
+	It("should broadcast messages and kick players", func() {
+		server.ConnectPlayer("griefer")
+
+		status, _ := request("POST", serverPath+"/broadcast", "moderator-token", `{"message": "Be nice"}`)
+		Expect(status).To(Equal(http.StatusOK))
+		status, _ = request("POST", serverPath+"/kick", "moderator-token", `{"username": "griefer", "reason": "Griefing"}`)
+		Expect(status).To(Equal(http.StatusOK))
+
+		Expect(server.Messages()).To(Equal([]string{"Be nice"}))
+		Expect(server.Commands()).To(ContainElement(`kickuser "griefer" -r "Griefing"`))
+
+		status, _ = request("POST", serverPath+"/broadcast", "moderator-token", `{"message": "\"quoted\""}`)
+		Expect(status).To(Equal(http.StatusBadRequest))
+	})
+
+	It("should ban declared users through the spec", func() {
+		status, _ := request("POST", serverPath+"/ban", "moderator-token", `{"username": "alice"}`)
+		Expect(status).To(Equal(http.StatusOK))
+
+		zomboidServer := &zomboidv1.ZomboidServer{}
+		Expect(k8sClient.Get(ctx, types.NamespacedName{Name: "test-server", Namespace: namespace}, zomboidServer)).To(Succeed())
+		Expect(zomboidServer.Spec.Users[0].Banned).To(BeTrue())
+		Expect(server.Commands()).To(BeEmpty())
+	})
+
+	It("should restart servers through the reconciler", func() {
+		status, _ := request("POST", serverPath+"/restart", "admin-token", "")
+		Expect(status).To(Equal(http.StatusOK))
+		Expect(server.Restarts()).To(Equal(1))
+		Expect(recorder.Events).To(Receive(Equal("Normal Restarting Restarting the server on request")))
+	})
+
+	It("should refuse to reach servers that aren't ready", func() {
+		zomboidServer := &zomboidv1.ZomboidServer{}
+		Expect(k8sClient.Get(ctx, types.NamespacedName{Name: "test-server", Namespace: namespace}, zomboidServer)).To(Succeed())
+		zomboidServer.Status.Ready = false
+		Expect(k8sClient.Update(ctx, zomboidServer)).To(Succeed())
+
+		status, _ := request("POST", serverPath+"/restart", "admin-token", "")
+		Expect(status).To(Equal(http.StatusConflict))
+		Expect(server.Restarts()).To(BeZero())
+	})
+
+	It("should report when a server has no backup plans", func() {
+		status, _ := request("POST", serverPath+"/backup", "admin-token", "")
+		Expect(status).To(Equal(http.StatusNotFound))
+	})
+
+	It("should refuse to serve plain HTTP unless told it's insecure", func() {
+		err := NewServer(k8sClient, nil, Options{BindAddress: "127.0.0.1:0"}).Start(ctx)
+		Expect(err).To(MatchError(ContainSubstring("TLS certificate and key")))
+
+		err = NewServer(k8sClient, nil, Options{BindAddress: "127.0.0.1:0", CertFile: "tls.crt"}).Start(ctx)
+		Expect(err).To(HaveOccurred())
+	})
+})
+
+var _ = Describe("Authenticators", func() {
+	It("should reject malformed token files", func() {
+		_, err := ReadTokens(strings.NewReader("token,user,superuser\n"))
+		Expect(err).To(MatchError(ContainSubstring("unknown role")))
+
+		_, err = ReadTokens(strings.NewReader("token,user\n"))
+		Expect(err).To(HaveOccurred())
+
+		_, err = ReadTokens(strings.NewReader("token,user,admin,games/\n"))
+		Expect(err).To(MatchError(ContainSubstring("invalid scope")))
+	})
+
+	It("should map OIDC groups to roles", func() {
+		authenticator := &OIDCAuthenticator{options: OIDCOptions{
+			UsernameClaim:   "email",
+			AdminGroups:     []string{"ops"},
+			ModeratorGroups: []string{"mods"},
+		}}
+
+		identity, err := authenticator.identity(map[string]interface{}{
+			"email":  "jo@example.com",
+			"groups": []interface{}{"mods", "players"},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(identity.Username).To(Equal("jo@example.com"))
+		Expect(identity.RoleFor("games", "test-server")).To(Equal(RoleModerator))
+
+		identity, err = authenticator.identity(map[string]interface{}{
+			"email":  "sam@example.com",
+			"groups": []interface{}{"ops", "mods"},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(identity.RoleFor("games", "test-server")).To(Equal(RoleAdmin))
+
+		_, err = authenticator.identity(map[string]interface{}{"sub": "1234"})
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should limit OIDC groups to their scopes", func() {
+		groupScopes, err := ParseGroupScopes("mods=games/test-server, players=games;lobby")
+		Expect(err).NotTo(HaveOccurred())
+		authenticator := &OIDCAuthenticator{options: OIDCOptions{
+			UsernameClaim:   "email",
+			ModeratorGroups: []string{"mods"},
+			GroupScopes:     groupScopes,
+		}}
+
+		identity, err := authenticator.identity(map[string]interface{}{
+			"email":  "jo@example.com",
+			"groups": []interface{}{"mods", "players"},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(identity.RoleFor("games", "test-server")).To(Equal(RoleModerator))
+		Expect(identity.RoleFor("games", "other-server")).To(Equal(RoleViewer))
+		Expect(identity.RoleFor("lobby", "test-server")).To(Equal(RoleViewer))
+		Expect(identity.RoleFor("private", "test-server")).To(BeZero())
+
+		identity, err = authenticator.identity(map[string]interface{}{"email": "sam@example.com"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(identity.RoleFor("games", "test-server")).To(BeZero())
+
+		_, err = ParseGroupScopes("mods")
+		Expect(err).To(HaveOccurred())
+	})
+})
+
+func splitHostPort(addr string) (string, int, error) {
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		return "", 0, err
+	}
+	p, err := strconv.Atoi(port)
+	return host, p, err
+}
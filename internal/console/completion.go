@@ -0,0 +1,180 @@
+package console
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/zomboidhost/zomboid-operator/internal/players"
+	"github.com/zomboidhost/zomboid-operator/internal/rcon"
+)
+
+// commands are the server console commands offered for completion, mapped to
+// the positions of their arguments that name a player
+var commands = map[string][]int{
+	"additem":                 {1},
+	"adduser":                 nil,
+	"addvehicle":              {2},
+	"addxp":                   {1},
+	"alarm":                   nil,
+	"banid":                   nil,
+	"banuser":                 {1},
+	"changeoption":            nil,
+	"checkModsNeedUpdate":     nil,
+	"chopper":                 nil,
+	"createhorde":             {2},
+	"godmod":                  {1},
+	"grantadmin":              {1},
+	"gunshot":                 nil,
+	"help":                    nil,
+	"invisible":               {1},
+	"kickuser":                {1},
+	"lightning":               {1},
+	"log":                     nil,
+	"noclip":                  {1},
+	"players":                 nil,
+	"quit":                    nil,
+	"releasesafehouse":        nil,
+	"reloadlua":               nil,
+	"reloadoptions":           nil,
+	"removeadmin":             {1},
+	"removeuserfromwhitelist": {1},
+	"removezombies":           nil,
+	"save":                    nil,
+	"servermsg":               nil,
+	"setaccesslevel":          {1},
+	"showoptions":             nil,
+	"startrain":               nil,
+	"startstorm":              nil,
+	"stoprain":                nil,
+	"stopweather":             nil,
+	"teleport":                {1, 2},
+	"thunder":                 {1},
+	"unbanid":                 nil,
+	"unbanuser":               {1},
+	"voiceban":                {1},
+}
+
+// playerCache remembers the connected players briefly, so that repeatedly
+// pressing tab doesn't send a command to the server each time
+type playerCache struct {
+	session *rcon.Session
+
+	mu        sync.Mutex
+	players   []string
+	fetchedAt time.Time
+}
+
+const playerCacheTTL = 5 * time.Second
+
+func (p *playerCache) get(ctx context.Context) []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if time.Since(p.fetchedAt) < playerCacheTTL {
+		return p.players
+	}
+
+	var connected []string
+	err := p.session.Do(ctx, func(client rcon.Client) (err error) {
+		connected, err = players.GetConnectedPlayers(ctx, client)
+		return err
+	})
+	if err != nil {
+		return p.players
+	}
+
+	sort.Strings(connected)
+	p.players = connected
+	p.fetchedAt = time.Now()
+	return p.players
+}
+
+// completer completes command names, and the names of online players for
+// arguments that take one
+type completer struct {
+	ctx     context.Context
+	players *playerCache
+}
+
+// Do implements readline.AutoCompleter, returning the suffixes that complete
+// the word under the cursor along with the length of that word
+func (c *completer) Do(line []rune, pos int) ([][]rune, int) {
+	text := strings.TrimPrefix(strings.TrimLeft(string(line[:pos]), " "), "/")
+	words, current := splitWords(text)
+
+	var candidates []string
+	if len(words) == 0 {
+		for name := range commands {
+			candidates = append(candidates, name+" ")
+		}
+	} else {
+		positions := commands[words[0]]
+		if !contains(positions, len(words)) {
+			return nil, 0
+		}
+		for _, player := range c.players.get(c.ctx) {
+			// Names can only be completed without quotes if they were
+			// started without them, and don't need them
+			if current != "" && !strings.HasPrefix(current, `"`) {
+				if !strings.Contains(player, " ") {
+					candidates = append(candidates, player+" ")
+				}
+				continue
+			}
+			candidates = append(candidates, quote(player)+" ")
+		}
+	}
+	sort.Strings(candidates)
+
+	var suffixes [][]rune
+	for _, candidate := range candidates {
+		if strings.HasPrefix(candidate, current) {
+			suffixes = append(suffixes, []rune(candidate[len(current):]))
+		}
+	}
+	return suffixes, len([]rune(current))
+}
+
+// splitWords splits a partial command line into its complete words and the
+// word being typed, keeping quoted arguments together
+func splitWords(text string) ([]string, string) {
+	var words []string
+	var word strings.Builder
+	quoted := false
+	started := false
+
+	for _, r := range text {
+		switch {
+		case r == '"':
+			quoted = !quoted
+			started = true
+			word.WriteRune(r)
+		case r == ' ' && !quoted:
+			if started {
+				words = append(words, strings.Trim(word.String(), `"`))
+				word.Reset()
+				started = false
+			}
+		default:
+			started = true
+			word.WriteRune(r)
+		}
+	}
+	return words, word.String()
+}
+
+func quote(s string) string {
+	return `"` + s + `"`
+}
+
+func contains(values []int, value int) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,205 @@
+package console
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/chzyer/readline"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	zomboidv1 "github.com/zomboidhost/zomboid-operator/api/v1"
+	"github.com/zomboidhost/zomboid-operator/internal/controller"
+	"github.com/zomboidhost/zomboid-operator/internal/rcon"
+)
+
+// Run opens an interactive RCON console to a ZomboidServer, resolved from the
+// current kubeconfig context
+func Run(ctx context.Context, args []string) error {
+	flags := flag.NewFlagSet("console", flag.ContinueOnError)
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "Usage: %s console [flags] SERVER\n\n", filepath.Base(os.Args[0]))
+		flags.PrintDefaults()
+	}
+
+	var namespace, kubeconfig string
+	flags.StringVar(&namespace, "namespace", "", "The namespace of the ZomboidServer. Defaults to the kubeconfig context's namespace.")
+	flags.StringVar(&namespace, "n", "", "Shorthand for --namespace.")
+	flags.StringVar(&kubeconfig, "kubeconfig", "", "Path to a kubeconfig. Defaults to $KUBECONFIG or ~/.kube/config.")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() != 1 {
+		flags.Usage()
+		return fmt.Errorf("expected the name of a ZomboidServer")
+	}
+
+	loadingRules := clientcmd.NewDefaultClientConfigLoadingRules()
+	loadingRules.ExplicitPath = kubeconfig
+	clientConfig := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(loadingRules, &clientcmd.ConfigOverrides{
+		Context: clientcmdapi.Context{Namespace: namespace},
+	})
+
+	config, err := clientConfig.ClientConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load kubeconfig: %w", err)
+	}
+	namespace, _, err = clientConfig.Namespace()
+	if err != nil {
+		return fmt.Errorf("failed to determine namespace: %w", err)
+	}
+
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		return err
+	}
+	if err := zomboidv1.AddToScheme(scheme); err != nil {
+		return err
+	}
+
+	k8sClient, err := client.New(config, client.Options{Scheme: scheme})
+	if err != nil {
+		return fmt.Errorf("failed to create client: %w", err)
+	}
+
+	zomboidServer := &zomboidv1.ZomboidServer{}
+	if err := k8sClient.Get(ctx, types.NamespacedName{Name: flags.Arg(0), Namespace: namespace}, zomboidServer); err != nil {
+		return fmt.Errorf("failed to get ZomboidServer: %w", err)
+	}
+
+	password, err := controller.GetRCONPassword(ctx, k8sClient, zomboidServer)
+	if err != nil {
+		return err
+	}
+
+	session := rcon.NewSession(zomboidServer.Name, password, func(ctx context.Context) (string, func(), error) {
+		hostname, port, cleanup, err := controller.GetServiceEndpoint(ctx, config, k8sClient,
+			zomboidServer.Name+"-rcon",
+			zomboidServer.Namespace,
+			27015,
+		)
+		if err != nil {
+			return "", cleanup, err
+		}
+		return fmt.Sprintf("%s:%d", hostname, port), cleanup, nil
+	})
+	defer session.Close()
+
+	if err := session.Connect(ctx); err != nil {
+		return err
+	}
+
+	return New(session).Run(ctx, zomboidServer.Name)
+}
+
+// Console is an interactive RCON console to a single server
+type Console struct {
+	session *rcon.Session
+	players *playerCache
+}
+
+// New returns a Console that sends commands over the given session
+func New(session *rcon.Session) *Console {
+	return &Console{
+		session: session,
+		players: &playerCache{session: session},
+	}
+}
+
+// Run reads commands until the user exits, printing each response
+func (c *Console) Run(ctx context.Context, serverName string) error {
+	historyFile := ""
+	if home, err := os.UserHomeDir(); err == nil {
+		historyFile = filepath.Join(home, ".zomboid_console_history")
+	}
+
+	rl, err := readline.NewEx(&readline.Config{
+		Prompt:          serverName + "> ",
+		HistoryFile:     historyFile,
+		AutoComplete:    &completer{ctx: ctx, players: c.players},
+		InterruptPrompt: "^C",
+		EOFPrompt:       "exit",
+	})
+	if err != nil {
+		return fmt.Errorf("failed to start console: %w", err)
+	}
+	defer rl.Close()
+
+	fmt.Fprintln(rl.Stdout(), "Connected to", serverName+". Type \"help\" for the server's commands, or \"exit\" to leave.")
+
+	for {
+		line, err := rl.Readline()
+		if errors.Is(err, readline.ErrInterrupt) {
+			if line == "" {
+				return nil
+			}
+			continue
+		}
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		command := normalize(line)
+		switch command {
+		case "":
+			continue
+		case "exit":
+			return nil
+		case "quit":
+			if !confirm(rl, "This shuts the server down. Are you sure? [y/N] ") {
+				continue
+			}
+		}
+
+		response, err := c.Execute(ctx, command)
+		if err != nil {
+			fmt.Fprintln(rl.Stderr(), "Error:", err)
+			continue
+		}
+		if response != "" {
+			fmt.Fprintln(rl.Stdout(), strings.TrimRight(response, "\n"))
+		}
+	}
+}
+
+// Execute sends a single command to the server and returns its response
+func (c *Console) Execute(ctx context.Context, command string) (string, error) {
+	var response string
+	err := c.session.Do(ctx, func(client rcon.Client) (err error) {
+		response, err = client.Execute(command)
+		return err
+	})
+	return response, err
+}
+
+// normalize trims a line typed into the console, dropping the leading slash
+// players use for commands in game
+func normalize(line string) string {
+	return strings.TrimPrefix(strings.TrimSpace(line), "/")
+}
+
+func confirm(rl *readline.Instance, prompt string) bool {
+	previous := rl.Config.Prompt
+	defer rl.SetPrompt(previous)
+
+	rl.SetPrompt(prompt)
+	answer, err := rl.Readline()
+	if err != nil {
+		return false
+	}
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	return answer == "y" || answer == "yes"
+}
@@ -0,0 +1,89 @@
+package console
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/zomboidhost/zomboid-operator/internal/rcon"
+	"github.com/zomboidhost/zomboid-operator/internal/rcon/fake"
+)
+
+var _ = Describe("Console", func() {
+	var (
+		ctx          context.Context
+		server       *fake.Server
+		console      *Console
+		autoComplete *completer
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+
+		var err error
+		server, err = fake.NewServer("rcon-password")
+		Expect(err).NotTo(HaveOccurred())
+		DeferCleanup(server.Close)
+
+		session := rcon.NewSession("test", "rcon-password", func(ctx context.Context) (string, func(), error) {
+			return server.RCONAddr(), nil, nil
+		})
+		DeferCleanup(session.Close)
+
+		console = New(session)
+		autoComplete = &completer{ctx: ctx, players: console.players}
+	})
+
+	complete := func(line string) ([]string, int) {
+		suffixes, length := autoComplete.Do([]rune(line), len([]rune(line)))
+		var completions []string
+		for _, suffix := range suffixes {
+			completions = append(completions, string(suffix))
+		}
+		return completions, length
+	}
+
+	It("should run commands and return the server's response", func() {
+		server.ConnectPlayer("alice")
+
+		response, err := console.Execute(ctx, normalize("  /players "))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(response).To(ContainSubstring("-alice"))
+		Expect(server.Commands()).To(Equal([]string{"players"}))
+	})
+
+	It("should complete command names", func() {
+		completions, length := complete("kick")
+		Expect(completions).To(Equal([]string{"user "}))
+		Expect(length).To(Equal(4))
+
+		completions, _ = complete("/stop")
+		Expect(completions).To(Equal([]string{"rain ", "weather "}))
+	})
+
+	It("should complete the names of online players", func() {
+		server.ConnectPlayer("alice")
+		server.ConnectPlayer("bob")
+
+		completions, length := complete("kickuser ")
+		Expect(completions).To(Equal([]string{`"alice" `, `"bob" `}))
+		Expect(length).To(Equal(0))
+
+		completions, length = complete(`teleport "alice" "b`)
+		Expect(completions).To(Equal([]string{`ob" `}))
+		Expect(length).To(Equal(2))
+
+		completions, _ = complete("banuser al")
+		Expect(completions).To(Equal([]string{"ice "}))
+	})
+
+	It("should not complete players for other arguments", func() {
+		server.ConnectPlayer("alice")
+
+		completions, _ := complete(`kickuser "alice" `)
+		Expect(completions).To(BeEmpty())
+		completions, _ = complete("servermsg ")
+		Expect(completions).To(BeEmpty())
+	})
+})
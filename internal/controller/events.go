@@ -0,0 +1,25 @@
+package controller
+
+// Reasons for the Events the controllers record, so that `kubectl describe`
+// tells the story of what the operator has done to a server.  Failures reuse
+// the reason of the condition they are recorded against.
+const (
+	EventReasonSettingChanged      = "SettingChanged"
+	EventReasonRestarting          = "Restarting"
+	EventReasonRestartScheduled    = "RestartScheduled"
+	EventReasonWorkshopItemUpdated = "WorkshopItemUpdated"
+
+	EventReasonWorkshopDependencyAdded = "WorkshopDependencyAdded"
+
+	EventReasonUserAdded          = "UserAdded"
+	EventReasonUserRemoved        = "UserRemoved"
+	EventReasonUserBanned         = "UserBanned"
+	EventReasonUserUnbanned       = "UserUnbanned"
+	EventReasonAccessLevelChanged = "AccessLevelChanged"
+	EventReasonPasswordRotated    = "PasswordRotated"
+
+	EventReasonCronJobCreated = "CronJobCreated"
+	EventReasonCronJobUpdated = "CronJobUpdated"
+	EventReasonCronJobDeleted = "CronJobDeleted"
+	EventReasonReconcileError = "ReconcileError"
+)
@@ -0,0 +1,68 @@
+package controller
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/remotecommand"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	zomboidv1 "github.com/zomboidhost/zomboid-operator/api/v1"
+)
+
+func (r *ZomboidServerReconciler) exec(ctx context.Context, zomboidServer *zomboidv1.ZomboidServer, command []string) ([]byte, error) {
+	if r.Exec != nil {
+		return r.Exec(ctx, zomboidServer, command)
+	}
+	if r.Config == nil {
+		return nil, fmt.Errorf("no cluster to run %s in", command[0])
+	}
+
+	return ExecInServer(ctx, r.Config, r.Client, zomboidServer, command)
+}
+
+// ExecInServer runs a command in the game container of a server's running
+// pod and returns what it wrote to stdout
+func ExecInServer(ctx context.Context, config *rest.Config, k8sClient client.Client, zomboidServer *zomboidv1.ZomboidServer, command []string) ([]byte, error) {
+	pods := &corev1.PodList{}
+	if err := k8sClient.List(ctx, pods, client.InNamespace(zomboidServer.Namespace), client.MatchingLabels(commonLabels(zomboidServer))); err != nil {
+		return nil, fmt.Errorf("failed to list pods: %w", err)
+	}
+
+	var pod *corev1.Pod
+	for i := range pods.Items {
+		if pods.Items[i].Status.Phase == corev1.PodRunning && pods.Items[i].DeletionTimestamp == nil {
+			pod = &pods.Items[i]
+			break
+		}
+	}
+	if pod == nil {
+		return nil, fmt.Errorf("no running pod found for ZomboidServer %s", zomboidServer.Name)
+	}
+
+	host, err := url.Parse(config.Host)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the API server's address: %w", err)
+	}
+	execURL := host.JoinPath("api", "v1", "namespaces", pod.Namespace, "pods", pod.Name, "exec")
+	query := url.Values{"container": {"zomboid"}, "stdout": {"true"}, "stderr": {"true"}}
+	query["command"] = command
+	execURL.RawQuery = query.Encode()
+
+	executor, err := remotecommand.NewSPDYExecutor(config, http.MethodPost, execURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create executor: %w", err)
+	}
+
+	var stdout, stderr bytes.Buffer
+	if err := executor.StreamWithContext(ctx, remotecommand.StreamOptions{Stdout: &stdout, Stderr: &stderr}); err != nil {
+		return nil, fmt.Errorf("failed to run %s in pod %s: %w: %s", command[0], pod.Name, err, strings.TrimSpace(stderr.String()))
+	}
+	return stdout.Bytes(), nil
+}
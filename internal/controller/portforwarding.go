@@ -34,12 +34,22 @@ func isRunningInCluster() bool {
 }
 
 func (r *ZomboidServerReconciler) getServiceEndpoint(ctx context.Context, name, namespace string, port int) (string, int, func(), error) {
+	if r.ServiceEndpoint != nil {
+		return r.ServiceEndpoint(ctx, name, namespace, port)
+	}
+
+	return GetServiceEndpoint(ctx, r.Config, r.Client, name, namespace, port)
+}
+
+// GetServiceEndpoint returns a hostname and port that reach a Service's port,
+// port-forwarding to one of its pods when running outside the cluster.  The
+// returned cleanup function must be called once the endpoint is no longer needed.
+func GetServiceEndpoint(ctx context.Context, config *rest.Config, k8sClient client.Client, name, namespace string, port int) (string, int, func(), error) {
 	hostname := fmt.Sprintf("%s.%s.svc.cluster.local", name, namespace)
 	cleanup := func() {}
 
 	if !isRunningInCluster() {
-		parts := strings.Split(hostname, ".")
-		localPort, cleanupFn, err := SetupPortForwarder(ctx, r.Config, r.Client, parts[1], parts[0], port)
+		localPort, cleanupFn, err := SetupPortForwarder(ctx, config, k8sClient, namespace, name, port)
 		if err != nil {
 			return "", 0, cleanup, fmt.Errorf("failed to setup port forwarder: %w", err)
 		}
@@ -2,63 +2,137 @@ package controller
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"time"
 
-	"github.com/gorcon/rcon"
 	zomboidv1 "github.com/zomboidhost/zomboid-operator/api/v1"
+	"github.com/zomboidhost/zomboid-operator/internal/rcon"
+	"github.com/zomboidhost/zomboid-operator/internal/settings"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
-func (r *ZomboidServerReconciler) connectRCON(ctx context.Context, zomboidServer *zomboidv1.ZomboidServer) (*rcon.Conn, func(), error) {
+// ErrServerNotReady is returned when asked to reach a server that isn't ready
+var ErrServerNotReady = errors.New("server is not ready")
+
+// rconSession returns the persistent RCON session for a server, replacing it
+// if the server's RCON password has changed.
+func (r *ZomboidServerReconciler) rconSession(ctx context.Context, zomboidServer *zomboidv1.ZomboidServer) (*rcon.Session, error) {
 	password, err := r.getRCONPassword(ctx, zomboidServer)
 	if err != nil {
-		return nil, nil, err
+		return nil, err
+	}
+
+	key := types.NamespacedName{Name: zomboidServer.Name, Namespace: zomboidServer.Namespace}
+	return r.RCON.Session(key, password, func(ctx context.Context) (string, func(), error) {
+		hostname, port, cleanup, err := r.getServiceEndpoint(ctx,
+			zomboidServer.Name+"-rcon",
+			zomboidServer.Namespace,
+			27015,
+		)
+		if err != nil {
+			return "", cleanup, err
+		}
+		return fmt.Sprintf("%s:%d", hostname, port), cleanup, nil
+	}), nil
+}
+
+// connectRCON makes sure the server's RCON session is connected, recording
+// the outcome in the RCONReachable condition.
+func (r *ZomboidServerReconciler) connectRCON(ctx context.Context, zomboidServer *zomboidv1.ZomboidServer) (*rcon.Session, error) {
+	session, err := r.rconSession(ctx, zomboidServer)
+	if err == nil {
+		err = session.Connect(ctx)
+	}
+	if err != nil {
+		meta.SetStatusCondition(&zomboidServer.Status.Conditions, metav1.Condition{
+			Type:               zomboidv1.TypeRCONReachable,
+			ObservedGeneration: zomboidServer.Generation,
+			Status:             metav1.ConditionFalse,
+			Reason:             zomboidv1.ReasonRCONUnreachable,
+			Message:            err.Error(),
+		})
+		r.Recorder.Event(zomboidServer, corev1.EventTypeWarning, zomboidv1.ReasonRCONUnreachable, err.Error())
+		return nil, err
+	}
+
+	meta.SetStatusCondition(&zomboidServer.Status.Conditions, metav1.Condition{
+		Type:               zomboidv1.TypeRCONReachable,
+		ObservedGeneration: zomboidServer.Generation,
+		Status:             metav1.ConditionTrue,
+		Reason:             zomboidv1.ReasonRCONConnected,
+		Message:            "RCON session is connected",
+	})
+	return session, nil
+}
+
+// WithRCON runs fn against a server's persistent RCON session, the same one
+// the reconciler uses
+func (r *ZomboidServerReconciler) WithRCON(ctx context.Context, zomboidServer *zomboidv1.ZomboidServer, fn func(client rcon.Client) error) error {
+	if !zomboidServer.Status.Ready {
+		return ErrServerNotReady
 	}
 
-	hostname, port, cleanup, err := r.getServiceEndpoint(ctx,
-		zomboidServer.Name+"-rcon",
-		zomboidServer.Namespace,
-		27015,
-	)
+	session, err := r.rconSession(ctx, zomboidServer)
 	if err != nil {
-		cleanup()
-		return nil, nil, err
+		return err
+	}
+	return session.Do(ctx, fn)
+}
+
+// Restart restarts a server over RCON, the same way the reconciler does when
+// its mods change
+func (r *ZomboidServerReconciler) Restart(ctx context.Context, zomboidServer *zomboidv1.ZomboidServer) error {
+	if !zomboidServer.Status.Ready {
+		return ErrServerNotReady
 	}
 
-	address := fmt.Sprintf("%s:%d", hostname, port)
-	conn, err := rcon.Dial(
-		address, password,
-		rcon.SetDialTimeout(5*time.Second),
-		rcon.SetDeadline(5*time.Second),
-	)
+	session, err := r.rconSession(ctx, zomboidServer)
 	if err != nil {
-		cleanup()
-		return nil, nil, fmt.Errorf("failed to connect to RCON: %w", err)
+		return err
+	}
+	if err := restartServer(ctx, session); err != nil {
+		return err
 	}
+	r.Recorder.Event(zomboidServer, corev1.EventTypeNormal, EventReasonRestarting, "Restarting the server on request")
+	return nil
+}
 
-	return conn, func() {
-		cleanup()
-		conn.Close()
-	}, nil
+// restartServer tells the server to save and quit, so that it's restarted
+// by its deployment, and drops the session so the next command reconnects
+func restartServer(ctx context.Context, session *rcon.Session) error {
+	if err := session.Do(ctx, func(client rcon.Client) error {
+		return settings.RestartServer(ctx, client)
+	}); err != nil {
+		return err
+	}
+	session.Reset()
+	return nil
 }
 
 func (r *ZomboidServerReconciler) getRCONPassword(ctx context.Context, zomboidServer *zomboidv1.ZomboidServer) (string, error) {
+	return GetRCONPassword(ctx, r.Client, zomboidServer)
+}
+
+// GetRCONPassword reads a server's RCON password, from the Secret it
+// references or from the one the operator generated for it
+func GetRCONPassword(ctx context.Context, c client.Reader, zomboidServer *zomboidv1.ZomboidServer) (string, error) {
+	selector := rconPasswordSelector(zomboidServer)
+
 	secret := &corev1.Secret{}
-	if err := r.Get(ctx, types.NamespacedName{
-		Name:      zomboidServer.Spec.Administrator.Password.LocalObjectReference.Name,
+	if err := c.Get(ctx, types.NamespacedName{
+		Name:      selector.Name,
 		Namespace: zomboidServer.Namespace,
 	}, secret); err != nil {
 		return "", fmt.Errorf("failed to get RCON secret: %w", err)
 	}
 
-	password := string(secret.Data[zomboidServer.Spec.Administrator.Password.Key])
+	password := string(secret.Data[selector.Key])
 	if password == "" {
-		return "", fmt.Errorf(
-			"RCON password not found in secret %s",
-			zomboidServer.Spec.Administrator.Password.LocalObjectReference.Name,
-		)
+		return "", fmt.Errorf("RCON password not found in secret %s", selector.Name)
 	}
 
 	return password, nil
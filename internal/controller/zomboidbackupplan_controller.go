@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"reflect"
 	"strings"
+	"time"
 
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
@@ -12,6 +13,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
@@ -25,6 +27,9 @@ import (
 type ZomboidBackupPlanReconciler struct {
 	client.Client
 	Scheme *runtime.Scheme
+
+	// Recorder records Events for the CronJobs the reconciler manages
+	Recorder record.EventRecorder
 }
 
 // SetupWithManager sets up the controller with the Manager.
@@ -115,6 +120,7 @@ func (r *ZomboidBackupPlanReconciler) findBackupPlansForDestination(ctx context.
 // +kubebuilder:rbac:groups=zomboid.host,resources=backupdestinations,verbs=get;list;watch
 // +kubebuilder:rbac:groups=batch,resources=cronjobs,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=[""],resources=secrets,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
 
 func (r *ZomboidBackupPlanReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	backupPlan := &zomboidhostv1.ZomboidBackupPlan{}
@@ -150,20 +156,28 @@ func (r *ZomboidBackupPlanReconciler) Reconcile(ctx context.Context, req ctrl.Re
 	}
 
 	if err := r.setOwnerReferences(ctx, backupPlan, server, destination); err != nil {
-		return ctrl.Result{}, fmt.Errorf("failed to set owner references: %w", err)
+		return ctrl.Result{}, r.reconcileError(backupPlan, fmt.Errorf("failed to set owner references: %w", err))
 	}
 
 	if err := r.reconcileApplicationSecret(ctx, backupPlan, server, destination); err != nil {
-		return ctrl.Result{}, fmt.Errorf("failed to reconcile application secret: %w", err)
+		return ctrl.Result{}, r.reconcileError(backupPlan, fmt.Errorf("failed to reconcile application secret: %w", err))
 	}
 
 	if err := r.reconcileCronJob(ctx, backupPlan, server, destination); err != nil {
-		return ctrl.Result{}, fmt.Errorf("failed to reconcile CronJob: %w", err)
+		return ctrl.Result{}, r.reconcileError(backupPlan, fmt.Errorf("failed to reconcile CronJob: %w", err))
 	}
 
 	return ctrl.Result{}, nil
 }
 
+// reconcileError records err as a Warning on the backup plan, and returns it
+func (r *ZomboidBackupPlanReconciler) reconcileError(backupPlan *zomboidhostv1.ZomboidBackupPlan, err error) error {
+	if !errors.IsConflict(err) {
+		r.Recorder.Event(backupPlan, corev1.EventTypeWarning, EventReasonReconcileError, err.Error())
+	}
+	return err
+}
+
 func (r *ZomboidBackupPlanReconciler) setOwnerReferences(ctx context.Context, backupPlan *zomboidhostv1.ZomboidBackupPlan, server *zomboidhostv1.ZomboidServer, destination *zomboidhostv1.BackupDestination) error {
 	originalRefs := backupPlan.GetOwnerReferences()
 
@@ -286,14 +300,7 @@ func (r *ZomboidBackupPlanReconciler) reconcileCronJob(ctx context.Context, back
 	var env []corev1.EnvVar
 	var remotePath string
 	if destination != nil {
-		switch {
-		case destination.Spec.Dropbox != nil:
-			env, remotePath = r.dropboxConfiguration(*destination.Spec.Dropbox, backupPlan)
-		case destination.Spec.GoogleDrive != nil:
-			env, remotePath = r.googleDriveConfiguration(*destination.Spec.GoogleDrive, backupPlan)
-		case destination.Spec.S3 != nil:
-			env, remotePath = r.s3Configuration(*destination.Spec.S3)
-		}
+		env, remotePath = destinationConfiguration(backupPlan, destination)
 	}
 
 	// If no provider is active or server is missing, we shouldn't have a CronJob
@@ -306,7 +313,12 @@ func (r *ZomboidBackupPlanReconciler) reconcileCronJob(ctx context.Context, back
 	}, cronJob)
 
 	if err == nil && !shouldExist {
-		return r.Delete(ctx, cronJob)
+		if err := r.Delete(ctx, cronJob); err != nil {
+			return err
+		}
+		r.Recorder.Event(backupPlan, corev1.EventTypeNormal, EventReasonCronJobDeleted,
+			"Deleted CronJob because the server or destination is missing")
+		return nil
 	} else if err != nil && !errors.IsNotFound(err) {
 		return fmt.Errorf("failed to get CronJob: %w", err)
 	}
@@ -322,7 +334,7 @@ func (r *ZomboidBackupPlanReconciler) reconcileCronJob(ctx context.Context, back
 		},
 	}
 
-	_, err = controllerutil.CreateOrUpdate(ctx, r.Client, cronJob, func() error {
+	result, err := controllerutil.CreateOrUpdate(ctx, r.Client, cronJob, func() error {
 		if err := controllerutil.SetControllerReference(backupPlan, cronJob, r.Scheme); err != nil {
 			return err
 		}
@@ -348,6 +360,7 @@ func (r *ZomboidBackupPlanReconciler) reconcileCronJob(ctx context.Context, back
 
 		cronJob.Spec = batchv1.CronJobSpec{
 			Schedule: backupPlan.Spec.Schedule,
+			TimeZone: backupPlan.Spec.TimeZone,
 			JobTemplate: batchv1.JobTemplateSpec{
 				Spec: batchv1.JobSpec{
 					Template: corev1.PodTemplateSpec{
@@ -371,11 +384,72 @@ func (r *ZomboidBackupPlanReconciler) reconcileCronJob(ctx context.Context, back
 		}
 		return nil
 	})
+	if err != nil {
+		return err
+	}
 
-	return err
+	switch result {
+	case controllerutil.OperationResultCreated:
+		r.Recorder.Eventf(backupPlan, corev1.EventTypeNormal, EventReasonCronJobCreated,
+			"Created CronJob %s to copy backups to %s on schedule %q", cronJob.Name, remotePath, backupPlan.Spec.Schedule)
+	case controllerutil.OperationResultUpdated:
+		r.Recorder.Eventf(backupPlan, corev1.EventTypeNormal, EventReasonCronJobUpdated,
+			"Updated CronJob %s to copy backups to %s on schedule %q", cronJob.Name, remotePath, backupPlan.Spec.Schedule)
+	}
+	return nil
+}
+
+// +kubebuilder:rbac:groups=batch,resources=jobs,verbs=create
+
+// RunBackupPlan starts a backup plan's job now rather than on its schedule,
+// the same way kubectl create job --from=cronjob does
+func RunBackupPlan(ctx context.Context, c client.Client, backupPlan *zomboidhostv1.ZomboidBackupPlan) (*batchv1.Job, error) {
+	cronJob := &batchv1.CronJob{}
+	if err := c.Get(ctx, types.NamespacedName{Name: backupPlan.Name, Namespace: backupPlan.Namespace}, cronJob); err != nil {
+		return nil, fmt.Errorf("failed to get CronJob for ZomboidBackupPlan %s: %w", backupPlan.Name, err)
+	}
+
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        fmt.Sprintf("%s-manual-%d", cronJob.Name, time.Now().Unix()),
+			Namespace:   cronJob.Namespace,
+			Labels:      cronJob.Spec.JobTemplate.Labels,
+			Annotations: map[string]string{"cronjob.kubernetes.io/instantiate": "manual"},
+		},
+		Spec: cronJob.Spec.JobTemplate.Spec,
+	}
+	if err := controllerutil.SetOwnerReference(cronJob, job, c.Scheme()); err != nil {
+		return nil, err
+	}
+
+	if err := c.Create(ctx, job); err != nil {
+		return nil, fmt.Errorf("failed to create job: %w", err)
+	}
+	return job, nil
+}
+
+// destinationConfiguration returns the rclone environment and remote path
+// for a backup plan's destination
+func destinationConfiguration(backupPlan *zomboidhostv1.ZomboidBackupPlan, destination *zomboidhostv1.BackupDestination) ([]corev1.EnvVar, string) {
+	switch {
+	case destination.Spec.Dropbox != nil:
+		return dropboxConfiguration(*destination.Spec.Dropbox, backupPlan)
+	case destination.Spec.GoogleDrive != nil:
+		return googleDriveConfiguration(*destination.Spec.GoogleDrive, backupPlan)
+	case destination.Spec.S3 != nil:
+		return s3Configuration(*destination.Spec.S3)
+	}
+	return nil, ""
+}
+
+// RemotePath returns the rclone remote path a backup plan copies backups
+// to, or "" if its destination has no provider
+func RemotePath(backupPlan *zomboidhostv1.ZomboidBackupPlan, destination *zomboidhostv1.BackupDestination) string {
+	_, remotePath := destinationConfiguration(backupPlan, destination)
+	return remotePath
 }
 
-func (r *ZomboidBackupPlanReconciler) dropboxConfiguration(dropbox zomboidhostv1.Dropbox, backupPlan *zomboidhostv1.ZomboidBackupPlan) ([]corev1.EnvVar, string) {
+func dropboxConfiguration(dropbox zomboidhostv1.Dropbox, backupPlan *zomboidhostv1.ZomboidBackupPlan) ([]corev1.EnvVar, string) {
 	var remotePath string
 	if dropbox.Path != "" {
 		// Strip leading slash for app folder scoping
@@ -422,7 +496,7 @@ func (r *ZomboidBackupPlanReconciler) dropboxConfiguration(dropbox zomboidhostv1
 	return env, fmt.Sprintf("dropbox:%s", remotePath)
 }
 
-func (r *ZomboidBackupPlanReconciler) s3Configuration(s3 zomboidhostv1.S3) ([]corev1.EnvVar, string) {
+func s3Configuration(s3 zomboidhostv1.S3) ([]corev1.EnvVar, string) {
 	env := []corev1.EnvVar{
 		{
 			Name:  "RCLONE_CONFIG_S3_TYPE",
@@ -488,7 +562,7 @@ func (r *ZomboidBackupPlanReconciler) s3Configuration(s3 zomboidhostv1.S3) ([]co
 	return env, fmt.Sprintf("s3:%s/%s", s3.BucketName, s3Path)
 }
 
-func (r *ZomboidBackupPlanReconciler) googleDriveConfiguration(googleDrive zomboidhostv1.GoogleDrive, backupPlan *zomboidhostv1.ZomboidBackupPlan) ([]corev1.EnvVar, string) {
+func googleDriveConfiguration(googleDrive zomboidhostv1.GoogleDrive, backupPlan *zomboidhostv1.ZomboidBackupPlan) ([]corev1.EnvVar, string) {
 	var remotePath string
 	if googleDrive.Path != "" {
 		remotePath = strings.TrimPrefix(googleDrive.Path, "/")
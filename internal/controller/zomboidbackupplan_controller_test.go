@@ -12,6 +12,7 @@ import (
 	errors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
@@ -22,6 +23,7 @@ var _ = Describe("ZomboidBackupPlan Controller", func() {
 	var (
 		ctx               context.Context
 		reconciler        *ZomboidBackupPlanReconciler
+		recorder          *record.FakeRecorder
 		namespace         string
 		server            *zomboidhostv1.ZomboidServer
 		operatorNS        *corev1.Namespace
@@ -62,9 +64,11 @@ var _ = Describe("ZomboidBackupPlan Controller", func() {
 	})
 
 	BeforeEach(func() {
+		recorder = record.NewFakeRecorder(100)
 		reconciler = &ZomboidBackupPlanReconciler{
-			Client: k8sClient,
-			Scheme: k8sClient.Scheme(),
+			Client:   k8sClient,
+			Scheme:   k8sClient.Scheme(),
+			Recorder: recorder,
 		}
 
 		namespace = "test-namespace-" + uuid.New().String()
@@ -212,6 +216,10 @@ var _ = Describe("ZomboidBackupPlan Controller", func() {
 				Expect(*ownerRef.Controller).To(BeTrue())
 			})
 
+			It("should record the CronJob's creation", func() {
+				Expect(recorder.Events).To(Receive(HavePrefix("Normal CronJobCreated Created CronJob " + backupPlan.Name)))
+			})
+
 			It("should set the CronJob schedule", func() {
 				Expect(cronJob.Spec.Schedule).To(Equal("*/15 * * * *"))
 			})
@@ -269,6 +277,7 @@ var _ = Describe("ZomboidBackupPlan Controller", func() {
 					cronJob := &batchv1.CronJob{}
 					err := k8sClient.Get(ctx, backupPlanName, cronJob)
 					Expect(errors.IsNotFound(err)).To(BeTrue())
+					Eventually(recorder.Events).Should(Receive(HavePrefix("Normal CronJobDeleted")))
 				})
 			})
 
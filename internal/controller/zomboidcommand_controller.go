@@ -0,0 +1,239 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	zomboidv1 "github.com/zomboidhost/zomboid-operator/api/v1"
+	"github.com/zomboidhost/zomboid-operator/internal/rcon"
+)
+
+// ZomboidCommandReconciler runs each ZomboidCommand once against its server
+type ZomboidCommandReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+
+	// Servers is used to reach ZomboidServers over RCON, sharing the
+	// sessions it keeps for them
+	Servers *ZomboidServerReconciler
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *ZomboidCommandReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&zomboidv1.ZomboidCommand{}).
+		Watches(&zomboidv1.ZomboidServer{}, handler.EnqueueRequestsFromMapFunc(r.findCommandsForServer)).
+		Named("zomboidcommand").
+		Complete(r)
+}
+
+// findCommandsForServer returns reconciliation requests for commands still
+// waiting on a ZomboidServer, so they run as soon as it becomes ready
+func (r *ZomboidCommandReconciler) findCommandsForServer(ctx context.Context, obj client.Object) []reconcile.Request {
+	server := obj.(*zomboidv1.ZomboidServer)
+
+	commands := &zomboidv1.ZomboidCommandList{}
+	if err := r.List(ctx, commands, client.InNamespace(server.Namespace)); err != nil {
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for _, command := range commands.Items {
+		if command.Spec.Server.Name != server.Name {
+			continue
+		}
+		if command.Status.Phase != "" && command.Status.Phase != zomboidv1.ZomboidCommandPending {
+			continue
+		}
+		requests = append(requests, reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Name:      command.Name,
+				Namespace: command.Namespace,
+			},
+		})
+	}
+	return requests
+}
+
+// +kubebuilder:rbac:groups=zomboid.host,resources=zomboidcommands,verbs=get;list;watch
+// +kubebuilder:rbac:groups=zomboid.host,resources=zomboidcommands/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=zomboid.host,resources=zomboidservers,verbs=get;list;watch
+
+// Reconcile runs a ZomboidCommand on its server, at most once
+func (r *ZomboidCommandReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	command := &zomboidv1.ZomboidCommand{}
+	if err := r.Get(ctx, req.NamespacedName, command); err != nil {
+		if errors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, fmt.Errorf("failed to get ZomboidCommand: %w", err)
+	}
+
+	switch command.Status.Phase {
+	case zomboidv1.ZomboidCommandSucceeded, zomboidv1.ZomboidCommandFailed:
+		return ctrl.Result{}, nil
+	case zomboidv1.ZomboidCommandRunning:
+		// We were interrupted after sending the command but before recording
+		// the outcome.  Running it again could repeat it, so give up instead.
+		return r.finish(ctx, command, zomboidv1.ZomboidCommandFailed, "", "Interrupted while running; the command may or may not have run")
+	}
+
+	if command.Status.RequestedBy == "" {
+		command.Status.RequestedBy = requestedBy(command)
+	}
+	command.Status.Command = renderCommand(command.Spec)
+
+	server := &zomboidv1.ZomboidServer{}
+	if err := r.Get(ctx, types.NamespacedName{
+		Name:      command.Spec.Server.Name,
+		Namespace: command.Namespace,
+	}, server); err != nil {
+		if errors.IsNotFound(err) {
+			return r.pending(ctx, command, fmt.Sprintf("ZomboidServer %s not found", command.Spec.Server.Name), nil)
+		}
+		return ctrl.Result{}, fmt.Errorf("failed to get ZomboidServer: %w", err)
+	}
+
+	if !server.Status.Ready {
+		return r.pending(ctx, command, "Waiting for the server to be ready", nil)
+	}
+
+	// If we're not pointing to a real cluster (like in tests), we can't do anything else
+	if r.Servers == nil || (r.Servers.Config == nil && r.Servers.ServiceEndpoint == nil) {
+		return r.pending(ctx, command, "Unable to reach the server", nil)
+	}
+
+	session, err := r.Servers.rconSession(ctx, server)
+	if err == nil {
+		err = session.Connect(ctx)
+	}
+	if err != nil {
+		return r.pending(ctx, command, fmt.Sprintf("Unable to reach the server: %s", err), &ctrl.Result{RequeueAfter: 10 * time.Second})
+	}
+
+	// Record that the command is running before sending it, so that if we
+	// fail to record the outcome we know not to send it again
+	command.Status.Phase = zomboidv1.ZomboidCommandRunning
+	command.Status.Message = ""
+	command.Status.ExecutedAt = &metav1.Time{Time: time.Now()}
+	if err := r.Status().Update(ctx, command); err != nil {
+		if errors.IsConflict(err) {
+			return ctrl.Result{Requeue: true}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	logger.Info("running command", "server", server.Name, "command", command.Status.Command, "requestedBy", command.Status.RequestedBy)
+
+	var response string
+	err = session.Do(ctx, func(client rcon.Client) (err error) {
+		response, err = client.Execute(command.Status.Command)
+		return err
+	})
+	if err != nil {
+		return r.finish(ctx, command, zomboidv1.ZomboidCommandFailed, response, fmt.Sprintf("Failed to run command: %s", err))
+	}
+
+	if strings.HasPrefix(response, "Unknown command") {
+		return r.finish(ctx, command, zomboidv1.ZomboidCommandFailed, response, "The server did not recognize the command")
+	}
+
+	return r.finish(ctx, command, zomboidv1.ZomboidCommandSucceeded, response, "")
+}
+
+func (r *ZomboidCommandReconciler) pending(ctx context.Context, command *zomboidv1.ZomboidCommand, message string, result *ctrl.Result) (ctrl.Result, error) {
+	command.Status.Phase = zomboidv1.ZomboidCommandPending
+	command.Status.Message = message
+	if err := r.Status().Update(ctx, command); err != nil {
+		if errors.IsConflict(err) {
+			return ctrl.Result{Requeue: true}, nil
+		}
+		return ctrl.Result{}, err
+	}
+	if result == nil {
+		return ctrl.Result{}, nil
+	}
+	return *result, nil
+}
+
+func (r *ZomboidCommandReconciler) finish(ctx context.Context, command *zomboidv1.ZomboidCommand, phase zomboidv1.ZomboidCommandPhase, response, message string) (ctrl.Result, error) {
+	command.Status.Phase = phase
+	command.Status.Response = response
+	command.Status.Message = message
+
+	// The outcome must be recorded, so retry through conflicts rather than
+	// leaving the command looking interrupted
+	for {
+		err := r.Status().Update(ctx, command)
+		if err == nil || !errors.IsConflict(err) {
+			return ctrl.Result{}, err
+		}
+
+		latest := &zomboidv1.ZomboidCommand{}
+		if err := r.Get(ctx, client.ObjectKeyFromObject(command), latest); err != nil {
+			return ctrl.Result{}, err
+		}
+		latest.Status = command.Status
+		command = latest
+	}
+}
+
+// requestedBy identifies who created a command, preferring an explicit
+// annotation over the field manager that created it.  Field managers are
+// chosen by clients rather than authenticated, so they're labelled as such.
+func requestedBy(command *zomboidv1.ZomboidCommand) string {
+	if by := command.Annotations[zomboidv1.RequestedByAnnotation]; by != "" {
+		return by
+	}
+
+	var earliest *metav1.ManagedFieldsEntry
+	for i := range command.ManagedFields {
+		entry := &command.ManagedFields[i]
+		if entry.Subresource != "" || entry.Time == nil {
+			continue
+		}
+		if earliest == nil || entry.Time.Before(earliest.Time) {
+			earliest = entry
+		}
+	}
+	if earliest != nil {
+		return "fieldManager:" + earliest.Manager
+	}
+	return ""
+}
+
+// renderCommand turns a ZomboidCommand's spec into a console command
+func renderCommand(spec zomboidv1.ZomboidCommandSpec) string {
+	switch {
+	case spec.Kick != nil:
+		if spec.Kick.Reason != "" {
+			return fmt.Sprintf("kickuser \"%s\" -r \"%s\"", spec.Kick.Username, spec.Kick.Reason)
+		}
+		return fmt.Sprintf("kickuser \"%s\"", spec.Kick.Username)
+	case spec.Teleport != nil:
+		return fmt.Sprintf("teleport \"%s\" \"%s\"", spec.Teleport.Username, spec.Teleport.ToUsername)
+	case spec.AddItem != nil:
+		count := spec.AddItem.Count
+		if count < 1 {
+			count = 1
+		}
+		return fmt.Sprintf("additem \"%s\" \"%s\" %d", spec.AddItem.Username, spec.AddItem.Item, count)
+	case spec.ServerMessage != nil:
+		return fmt.Sprintf("servermsg \"%s\"", spec.ServerMessage.Message)
+	}
+	return strings.TrimPrefix(strings.TrimSpace(spec.Command), "/")
+}
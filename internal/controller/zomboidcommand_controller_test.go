@@ -0,0 +1,267 @@
+package controller
+
+import (
+	"context"
+	"net"
+	"strconv"
+
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	zomboidv1 "github.com/zomboidhost/zomboid-operator/api/v1"
+	"github.com/zomboidhost/zomboid-operator/internal/rcon"
+	"github.com/zomboidhost/zomboid-operator/internal/rcon/fake"
+)
+
+var _ = Describe("ZomboidCommand Controller", func() {
+	var (
+		ctx           context.Context
+		reconciler    *ZomboidCommandReconciler
+		server        *fake.Server
+		namespace     string
+		zomboidServer *zomboidv1.ZomboidServer
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+
+		var err error
+		server, err = fake.NewServer("rcon-password")
+		Expect(err).NotTo(HaveOccurred())
+		DeferCleanup(server.Close)
+
+		reconciler = &ZomboidCommandReconciler{
+			Client: k8sClient,
+			Scheme: k8sClient.Scheme(),
+			Servers: &ZomboidServerReconciler{
+				Client:   k8sClient,
+				Scheme:   k8sClient.Scheme(),
+				RCON:     rcon.NewManager(),
+				Recorder: &record.FakeRecorder{},
+				ServiceEndpoint: func(ctx context.Context, name, namespace string, port int) (string, int, func(), error) {
+					hostname, rconPort, err := net.SplitHostPort(server.RCONAddr())
+					if err != nil {
+						return "", 0, func() {}, err
+					}
+					port, err = strconv.Atoi(rconPort)
+					return hostname, port, func() {}, err
+				},
+			},
+		}
+
+		namespace = "test-command-" + uuid.New().String()
+		Expect(k8sClient.Create(ctx, &corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{Name: namespace},
+		})).To(Succeed())
+
+		Expect(k8sClient.Create(ctx, &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "passwords",
+				Namespace: namespace,
+			},
+			StringData: map[string]string{
+				"admin": "admin-password",
+				"rcon":  "rcon-password",
+			},
+		})).To(Succeed())
+
+		zomboidServer = &zomboidv1.ZomboidServer{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-server",
+				Namespace: namespace,
+			},
+			Spec: zomboidv1.ZomboidServerSpec{
+				Version: "latest",
+				Storage: zomboidv1.Storage{
+					Request: resource.MustParse("10Gi"),
+				},
+				Administrator: zomboidv1.Administrator{
+					Username: "admin",
+					Password: corev1.SecretKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{Name: "passwords"},
+						Key:                  "admin",
+					},
+				},
+				RCON: &zomboidv1.RCON{
+					Password: &corev1.SecretKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{Name: "passwords"},
+						Key:                  "rcon",
+					},
+				},
+			},
+		}
+		Expect(k8sClient.Create(ctx, zomboidServer)).To(Succeed())
+	})
+
+	setReady := func(ready bool) {
+		zomboidServer.Status.Ready = ready
+		Expect(k8sClient.Status().Update(ctx, zomboidServer)).To(Succeed())
+	}
+
+	createAndReconcile := func(command *zomboidv1.ZomboidCommand) *zomboidv1.ZomboidCommand {
+		command.Namespace = namespace
+		command.Spec.Server = corev1.LocalObjectReference{Name: zomboidServer.Name}
+		Expect(k8sClient.Create(ctx, command)).To(Succeed())
+
+		key := types.NamespacedName{Name: command.Name, Namespace: namespace}
+		_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(k8sClient.Get(ctx, key, command)).To(Succeed())
+		return command
+	}
+
+	It("should run a raw command and record the response", func() {
+		setReady(true)
+
+		command := createAndReconcile(&zomboidv1.ZomboidCommand{
+			ObjectMeta: metav1.ObjectMeta{Name: "players"},
+			Spec:       zomboidv1.ZomboidCommandSpec{Command: "players"},
+		})
+
+		Expect(command.Status.Phase).To(Equal(zomboidv1.ZomboidCommandSucceeded))
+		Expect(command.Status.Command).To(Equal("players"))
+		Expect(command.Status.Response).To(ContainSubstring("Players connected (0)"))
+		Expect(command.Status.ExecutedAt).NotTo(BeNil())
+		Expect(command.Status.RequestedBy).To(HavePrefix("fieldManager:"))
+	})
+
+	It("should run structured actions", func() {
+		setReady(true)
+		server.ConnectPlayer("griefer")
+
+		command := createAndReconcile(&zomboidv1.ZomboidCommand{
+			ObjectMeta: metav1.ObjectMeta{Name: "kick"},
+			Spec: zomboidv1.ZomboidCommandSpec{
+				Kick: &zomboidv1.KickAction{Username: "griefer", Reason: "Griefing"},
+			},
+		})
+
+		Expect(command.Status.Phase).To(Equal(zomboidv1.ZomboidCommandSucceeded))
+		Expect(command.Status.Command).To(Equal(`kickuser "griefer" -r "Griefing"`))
+		Expect(command.Status.Response).To(Equal("User griefer kicked."))
+
+		command = createAndReconcile(&zomboidv1.ZomboidCommand{
+			ObjectMeta: metav1.ObjectMeta{Name: "announce"},
+			Spec: zomboidv1.ZomboidCommandSpec{
+				ServerMessage: &zomboidv1.ServerMessageAction{Message: "Restarting soon"},
+			},
+		})
+
+		Expect(command.Status.Phase).To(Equal(zomboidv1.ZomboidCommandSucceeded))
+		Expect(server.Messages()).To(Equal([]string{"Restarting soon"}))
+	})
+
+	It("should only run a command once", func() {
+		setReady(true)
+
+		command := createAndReconcile(&zomboidv1.ZomboidCommand{
+			ObjectMeta: metav1.ObjectMeta{Name: "announce"},
+			Spec: zomboidv1.ZomboidCommandSpec{
+				ServerMessage: &zomboidv1.ServerMessageAction{Message: "Hello"},
+			},
+		})
+		Expect(command.Status.Phase).To(Equal(zomboidv1.ZomboidCommandSucceeded))
+
+		_, err := reconciler.Reconcile(ctx, ctrl.Request{NamespacedName: types.NamespacedName{Name: command.Name, Namespace: namespace}})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(server.Messages()).To(HaveLen(1))
+	})
+
+	It("should record who requested the command from the annotation", func() {
+		setReady(true)
+
+		command := createAndReconcile(&zomboidv1.ZomboidCommand{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "annotated",
+				Annotations: map[string]string{zomboidv1.RequestedByAnnotation: "moderator@example.com"},
+			},
+			Spec: zomboidv1.ZomboidCommandSpec{Command: "players"},
+		})
+
+		Expect(command.Status.RequestedBy).To(Equal("moderator@example.com"))
+	})
+
+	It("should fail commands the server doesn't recognize", func() {
+		setReady(true)
+
+		command := createAndReconcile(&zomboidv1.ZomboidCommand{
+			ObjectMeta: metav1.ObjectMeta{Name: "bogus"},
+			Spec:       zomboidv1.ZomboidCommandSpec{Command: "bogus"},
+		})
+
+		Expect(command.Status.Phase).To(Equal(zomboidv1.ZomboidCommandFailed))
+		Expect(command.Status.Response).To(Equal("Unknown command bogus"))
+	})
+
+	It("should wait for the server to be ready", func() {
+		command := createAndReconcile(&zomboidv1.ZomboidCommand{
+			ObjectMeta: metav1.ObjectMeta{Name: "waiting"},
+			Spec:       zomboidv1.ZomboidCommandSpec{Command: "players"},
+		})
+
+		Expect(command.Status.Phase).To(Equal(zomboidv1.ZomboidCommandPending))
+		Expect(server.Commands()).To(BeEmpty())
+
+		requests := reconciler.findCommandsForServer(ctx, zomboidServer)
+		Expect(requests).To(ConsistOf(reconcile.Request{
+			NamespacedName: types.NamespacedName{Name: command.Name, Namespace: namespace},
+		}))
+	})
+
+	It("should not rerun a command that was interrupted", func() {
+		setReady(true)
+
+		command := &zomboidv1.ZomboidCommand{
+			ObjectMeta: metav1.ObjectMeta{Name: "interrupted", Namespace: namespace},
+			Spec: zomboidv1.ZomboidCommandSpec{
+				Server:  corev1.LocalObjectReference{Name: zomboidServer.Name},
+				Command: "players",
+			},
+		}
+		Expect(k8sClient.Create(ctx, command)).To(Succeed())
+		command.Status.Phase = zomboidv1.ZomboidCommandRunning
+		Expect(k8sClient.Status().Update(ctx, command)).To(Succeed())
+
+		key := types.NamespacedName{Name: command.Name, Namespace: namespace}
+		_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(k8sClient.Get(ctx, key, command)).To(Succeed())
+		Expect(command.Status.Phase).To(Equal(zomboidv1.ZomboidCommandFailed))
+		Expect(server.Commands()).To(BeEmpty())
+	})
+
+	It("should reject commands with more than one action", func() {
+		command := &zomboidv1.ZomboidCommand{
+			ObjectMeta: metav1.ObjectMeta{Name: "ambiguous", Namespace: namespace},
+			Spec: zomboidv1.ZomboidCommandSpec{
+				Server:        corev1.LocalObjectReference{Name: zomboidServer.Name},
+				Command:       "players",
+				ServerMessage: &zomboidv1.ServerMessageAction{Message: "Hello"},
+			},
+		}
+		Expect(k8sClient.Create(ctx, command)).NotTo(Succeed())
+	})
+
+	It("should render structured actions as console commands", func() {
+		Expect(renderCommand(zomboidv1.ZomboidCommandSpec{
+			Teleport: &zomboidv1.TeleportAction{Username: "alice", ToUsername: "bob"},
+		})).To(Equal(`teleport "alice" "bob"`))
+		Expect(renderCommand(zomboidv1.ZomboidCommandSpec{
+			AddItem: &zomboidv1.AddItemAction{Username: "alice", Item: "Base.Axe", Count: 2},
+		})).To(Equal(`additem "alice" "Base.Axe" 2`))
+		Expect(renderCommand(zomboidv1.ZomboidCommandSpec{
+			Kick: &zomboidv1.KickAction{Username: "alice"},
+		})).To(Equal(`kickuser "alice"`))
+		Expect(renderCommand(zomboidv1.ZomboidCommandSpec{Command: "/save"})).To(Equal("save"))
+	})
+})
@@ -4,6 +4,7 @@ import (
 	"context"
 	"crypto/sha256"
 	"fmt"
+	"strings"
 	"time"
 
 	"k8s.io/apimachinery/pkg/api/errors"
@@ -11,9 +12,10 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/rest"
-	"k8s.io/utils/ptr"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
@@ -22,12 +24,14 @@ import (
 
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
-	"github.com/gorcon/rcon"
 	zomboidv1 "github.com/zomboidhost/zomboid-operator/api/v1"
 	"github.com/zomboidhost/zomboid-operator/internal/players"
+	"github.com/zomboidhost/zomboid-operator/internal/rcon"
 	"github.com/zomboidhost/zomboid-operator/internal/settings"
+	"github.com/zomboidhost/zomboid-operator/internal/workshop"
 )
 
 // ZomboidServerReconciler reconciles a ZomboidServer object
@@ -35,12 +39,39 @@ type ZomboidServerReconciler struct {
 	client.Client
 	Scheme *runtime.Scheme
 	Config *rest.Config
+
+	// Recorder records Events for the changes the reconciler makes to servers
+	Recorder record.EventRecorder
+
+	// RCON holds the persistent RCON sessions to each server
+	RCON *rcon.Manager
+
+	// ServiceEndpoint, if set, overrides how the RCON and ws4sqlite services
+	// of a server are reached.  Tests use it to point at a fake server.
+	ServiceEndpoint func(ctx context.Context, name, namespace string, port int) (string, int, func(), error)
+
+	// Workshop looks up the server's workshop items on the Steam Workshop
+	Workshop *workshop.Client
+
+	// Exec, if set, overrides how commands are run in a server's game
+	// container.  Tests use it to stand in for the server's files.
+	Exec func(ctx context.Context, zomboidServer *zomboidv1.ZomboidServer, command []string) ([]byte, error)
 }
 
 const settingsUpdateInterval = 10 * time.Second
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *ZomboidServerReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	if r.RCON == nil {
+		r.RCON = rcon.NewManager()
+	}
+	if r.Workshop == nil {
+		r.Workshop = workshop.NewClient(workshop.DefaultEndpoint)
+	}
+	if err := mgr.Add(r.RCON); err != nil {
+		return err
+	}
+
 	periodicSettingsRunner := &periodicSettingsRunner{
 		client:       r.Client,
 		eventChannel: make(chan event.GenericEvent),
@@ -55,16 +86,74 @@ func (r *ZomboidServerReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		Owns(&corev1.PersistentVolumeClaim{}).
 		Owns(&appsv1.Deployment{}).
 		Owns(&corev1.Service{}).
+		Owns(&networkingv1.NetworkPolicy{}).
+		Owns(&corev1.ConfigMap{}).
 		Watches(
 			&corev1.Secret{},
 			handler.EnqueueRequestsFromMapFunc(r.findZomboidServersForSecret),
 		).
+		Watches(
+			&zomboidv1.ZomboidSettingsProfile{},
+			handler.EnqueueRequestsFromMapFunc(r.findZomboidServersForSettingsProfile),
+		).
+		Watches(
+			&zomboidv1.ClusterZomboidSettingsProfile{},
+			handler.EnqueueRequestsFromMapFunc(r.findZomboidServersForSettingsProfile),
+		).
+		Watches(
+			&zomboidv1.ZomboidBackupPlan{},
+			handler.EnqueueRequestsFromMapFunc(findZomboidServerForBackupPlan),
+		).
 		WatchesRawSource(
 			source.Channel(periodicSettingsRunner.eventChannel, &handler.EnqueueRequestForObject{}),
 		).
 		Complete(r)
 }
 
+// findZomboidServerForBackupPlan returns a reconciliation request for the
+// ZomboidServer a backup plan backs up
+func findZomboidServerForBackupPlan(ctx context.Context, obj client.Object) []reconcile.Request {
+	backupPlan := obj.(*zomboidv1.ZomboidBackupPlan)
+	return []reconcile.Request{{
+		NamespacedName: types.NamespacedName{
+			Name:      backupPlan.Spec.Server.Name,
+			Namespace: backupPlan.Namespace,
+		},
+	}}
+}
+
+// findZomboidServersForSettingsProfile returns reconciliation requests for
+// the ZomboidServers that layer a settings profile under their settings
+func (r *ZomboidServerReconciler) findZomboidServersForSettingsProfile(ctx context.Context, obj client.Object) []reconcile.Request {
+	kind := "ZomboidSettingsProfile"
+	if _, ok := obj.(*zomboidv1.ClusterZomboidSettingsProfile); ok {
+		kind = "ClusterZomboidSettingsProfile"
+	}
+
+	zomboidList := &zomboidv1.ZomboidServerList{}
+	if err := r.List(ctx, zomboidList, client.InNamespace(obj.GetNamespace())); err != nil {
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for _, zs := range zomboidList.Items {
+		for _, ref := range zs.Spec.SettingsFrom {
+			refKind := ref.Kind
+			if refKind == "" {
+				refKind = "ZomboidSettingsProfile"
+			}
+			if refKind == kind && ref.Name == obj.GetName() {
+				requests = append(requests, reconcile.Request{
+					NamespacedName: types.NamespacedName{Name: zs.Name, Namespace: zs.Namespace},
+				})
+				break
+			}
+		}
+	}
+
+	return requests
+}
+
 // findZomboidServersForSecret returns reconciliation requests for ZomboidServers that reference a Secret
 func (r *ZomboidServerReconciler) findZomboidServersForSecret(ctx context.Context, obj client.Object) []reconcile.Request {
 	secret := obj.(*corev1.Secret)
@@ -96,6 +185,13 @@ func (r *ZomboidServerReconciler) findZomboidServersForSecret(ctx context.Contex
 			continue
 		}
 
+		// Check RCON password, whether referenced or generated
+		if rconPasswordSelector(&zs).Name == secret.Name {
+			logger.Info("requeueing to update RCON password", "name", zs.Name)
+			requests = append(requests, request)
+			continue
+		}
+
 		// Check server password if set
 		if zs.Spec.Password != nil && zs.Spec.Password.LocalObjectReference.Name == secret.Name {
 			logger.Info("requeueing to update server password", "name", zs.Name)
@@ -122,6 +218,13 @@ func (r *ZomboidServerReconciler) findZomboidServersForSecret(ctx context.Contex
 			}
 		}
 
+		// Check generated ws4sqlite credentials
+		if secret.Name == sqliteCredentialsSecretName(&zs) {
+			logger.Info("requeueing to update SQLite credentials", "name", zs.Name)
+			requests = append(requests, request)
+			continue
+		}
+
 		// Check user passwords
 		for _, user := range zs.Spec.Users {
 			if user.Password != nil && user.Password.LocalObjectReference.Name == secret.Name {
@@ -198,12 +301,18 @@ func (r *periodicSettingsRunner) checkServers(ctx context.Context) error {
 // +kubebuilder:rbac:groups=zomboid.host,resources=zomboidservers,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=zomboid.host,resources=zomboidservers/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=zomboid.host,resources=zomboidservers/finalizers,verbs=update
+// +kubebuilder:rbac:groups=zomboid.host,resources=zomboidbackupplans,verbs=get;list;watch
+// +kubebuilder:rbac:groups=zomboid.host,resources=zomboidsettingsprofiles;clusterzomboidsettingsprofiles,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
 
 // +kubebuilder:rbac:groups="",resources=services,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups="",resources=persistentvolumeclaims,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;list;watch;create;update;patch;delete
-// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
+// +kubebuilder:rbac:groups=networking.k8s.io,resources=networkpolicies,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups="",resources=pods,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=pods/exec,verbs=create
 
 // Reconcile is the main function that reconciles a ZomboidServer resource
 func (r *ZomboidServerReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
@@ -216,16 +325,32 @@ func (r *ZomboidServerReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 	err = r.Get(ctx, req.NamespacedName, zomboidServer)
 	if err != nil {
 		if errors.IsNotFound(err) {
+			if r.RCON != nil {
+				r.RCON.Close(req.NamespacedName)
+			}
 			return ctrl.Result{}, nil
 		}
 		return ctrl.Result{}, err
 	}
 
+	// A missing profile only holds up the settings steps, and the profile's
+	// watch requeues the server once it's created
+	settingsResolved := true
+	if err := r.resolveSettings(ctx, zomboidServer); err != nil {
+		if !errors.IsNotFound(err) {
+			return r.status(ctx, zomboidServer, &ctrl.Result{}, err)
+		}
+		settingsResolved = false
+	}
+
 	result, err := r.reconcileInfrastructure(ctx, zomboidServer)
 	if result != nil {
 		return r.status(ctx, zomboidServer, result, err)
 	}
 	if err != nil {
+		if condition := meta.FindStatusCondition(zomboidServer.Status.Conditions, zomboidv1.TypeInfrastructureReady); condition != nil {
+			r.Recorder.Event(zomboidServer, corev1.EventTypeWarning, condition.Reason, condition.Message)
+		}
 		return r.status(ctx, zomboidServer, &ctrl.Result{}, err)
 	}
 
@@ -237,6 +362,10 @@ func (r *ZomboidServerReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 		Message:            "All required infrastructure components are ready",
 	})
 
+	if err := r.observeBackups(ctx, zomboidServer); err != nil {
+		return r.status(ctx, zomboidServer, &ctrl.Result{}, err)
+	}
+
 	deployment := &appsv1.Deployment{}
 	if err := r.Get(ctx, types.NamespacedName{Name: zomboidServer.Name, Namespace: zomboidServer.Namespace}, deployment); err != nil {
 		zomboidServer.Status.Ready = false
@@ -263,26 +392,25 @@ func (r *ZomboidServerReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 	}
 
 	if !zomboidServer.Status.Ready {
+		// Any open RCON connection is to a server that is going away
+		if r.RCON != nil {
+			r.RCON.Close(req.NamespacedName)
+		}
 		return r.status(ctx, zomboidServer, &ctrl.Result{RequeueAfter: 1 * time.Second}, nil)
 	}
 
 	// If we're not pointing to a real cluster (like in tests), we can't do anything else
-	if r.Config == nil {
+	if r.Config == nil && r.ServiceEndpoint == nil {
 		return r.status(ctx, zomboidServer, &ctrl.Result{}, nil)
 	}
 
-	// Establish RCON connection for all subsequent operations
-	conn, cleanup, err := r.connectRCON(ctx, zomboidServer)
+	// Use the server's persistent RCON session for all subsequent operations
+	session, err := r.connectRCON(ctx, zomboidServer)
 	if err != nil {
 		return r.status(ctx, zomboidServer, &ctrl.Result{}, err)
 	}
-	defer cleanup()
-
-	if conn == nil {
-		logger.Info("no RCON connection, skipping reconciliation")
-	}
 
-	result, err = r.observeCurrentSettings(ctx, conn, zomboidServer)
+	result, err = r.observeCurrentSettings(ctx, session, zomboidServer)
 	if result != nil {
 		return r.status(ctx, zomboidServer, result, err)
 	}
@@ -290,7 +418,21 @@ func (r *ZomboidServerReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 		return r.status(ctx, zomboidServer, &ctrl.Result{}, err)
 	}
 
-	result, err = r.applyDesiredSettings(ctx, conn, zomboidServer)
+	if settingsResolved {
+		r.resolveWorkshopMods(ctx, zomboidServer)
+
+		result, err = r.applyDesiredSettings(ctx, session, zomboidServer)
+		if result != nil {
+			return r.status(ctx, zomboidServer, result, err)
+		}
+		if err != nil {
+			return r.status(ctx, zomboidServer, &ctrl.Result{}, err)
+		}
+
+		r.observeInstalledMaps(ctx, zomboidServer)
+	}
+
+	result, err = r.observeCurrentAllowlist(ctx, zomboidServer)
 	if result != nil {
 		return r.status(ctx, zomboidServer, result, err)
 	}
@@ -298,7 +440,7 @@ func (r *ZomboidServerReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 		return r.status(ctx, zomboidServer, &ctrl.Result{}, err)
 	}
 
-	result, err = r.observeCurrentAllowlist(ctx, zomboidServer)
+	result, err = r.observeConnectedPlayers(ctx, session, zomboidServer)
 	if result != nil {
 		return r.status(ctx, zomboidServer, result, err)
 	}
@@ -306,7 +448,11 @@ func (r *ZomboidServerReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 		return r.status(ctx, zomboidServer, &ctrl.Result{}, err)
 	}
 
-	result, err = r.observeConnectedPlayers(ctx, conn, zomboidServer)
+	if settingsResolved {
+		r.checkWorkshopUpdates(ctx, zomboidServer)
+	}
+
+	result, err = r.restartForPendingSettings(ctx, session, zomboidServer)
 	if result != nil {
 		return r.status(ctx, zomboidServer, result, err)
 	}
@@ -314,7 +460,7 @@ func (r *ZomboidServerReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 		return r.status(ctx, zomboidServer, &ctrl.Result{}, err)
 	}
 
-	result, err = r.reconcileUsers(ctx, conn, zomboidServer)
+	result, err = r.reconcileUsers(ctx, session, zomboidServer)
 	if result != nil {
 		return r.status(ctx, zomboidServer, result, err)
 	}
@@ -347,13 +493,96 @@ func commonLabels(zomboidServer *zomboidv1.ZomboidServer) map[string]string {
 	}
 }
 
-func (r *ZomboidServerReconciler) observeCurrentSettings(ctx context.Context, conn *rcon.Conn, zomboidServer *zomboidv1.ZomboidServer) (*ctrl.Result, error) {
+// observeBackups records whether the server keeps backups, and which backup
+// plans copy them off the cluster
+func (r *ZomboidServerReconciler) observeBackups(ctx context.Context, zomboidServer *zomboidv1.ZomboidServer) error {
+	if zomboidServer.Spec.Backups.Request == nil {
+		meta.SetStatusCondition(&zomboidServer.Status.Conditions, metav1.Condition{
+			Type:               zomboidv1.TypeBackupsConfigured,
+			ObservedGeneration: zomboidServer.Generation,
+			Status:             metav1.ConditionFalse,
+			Reason:             zomboidv1.ReasonBackupsDisabled,
+			Message:            "spec.backups.request is not set, so the server keeps no backups",
+		})
+		return nil
+	}
+
+	backupPlans := &zomboidv1.ZomboidBackupPlanList{}
+	if err := r.List(ctx, backupPlans, client.InNamespace(zomboidServer.Namespace)); err != nil {
+		return fmt.Errorf("failed to list ZomboidBackupPlans: %w", err)
+	}
+
+	var names []string
+	for _, backupPlan := range backupPlans.Items {
+		if backupPlan.Spec.Server.Name == zomboidServer.Name {
+			names = append(names, backupPlan.Name)
+		}
+	}
+
+	if len(names) == 0 {
+		meta.SetStatusCondition(&zomboidServer.Status.Conditions, metav1.Condition{
+			Type:               zomboidv1.TypeBackupsConfigured,
+			ObservedGeneration: zomboidServer.Generation,
+			Status:             metav1.ConditionTrue,
+			Reason:             zomboidv1.ReasonBackupVolumeOnly,
+			Message:            "Backups are kept on the backups volume, but no ZomboidBackupPlan copies them off the cluster",
+		})
+		return nil
+	}
+
+	meta.SetStatusCondition(&zomboidServer.Status.Conditions, metav1.Condition{
+		Type:               zomboidv1.TypeBackupsConfigured,
+		ObservedGeneration: zomboidServer.Generation,
+		Status:             metav1.ConditionTrue,
+		Reason:             zomboidv1.ReasonBackupPlansConfigured,
+		Message:            fmt.Sprintf("Backups are copied off the cluster by %s", strings.Join(names, ", ")),
+	})
+	return nil
+}
+
+// resolveSettings layers the profiles the server refers to under its
+// spec.settings, recording the result in status.resolvedSettings.  Until every
+// profile exists the server's settings are left alone, rather than changing
+// them to an incomplete set.
+func (r *ZomboidServerReconciler) resolveSettings(ctx context.Context, zomboidServer *zomboidv1.ZomboidServer) error {
+	if len(zomboidServer.Spec.SettingsFrom) == 0 {
+		zomboidServer.Status.ResolvedSettings = nil
+		return nil
+	}
+
+	resolved, err := settings.Resolve(ctx, r.Client, zomboidServer)
+	if err != nil {
+		meta.SetStatusCondition(&zomboidServer.Status.Conditions, metav1.Condition{
+			Type:               zomboidv1.TypeSettingsSynced,
+			ObservedGeneration: zomboidServer.Generation,
+			Status:             metav1.ConditionFalse,
+			Reason:             zomboidv1.ReasonSettingsProfileMissing,
+			Message:            err.Error(),
+		})
+		r.Recorder.Event(zomboidServer, corev1.EventTypeWarning, zomboidv1.ReasonSettingsProfileMissing, err.Error())
+		return err
+	}
+
+	zomboidServer.Status.ResolvedSettings = &resolved
+	return nil
+}
+
+func (r *ZomboidServerReconciler) observeCurrentSettings(ctx context.Context, session *rcon.Session, zomboidServer *zomboidv1.ZomboidServer) (*ctrl.Result, error) {
 	if zomboidServer == nil {
 		return nil, nil
 	}
 
 	observed := zomboidv1.ZomboidSettings{}
-	if err := settings.ReadServerOptions(ctx, conn, &observed); err != nil {
+	if err := session.Do(ctx, func(client rcon.Client) error {
+		return settings.ReadServerOptions(ctx, client, &observed)
+	}); err != nil {
+		meta.SetStatusCondition(&zomboidServer.Status.Conditions, metav1.Condition{
+			Type:               zomboidv1.TypeSettingsSynced,
+			ObservedGeneration: zomboidServer.Generation,
+			Status:             metav1.ConditionFalse,
+			Reason:             zomboidv1.ReasonSettingsNotObserved,
+			Message:            fmt.Sprintf("Failed to read the server's settings: %v", err),
+		})
 		return nil, err
 	}
 
@@ -363,52 +592,287 @@ func (r *ZomboidServerReconciler) observeCurrentSettings(ctx context.Context, co
 	return nil, nil
 }
 
-func (r *ZomboidServerReconciler) applyDesiredSettings(ctx context.Context, conn *rcon.Conn, zomboidServer *zomboidv1.ZomboidServer) (*ctrl.Result, error) {
+func (r *ZomboidServerReconciler) applyDesiredSettings(ctx context.Context, session *rcon.Session, zomboidServer *zomboidv1.ZomboidServer) (*ctrl.Result, error) {
 	if zomboidServer == nil || zomboidServer.Status.Settings == nil {
 		return nil, nil
 	}
 
-	specSettings := zomboidServer.Spec.Settings
 	statusSettings := zomboidServer.Status.Settings
 
-	// Special cases handling...
-	if specSettings.Identity.ResetID == nil && statusSettings.Identity.ResetID != nil {
-		specSettings.Identity.ResetID = ptr.To(*statusSettings.Identity.ResetID)
+	desired := settings.Desired(zomboidServer)
+	updates := settings.ManagedUpdates(zomboidServer.Spec.SettingsPolicy, desired, *statusSettings)
+	if len(updates) == 0 {
+		zomboidServer.Status.SettingsDrift = settings.Drift(desired, *statusSettings)
+		setSettingsDriftConditions(zomboidServer)
+		return nil, nil
 	}
-	if specSettings.Identity.ServerPlayerID == nil && statusSettings.Identity.ServerPlayerID != nil {
-		specSettings.Identity.ServerPlayerID = ptr.To(*statusSettings.Identity.ServerPlayerID)
+
+	observed := settings.Values(*statusSettings)
+	if err := session.Do(ctx, func(client rcon.Client) error {
+		return settings.ApplySettingsUpdates(ctx, client, updates, statusSettings)
+	}); err != nil {
+		zomboidServer.Status.SettingsDrift = settings.Drift(desired, *statusSettings)
+		meta.SetStatusCondition(&zomboidServer.Status.Conditions, metav1.Condition{
+			Type:               zomboidv1.TypeSettingsSynced,
+			ObservedGeneration: zomboidServer.Generation,
+			Status:             metav1.ConditionFalse,
+			Reason:             zomboidv1.ReasonSettingsUpdateFailed,
+			Message:            err.Error(),
+		})
+		r.Recorder.Event(zomboidServer, corev1.EventTypeWarning, zomboidv1.ReasonSettingsUpdateFailed, err.Error())
+		return nil, err
 	}
 
-	settings.MergeWorkshopMods(&specSettings)
+	zomboidServer.Status.SettingsLastObserved = &metav1.Time{Time: time.Now()}
+	zomboidServer.Status.SettingsDrift = settings.Drift(desired, *statusSettings)
 
-	updates := settings.SettingsDiff(*statusSettings, specSettings)
-	if len(updates) == 0 {
+	if err := r.recordSettingsHistory(ctx, zomboidServer, updates, observed); err != nil {
+		// The changes are made either way, so a missing record mustn't block them
+		log.FromContext(ctx).Error(err, "failed to record settings history", "name", zomboidServer.Name)
+	}
+
+	var names []string
+	for _, update := range updates {
+		names = append(names, update[0])
+		r.Recorder.Eventf(zomboidServer, corev1.EventTypeNormal, EventReasonSettingChanged, "Set %s to %q", update[0], update[1])
+	}
+	meta.SetStatusCondition(&zomboidServer.Status.Conditions, metav1.Condition{
+		Type:               zomboidv1.TypeSettingsSynced,
+		ObservedGeneration: zomboidServer.Generation,
+		Status:             metav1.ConditionTrue,
+		Reason:             zomboidv1.ReasonSettingsApplied,
+		Message:            fmt.Sprintf("Applied %s", strings.Join(names, ", ")),
+	})
+
+	// Settings the server only reads on start wait for it to restart
+	modsChanged := false
+	for _, update := range updates {
+		if !settings.RequiresRestart(update[0]) {
+			continue
+		}
+		addPendingRestart(zomboidServer, update[0], update[1])
+		if update[0] == "Mods" || update[0] == "WorkshopItems" {
+			modsChanged = true
+		}
+	}
+
+	if modsChanged {
+		meta.SetStatusCondition(&zomboidServer.Status.Conditions, metav1.Condition{
+			Type:               zomboidv1.TypeModsReady,
+			ObservedGeneration: zomboidServer.Generation,
+			Status:             metav1.ConditionFalse,
+			Reason:             zomboidv1.ReasonModsPendingRestart,
+			Message:            "Changed mods load once the server restarts",
+		})
 		return nil, nil
 	}
+	meta.SetStatusCondition(&zomboidServer.Status.Conditions, metav1.Condition{
+		Type:               zomboidv1.TypeModsReady,
+		ObservedGeneration: zomboidServer.Generation,
+		Status:             metav1.ConditionTrue,
+		Reason:             zomboidv1.ReasonModsLoaded,
+		Message:            "Server has loaded the declared mods",
+	})
+	return nil, nil
+}
+
+// addPendingRestart records a change that takes effect once the server
+// restarts, replacing any earlier change of the same name
+func addPendingRestart(zomboidServer *zomboidv1.ZomboidServer, name, value string) {
+	pending := zomboidv1.PendingRestartSetting{Name: name, Value: value, ChangedAt: metav1.Now()}
+	for i, existing := range zomboidServer.Status.PendingRestart {
+		if existing.Name == name {
+			zomboidServer.Status.PendingRestart[i] = pending
+			return
+		}
+	}
+	zomboidServer.Status.PendingRestart = append(zomboidServer.Status.PendingRestart, pending)
+}
+
+// recordSettingsHistory adds a batch of applied changes to the server's
+// settings history, kept in an owned ConfigMap, along with why each was made
+func (r *ZomboidServerReconciler) recordSettingsHistory(ctx context.Context, zomboidServer *zomboidv1.ZomboidServer, updates, observed [][2]string) error {
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      settings.HistoryConfigMapName(zomboidServer.Name),
+			Namespace: zomboidServer.Namespace,
+		},
+	}
+
+	_, err := controllerutil.CreateOrUpdate(ctx, r.Client, configMap, func() error {
+		configMap.Labels = commonLabels(zomboidServer)
+		// A history that can't be read is started over by AppendHistory
+		history, _ := settings.ReadHistory(configMap)
+		entry := settings.NewHistoryEntry(zomboidServer, updates, observed, history)
+		if err := settings.AppendHistory(configMap, entry); err != nil {
+			return err
+		}
+		return ctrl.SetControllerReference(zomboidServer, configMap, r.Scheme)
+	})
+	return err
+}
 
-	if err := settings.ApplySettingsUpdates(ctx, conn, updates, statusSettings); err != nil {
+// restartForPendingSettings restarts the server to pick up the settings in
+// status.pendingRestart, when its restart policy allows.  Settings are no
+// longer pending once the server has started since they were changed.
+func (r *ZomboidServerReconciler) restartForPendingSettings(ctx context.Context, session *rcon.Session, zomboidServer *zomboidv1.ZomboidServer) (*ctrl.Result, error) {
+	startedAt, err := r.serverStartedAt(ctx, zomboidServer)
+	if err != nil {
 		return nil, err
 	}
 
-	zomboidServer.Status.SettingsLastObserved = &metav1.Time{Time: time.Now()}
+	var pending []zomboidv1.PendingRestartSetting
+	var names []string
+	modsPending := false
+	for _, setting := range zomboidServer.Status.PendingRestart {
+		if startedAt != nil && setting.ChangedAt.Before(startedAt) {
+			continue
+		}
+		pending = append(pending, setting)
+		names = append(names, setting.Name)
+		if setting.Name == "Mods" || setting.Name == "WorkshopItems" || setting.Name == pendingWorkshopUpdates {
+			modsPending = true
+		}
+	}
+	zomboidServer.Status.PendingRestart = pending
+	if len(pending) == 0 {
+		zomboidServer.Status.RestartAt = nil
+		return nil, nil
+	}
 
-	needsRestart := false
-	for _, update := range updates {
-		fieldName := update[0]
-		if fieldName == "Mods" || fieldName == "WorkshopItems" {
-			needsRestart = true
-			break
+	var waiting string
+	switch zomboidServer.Spec.RestartPolicy {
+	case zomboidv1.RestartPolicyManual:
+		waiting = "waiting for the server to be restarted, as restartPolicy is Manual"
+	case zomboidv1.RestartPolicyWhenEmpty:
+		if len(zomboidServer.Status.ConnectedPlayers) > 0 {
+			waiting = fmt.Sprintf("waiting for the %d connected players to leave", len(zomboidServer.Status.ConnectedPlayers))
 		}
 	}
 
-	if needsRestart {
-		if err := settings.RestartServer(ctx, conn); err != nil {
-			return nil, fmt.Errorf("failed to restart server after mod changes: %w", err)
+	if waiting != "" {
+		message := fmt.Sprintf("%s take effect once the server restarts; %s", strings.Join(names, ", "), waiting)
+		if meta.IsStatusConditionTrue(zomboidServer.Status.Conditions, zomboidv1.TypeSettingsSynced) {
+			meta.SetStatusCondition(&zomboidServer.Status.Conditions, metav1.Condition{
+				Type:               zomboidv1.TypeSettingsSynced,
+				ObservedGeneration: zomboidServer.Generation,
+				Status:             metav1.ConditionTrue,
+				Reason:             zomboidv1.ReasonSettingsPendingRestart,
+				Message:            message,
+			})
+		}
+		if modsPending {
+			meta.SetStatusCondition(&zomboidServer.Status.Conditions, metav1.Condition{
+				Type:               zomboidv1.TypeModsReady,
+				ObservedGeneration: zomboidServer.Generation,
+				Status:             metav1.ConditionFalse,
+				Reason:             zomboidv1.ReasonModsPendingRestart,
+				Message:            fmt.Sprintf("Changed mods load once the server restarts; %s", waiting),
+			})
 		}
-		return &ctrl.Result{RequeueAfter: 30 * time.Second}, nil
+		return nil, nil
 	}
 
-	return nil, nil
+	// Warn connected players ahead of the restart, which happens once the
+	// warning runs out or the last of them leaves
+	if warning := zomboidServer.Spec.RestartWarning; warning != nil && warning.Duration > 0 && len(zomboidServer.Status.ConnectedPlayers) > 0 {
+		if zomboidServer.Status.RestartAt == nil {
+			message := fmt.Sprintf("The server will restart in %s", players.FormatDuration(warning.Duration))
+			if err := session.Do(ctx, func(client rcon.Client) error {
+				return players.SendServerMessage(ctx, client, message)
+			}); err != nil {
+				return nil, fmt.Errorf("failed to warn players of the restart: %w", err)
+			}
+			restartAt := metav1.NewTime(time.Now().Add(warning.Duration))
+			zomboidServer.Status.RestartAt = &restartAt
+			r.Recorder.Eventf(zomboidServer, corev1.EventTypeNormal, EventReasonRestartScheduled,
+				"Restarting the server at %s to apply %s, after warning the connected players", restartAt.UTC().Format(time.RFC3339), strings.Join(names, ", "))
+		}
+
+		if time.Now().Before(zomboidServer.Status.RestartAt.Time) {
+			if meta.IsStatusConditionTrue(zomboidServer.Status.Conditions, zomboidv1.TypeSettingsSynced) {
+				meta.SetStatusCondition(&zomboidServer.Status.Conditions, metav1.Condition{
+					Type:               zomboidv1.TypeSettingsSynced,
+					ObservedGeneration: zomboidServer.Generation,
+					Status:             metav1.ConditionTrue,
+					Reason:             zomboidv1.ReasonSettingsPendingRestart,
+					Message: fmt.Sprintf("%s take effect once the server restarts at %s, after warning the connected players",
+						strings.Join(names, ", "), zomboidServer.Status.RestartAt.UTC().Format(time.RFC3339)),
+				})
+			}
+			if modsPending {
+				meta.SetStatusCondition(&zomboidServer.Status.Conditions, metav1.Condition{
+					Type:               zomboidv1.TypeModsReady,
+					ObservedGeneration: zomboidServer.Generation,
+					Status:             metav1.ConditionFalse,
+					Reason:             zomboidv1.ReasonModsPendingRestart,
+					Message: fmt.Sprintf("Changed mods load once the server restarts at %s",
+						zomboidServer.Status.RestartAt.UTC().Format(time.RFC3339)),
+				})
+			}
+			return nil, nil
+		}
+	}
+
+	if err := restartServer(ctx, session); err != nil {
+		message := fmt.Sprintf("Failed to restart the server to apply %s: %v", strings.Join(names, ", "), err)
+		meta.SetStatusCondition(&zomboidServer.Status.Conditions, metav1.Condition{
+			Type:               zomboidv1.TypeSettingsSynced,
+			ObservedGeneration: zomboidServer.Generation,
+			Status:             metav1.ConditionFalse,
+			Reason:             zomboidv1.ReasonRestartFailed,
+			Message:            message,
+		})
+		r.Recorder.Event(zomboidServer, corev1.EventTypeWarning, zomboidv1.ReasonRestartFailed, message)
+		if modsPending {
+			meta.SetStatusCondition(&zomboidServer.Status.Conditions, metav1.Condition{
+				Type:               zomboidv1.TypeModsReady,
+				ObservedGeneration: zomboidServer.Generation,
+				Status:             metav1.ConditionFalse,
+				Reason:             zomboidv1.ReasonModsRestartFailed,
+				Message:            fmt.Sprintf("Failed to restart the server to load changed mods: %v", err),
+			})
+		}
+		return nil, fmt.Errorf("failed to restart server after setting changes: %w", err)
+	}
+
+	// The server reads every pending setting as it starts again
+	zomboidServer.Status.PendingRestart = nil
+	zomboidServer.Status.RestartAt = nil
+
+	if modsPending {
+		meta.SetStatusCondition(&zomboidServer.Status.Conditions, metav1.Condition{
+			Type:               zomboidv1.TypeModsReady,
+			ObservedGeneration: zomboidServer.Generation,
+			Status:             metav1.ConditionFalse,
+			Reason:             zomboidv1.ReasonModsRestarting,
+			Message:            "Restarting the server to load changed mods",
+		})
+	}
+	r.Recorder.Eventf(zomboidServer, corev1.EventTypeNormal, EventReasonRestarting, "Restarting the server to apply %s", strings.Join(names, ", "))
+	return &ctrl.Result{RequeueAfter: 30 * time.Second}, nil
+}
+
+// serverStartedAt returns when the game server last started, or nil when it
+// isn't running
+func (r *ZomboidServerReconciler) serverStartedAt(ctx context.Context, zomboidServer *zomboidv1.ZomboidServer) (*metav1.Time, error) {
+	pods := &corev1.PodList{}
+	if err := r.List(ctx, pods, client.InNamespace(zomboidServer.Namespace), client.MatchingLabels(commonLabels(zomboidServer))); err != nil {
+		return nil, fmt.Errorf("failed to list pods: %w", err)
+	}
+
+	var startedAt *metav1.Time
+	for _, pod := range pods.Items {
+		for _, container := range pod.Status.ContainerStatuses {
+			if container.Name != "zomboid" || container.State.Running == nil {
+				continue
+			}
+			if startedAt == nil || startedAt.Before(&container.State.Running.StartedAt) {
+				startedAt = container.State.Running.StartedAt.DeepCopy()
+			}
+		}
+	}
+	return startedAt, nil
 }
 
 func (r *ZomboidServerReconciler) observeCurrentAllowlist(ctx context.Context, zomboidServer *zomboidv1.ZomboidServer) (*ctrl.Result, error) {
@@ -416,20 +880,25 @@ func (r *ZomboidServerReconciler) observeCurrentAllowlist(ctx context.Context, z
 		return nil, nil
 	}
 
-	hostname, port, cleanup, err := r.getServiceEndpoint(ctx,
-		zomboidServer.Name+"-sqlite",
-		zomboidServer.Namespace,
-		12321,
-	)
-	defer cleanup()
+	allowlist, err := r.getAllowlist(ctx, zomboidServer)
 	if err != nil {
+		meta.SetStatusCondition(&zomboidServer.Status.Conditions, metav1.Condition{
+			Type:               zomboidv1.TypeDatabaseReachable,
+			ObservedGeneration: zomboidServer.Generation,
+			Status:             metav1.ConditionFalse,
+			Reason:             zomboidv1.ReasonDatabaseUnreachable,
+			Message:            err.Error(),
+		})
 		return nil, err
 	}
 
-	allowlist, err := players.GetAllowlist(hostname, port, zomboidServer.Name)
-	if err != nil {
-		return nil, err
-	}
+	meta.SetStatusCondition(&zomboidServer.Status.Conditions, metav1.Condition{
+		Type:               zomboidv1.TypeDatabaseReachable,
+		ObservedGeneration: zomboidServer.Generation,
+		Status:             metav1.ConditionTrue,
+		Reason:             zomboidv1.ReasonDatabaseConnected,
+		Message:            "Read the allowlist from the server's database",
+	})
 
 	// Create map of existing users to preserve hashed passwords
 	existingUsers := make(map[string]zomboidv1.AllowlistUser)
@@ -449,7 +918,90 @@ func (r *ZomboidServerReconciler) observeCurrentAllowlist(ctx context.Context, z
 	return nil, nil
 }
 
-func (r *ZomboidServerReconciler) reconcileUsers(ctx context.Context, conn *rcon.Conn, zomboidServer *zomboidv1.ZomboidServer) (*ctrl.Result, error) {
+// setSettingsDriftConditions records how the server's settings compare to
+// the spec once the operator has nothing left to change
+func setSettingsDriftConditions(zomboidServer *zomboidv1.ZomboidServer) {
+	drift := zomboidServer.Status.SettingsDrift
+
+	var names []string
+	modsDrifted := false
+	for _, setting := range drift {
+		names = append(names, setting.Name)
+		if setting.Name == "Mods" || setting.Name == "WorkshopItems" {
+			modsDrifted = true
+		}
+	}
+
+	switch {
+	case len(drift) == 0:
+		meta.SetStatusCondition(&zomboidServer.Status.Conditions, metav1.Condition{
+			Type:               zomboidv1.TypeSettingsSynced,
+			ObservedGeneration: zomboidServer.Generation,
+			Status:             metav1.ConditionTrue,
+			Reason:             zomboidv1.ReasonSettingsInSync,
+			Message:            "Server settings match the spec",
+		})
+	case zomboidServer.Spec.SettingsPolicy == zomboidv1.SettingsPolicyObserveOnly:
+		meta.SetStatusCondition(&zomboidServer.Status.Conditions, metav1.Condition{
+			Type:               zomboidv1.TypeSettingsSynced,
+			ObservedGeneration: zomboidServer.Generation,
+			Status:             metav1.ConditionFalse,
+			Reason:             zomboidv1.ReasonSettingsDrifted,
+			Message: fmt.Sprintf("%d settings differ from the spec and settingsPolicy is ObserveOnly: %s",
+				len(drift), strings.Join(names, ", ")),
+		})
+	default:
+		meta.SetStatusCondition(&zomboidServer.Status.Conditions, metav1.Condition{
+			Type:               zomboidv1.TypeSettingsSynced,
+			ObservedGeneration: zomboidServer.Generation,
+			Status:             metav1.ConditionTrue,
+			Reason:             zomboidv1.ReasonSettingsInSync,
+			Message: fmt.Sprintf("Server settings match the spec; %d settings it leaves unset were changed on the server: %s",
+				len(drift), strings.Join(names, ", ")),
+		})
+	}
+
+	if modsDrifted && zomboidServer.Spec.SettingsPolicy == zomboidv1.SettingsPolicyObserveOnly {
+		meta.SetStatusCondition(&zomboidServer.Status.Conditions, metav1.Condition{
+			Type:               zomboidv1.TypeModsReady,
+			ObservedGeneration: zomboidServer.Generation,
+			Status:             metav1.ConditionFalse,
+			Reason:             zomboidv1.ReasonModsDrifted,
+			Message:            "Server's mods differ from the spec and settingsPolicy is ObserveOnly",
+		})
+		return
+	}
+	meta.SetStatusCondition(&zomboidServer.Status.Conditions, metav1.Condition{
+		Type:               zomboidv1.TypeModsReady,
+		ObservedGeneration: zomboidServer.Generation,
+		Status:             metav1.ConditionTrue,
+		Reason:             zomboidv1.ReasonModsLoaded,
+		Message:            "Server has loaded the declared mods",
+	})
+}
+
+// getAllowlist reads the allowlist from the server's database through its
+// ws4sqlite service
+func (r *ZomboidServerReconciler) getAllowlist(ctx context.Context, zomboidServer *zomboidv1.ZomboidServer) ([]zomboidv1.AllowlistUser, error) {
+	hostname, port, cleanup, err := r.getServiceEndpoint(ctx,
+		zomboidServer.Name+"-sqlite",
+		zomboidServer.Namespace,
+		12321,
+	)
+	defer cleanup()
+	if err != nil {
+		return nil, err
+	}
+
+	credentials, err := r.getSQLiteCredentials(ctx, zomboidServer)
+	if err != nil {
+		return nil, err
+	}
+
+	return players.GetAllowlist(hostname, port, zomboidServer.Name, credentials)
+}
+
+func (r *ZomboidServerReconciler) reconcileUsers(ctx context.Context, session *rcon.Session, zomboidServer *zomboidv1.ZomboidServer) (*ctrl.Result, error) {
 	if zomboidServer == nil {
 		return nil, nil
 	}
@@ -461,7 +1013,12 @@ func (r *ZomboidServerReconciler) reconcileUsers(ctx context.Context, conn *rcon
 	)
 	defer cleanup()
 	if err != nil {
-		return nil, err
+		return nil, r.usersSyncFailed(zomboidServer, zomboidv1.ReasonUsersUpdateFailed, err)
+	}
+
+	credentials, err := r.getSQLiteCredentials(ctx, zomboidServer)
+	if err != nil {
+		return nil, r.usersSyncFailed(zomboidServer, zomboidv1.ReasonUsersUpdateFailed, err)
 	}
 
 	currentUsers := make(map[string]zomboidv1.AllowlistUser)
@@ -481,20 +1038,35 @@ func (r *ZomboidServerReconciler) reconcileUsers(ctx context.Context, conn *rcon
 		Banned:      false,
 	})
 
+	// Look up every declared password before changing anything, so a missing
+	// secret doesn't leave the allowlist half updated
+	passwords := make(map[string]string)
+	for _, desiredUser := range desiredUsers {
+		if desiredUser.Password == nil {
+			continue
+		}
+
+		userSecret := &corev1.Secret{}
+		if err := r.Get(ctx, types.NamespacedName{
+			Name:      desiredUser.Password.Name,
+			Namespace: zomboidServer.Namespace,
+		}, userSecret); err != nil {
+			return nil, r.usersSyncFailed(zomboidServer, zomboidv1.ReasonUserSecretMissing,
+				fmt.Errorf("failed to get user secret for %s: %w", desiredUser.Username, err))
+		}
+		password, ok := userSecret.Data[desiredUser.Password.Key]
+		if !ok {
+			return nil, r.usersSyncFailed(zomboidServer, zomboidv1.ReasonUserSecretMissing,
+				fmt.Errorf("user secret %s for %s has no key %s", desiredUser.Password.Name, desiredUser.Username, desiredUser.Password.Key))
+		}
+		passwords[desiredUser.Username] = string(password)
+	}
+
 	for _, desiredUser := range desiredUsers {
 		current, exists := currentUsers[desiredUser.Username]
 
 		// Only manage passwords if they are declared, otherwise we'll just let users manage their own
-		if desiredUser.Password != nil {
-			userSecret := &corev1.Secret{}
-			if err := r.Get(ctx, types.NamespacedName{
-				Name:      desiredUser.Password.Name,
-				Namespace: zomboidServer.Namespace,
-			}, userSecret); err != nil {
-				return nil, fmt.Errorf("failed to get user secret for %s: %w", desiredUser.Username, err)
-			}
-
-			password := string(userSecret.Data[desiredUser.Password.Key])
+		if password, ok := passwords[desiredUser.Username]; ok {
 			hashedPassword := fmt.Sprintf("%x", sha256.Sum256([]byte(password)))
 
 			if !exists {
@@ -503,17 +1075,22 @@ func (r *ZomboidServerReconciler) reconcileUsers(ctx context.Context, conn *rcon
 					HashedPassword: hashedPassword,
 				}
 
-				if err := players.AddUser(ctx, conn, desiredUser.Username, password); err != nil {
-					return nil, fmt.Errorf("failed to add user %s: %w", desiredUser.Username, err)
+				if err := session.Do(ctx, func(client rcon.Client) error {
+					return players.AddUser(ctx, client, desiredUser.Username, password)
+				}); err != nil {
+					return nil, r.usersSyncFailed(zomboidServer, zomboidv1.ReasonUsersUpdateFailed, fmt.Errorf("failed to add user %s: %w", desiredUser.Username, err))
 				}
+				r.Recorder.Eventf(zomboidServer, corev1.EventTypeNormal, EventReasonUserAdded, "Added %s to the allowlist", desiredUser.Username)
 
 				currentUsers[desiredUser.Username] = current
 				zomboidServer.Status.Allowlist = append(zomboidServer.Status.Allowlist, current)
 			} else {
 				if current.HashedPassword != hashedPassword {
-					if err := players.SetPassword(ctx, hostname, port, zomboidServer.Name, desiredUser.Username, password); err != nil {
-						return nil, fmt.Errorf("failed to set password for user %s: %w", desiredUser.Username, err)
+					if err := players.SetPassword(ctx, hostname, port, zomboidServer.Name, credentials, desiredUser.Username, password); err != nil {
+						return nil, r.usersSyncFailed(zomboidServer, zomboidv1.ReasonUsersUpdateFailed, fmt.Errorf("failed to set password for user %s: %w", desiredUser.Username, err))
 					}
+					r.Recorder.Eventf(zomboidServer, corev1.EventTypeNormal, EventReasonPasswordRotated,
+						"Set the password for %s from secret %s", desiredUser.Username, desiredUser.Password.Name)
 				}
 				for i := range zomboidServer.Status.Allowlist {
 					if zomboidServer.Status.Allowlist[i].Username == desiredUser.Username {
@@ -525,27 +1102,38 @@ func (r *ZomboidServerReconciler) reconcileUsers(ctx context.Context, conn *rcon
 		}
 
 		if exists && current.AccessLevel != desiredUser.AccessLevel {
-			if err := players.SetAccessLevel(ctx, conn, desiredUser.Username, desiredUser.AccessLevel); err != nil {
-				return nil, fmt.Errorf("failed to set access level for %s: %w", desiredUser.Username, err)
+			if err := session.Do(ctx, func(client rcon.Client) error {
+				return players.SetAccessLevel(ctx, client, desiredUser.Username, desiredUser.AccessLevel)
+			}); err != nil {
+				return nil, r.usersSyncFailed(zomboidServer, zomboidv1.ReasonUsersUpdateFailed, fmt.Errorf("failed to set access level for %s: %w", desiredUser.Username, err))
 			}
+			r.Recorder.Eventf(zomboidServer, corev1.EventTypeNormal, EventReasonAccessLevelChanged,
+				"Changed the access level of %s from %q to %q", desiredUser.Username, current.AccessLevel, desiredUser.AccessLevel)
 		}
 
 		if exists && current.Banned != desiredUser.Banned {
 			if desiredUser.Banned {
-				if err := players.BanUser(ctx, conn, desiredUser.Username); err != nil {
-					return nil, fmt.Errorf("failed to ban user %s: %w", desiredUser.Username, err)
+				if err := session.Do(ctx, func(client rcon.Client) error {
+					return players.BanUser(ctx, client, desiredUser.Username)
+				}); err != nil {
+					return nil, r.usersSyncFailed(zomboidServer, zomboidv1.ReasonUsersUpdateFailed, fmt.Errorf("failed to ban user %s: %w", desiredUser.Username, err))
 				}
+				r.Recorder.Eventf(zomboidServer, corev1.EventTypeNormal, EventReasonUserBanned, "Banned %s", desiredUser.Username)
 			} else {
-				if err := players.UnbanUser(ctx, conn, desiredUser.Username); err != nil {
-					return nil, fmt.Errorf("failed to unban user %s: %w", desiredUser.Username, err)
+				if err := session.Do(ctx, func(client rcon.Client) error {
+					return players.UnbanUser(ctx, client, desiredUser.Username)
+				}); err != nil {
+					return nil, r.usersSyncFailed(zomboidServer, zomboidv1.ReasonUsersUpdateFailed, fmt.Errorf("failed to unban user %s: %w", desiredUser.Username, err))
 				}
+				r.Recorder.Eventf(zomboidServer, corev1.EventTypeNormal, EventReasonUserUnbanned, "Unbanned %s", desiredUser.Username)
 			}
 		}
 	}
 
 	// For open servers, we won't remove unlisted users, so we're done here
 	// The default is open
-	if zomboidServer.Spec.Settings.Player.Open == nil || *zomboidServer.Spec.Settings.Player.Open {
+	if open := settings.Desired(zomboidServer).Player.Open; open == nil || *open {
+		usersSynced(zomboidServer)
 		return nil, nil
 	}
 
@@ -560,27 +1148,61 @@ func (r *ZomboidServerReconciler) reconcileUsers(ctx context.Context, conn *rcon
 		}
 
 		if _, desired := desiredUsersMap[username]; !desired {
-			if err := players.RemoveUser(ctx, conn, username); err != nil {
-				return nil, fmt.Errorf("failed to remove user %s: %w", username, err)
+			if err := session.Do(ctx, func(client rcon.Client) error {
+				return players.RemoveUser(ctx, client, username)
+			}); err != nil {
+				return nil, r.usersSyncFailed(zomboidServer, zomboidv1.ReasonUsersUpdateFailed, fmt.Errorf("failed to remove user %s: %w", username, err))
 			}
+			r.Recorder.Eventf(zomboidServer, corev1.EventTypeNormal, EventReasonUserRemoved,
+				"Removed %s from the allowlist because the server is closed and they aren't declared", username)
 		}
 	}
 
+	usersSynced(zomboidServer)
 	return nil, nil
 }
 
-func (r *ZomboidServerReconciler) observeConnectedPlayers(ctx context.Context, conn *rcon.Conn, zomboidServer *zomboidv1.ZomboidServer) (*ctrl.Result, error) {
+// usersSynced records that the allowlist matches the declared users
+func usersSynced(zomboidServer *zomboidv1.ZomboidServer) {
+	meta.SetStatusCondition(&zomboidServer.Status.Conditions, metav1.Condition{
+		Type:               zomboidv1.TypeUsersSynced,
+		ObservedGeneration: zomboidServer.Generation,
+		Status:             metav1.ConditionTrue,
+		Reason:             zomboidv1.ReasonUsersInSync,
+		Message:            "Allowlist matches the declared users",
+	})
+}
+
+// usersSyncFailed records why the allowlist couldn't be brought in line
+// with the declared users, and returns err
+func (r *ZomboidServerReconciler) usersSyncFailed(zomboidServer *zomboidv1.ZomboidServer, reason string, err error) error {
+	meta.SetStatusCondition(&zomboidServer.Status.Conditions, metav1.Condition{
+		Type:               zomboidv1.TypeUsersSynced,
+		ObservedGeneration: zomboidServer.Generation,
+		Status:             metav1.ConditionFalse,
+		Reason:             reason,
+		Message:            err.Error(),
+	})
+	r.Recorder.Event(zomboidServer, corev1.EventTypeWarning, reason, err.Error())
+	return err
+}
+
+func (r *ZomboidServerReconciler) observeConnectedPlayers(ctx context.Context, session *rcon.Session, zomboidServer *zomboidv1.ZomboidServer) (*ctrl.Result, error) {
 	if zomboidServer == nil {
 		return nil, nil
 	}
 
-	players, err := players.GetConnectedPlayers(ctx, conn)
+	var usernames []string
+	err := session.Do(ctx, func(client rcon.Client) (err error) {
+		usernames, err = players.GetConnectedPlayers(ctx, client)
+		return err
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	connectedPlayers := make([]zomboidv1.ConnectedPlayer, len(players))
-	for i, username := range players {
+	connectedPlayers := make([]zomboidv1.ConnectedPlayer, len(usernames))
+	for i, username := range usernames {
 		connectedPlayers[i] = zomboidv1.ConnectedPlayer{
 			Username: username,
 		}
@@ -589,3 +1211,18 @@ func (r *ZomboidServerReconciler) observeConnectedPlayers(ctx context.Context, c
 	zomboidServer.Status.ConnectedPlayers = connectedPlayers
 	return nil, nil
 }
+
+func (r *ZomboidServerReconciler) getSQLiteCredentials(ctx context.Context, zomboidServer *zomboidv1.ZomboidServer) (players.SQLiteCredentials, error) {
+	secret := &corev1.Secret{}
+	if err := r.Get(ctx, types.NamespacedName{
+		Name:      sqliteCredentialsSecretName(zomboidServer),
+		Namespace: zomboidServer.Namespace,
+	}, secret); err != nil {
+		return players.SQLiteCredentials{}, fmt.Errorf("failed to get SQLite credentials: %w", err)
+	}
+
+	return players.SQLiteCredentials{
+		Username: string(secret.Data["username"]),
+		Password: string(secret.Data["password"]),
+	}, nil
+}
@@ -2,14 +2,18 @@ package controller
 
 import (
 	"context"
+	"crypto/rand"
 	"crypto/sha256"
 	"encoding/hex"
 	"fmt"
 	"os"
+	"strings"
 
 	zomboidv1 "github.com/zomboidhost/zomboid-operator/api/v1"
+	"github.com/zomboidhost/zomboid-operator/internal/settings"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	errors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -34,6 +38,32 @@ func (r *ZomboidServerReconciler) reconcileInfrastructure(ctx context.Context, z
 		return nil, err
 	}
 
+	if err := r.reconcileSQLiteCredentials(ctx, zomboidServer); err != nil {
+		if errors.IsConflict(err) {
+			return &ctrl.Result{Requeue: true}, nil
+		}
+		meta.SetStatusCondition(&zomboidServer.Status.Conditions, metav1.Condition{
+			Type:    zomboidv1.TypeInfrastructureReady,
+			Status:  metav1.ConditionFalse,
+			Reason:  zomboidv1.ReasonMissingSQLiteCredentials,
+			Message: fmt.Sprintf("Failed to reconcile SQLite credentials: %v", err),
+		})
+		return nil, err
+	}
+
+	if err := r.reconcileRCONPassword(ctx, zomboidServer); err != nil {
+		if errors.IsConflict(err) {
+			return &ctrl.Result{Requeue: true}, nil
+		}
+		meta.SetStatusCondition(&zomboidServer.Status.Conditions, metav1.Condition{
+			Type:    zomboidv1.TypeInfrastructureReady,
+			Status:  metav1.ConditionFalse,
+			Reason:  zomboidv1.ReasonMissingRCONPassword,
+			Message: fmt.Sprintf("Failed to reconcile RCON password: %v", err),
+		})
+		return nil, err
+	}
+
 	if err := r.reconcileDeployment(ctx, zomboidServer); err != nil {
 		if errors.IsConflict(err) {
 			return &ctrl.Result{Requeue: true}, nil
@@ -85,6 +115,45 @@ func (r *ZomboidServerReconciler) reconcileInfrastructure(ctx context.Context, z
 		})
 		return nil, err
 	}
+
+	if err := r.reconcileNetworkPolicy(ctx, zomboidServer); err != nil {
+		if errors.IsConflict(err) {
+			return &ctrl.Result{Requeue: true}, nil
+		}
+		meta.SetStatusCondition(&zomboidServer.Status.Conditions, metav1.Condition{
+			Type:    zomboidv1.TypeInfrastructureReady,
+			Status:  metav1.ConditionFalse,
+			Reason:  zomboidv1.ReasonMissingNetworkPolicy,
+			Message: fmt.Sprintf("Failed to reconcile NetworkPolicy: %v", err),
+		})
+		return nil, err
+	}
+
+	if err := r.reconcileSettingsConfigMap(ctx, zomboidServer); err != nil {
+		if errors.IsConflict(err) {
+			return &ctrl.Result{Requeue: true}, nil
+		}
+		meta.SetStatusCondition(&zomboidServer.Status.Conditions, metav1.Condition{
+			Type:    zomboidv1.TypeInfrastructureReady,
+			Status:  metav1.ConditionFalse,
+			Reason:  zomboidv1.ReasonMissingConfigMap,
+			Message: fmt.Sprintf("Failed to reconcile settings ConfigMap: %v", err),
+		})
+		return nil, err
+	}
+
+	if err := r.reconcileSpawnRegionsConfigMap(ctx, zomboidServer); err != nil {
+		if errors.IsConflict(err) {
+			return &ctrl.Result{Requeue: true}, nil
+		}
+		meta.SetStatusCondition(&zomboidServer.Status.Conditions, metav1.Condition{
+			Type:    zomboidv1.TypeInfrastructureReady,
+			Status:  metav1.ConditionFalse,
+			Reason:  zomboidv1.ReasonMissingConfigMap,
+			Message: fmt.Sprintf("Failed to reconcile spawn regions ConfigMap: %v", err),
+		})
+		return nil, err
+	}
 	return nil, nil
 }
 
@@ -204,6 +273,131 @@ func (r *ZomboidServerReconciler) reconcilePVC(ctx context.Context, zomboidServe
 	return nil
 }
 
+// sqliteUsername is the user the operator and metrics sidecar authenticate to
+// ws4sqlite as
+const sqliteUsername = "zomboid-operator"
+
+func sqliteCredentialsSecretName(zomboidServer *zomboidv1.ZomboidServer) string {
+	return zomboidServer.Name + "-sqlite-credentials"
+}
+
+// reconcileSQLiteCredentials maintains the generated Secret holding the
+// credentials for the ws4sqlite sidecar, along with the ws4sqlite config file
+// that requires them.  The password is generated once and kept, so it can be
+// rotated by editing or deleting the Secret.
+func (r *ZomboidServerReconciler) reconcileSQLiteCredentials(ctx context.Context, zomboidServer *zomboidv1.ZomboidServer) error {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      sqliteCredentialsSecretName(zomboidServer),
+			Namespace: zomboidServer.Namespace,
+		},
+	}
+
+	_, err := controllerutil.CreateOrUpdate(ctx, r.Client, secret, func() error {
+		secret.Labels = commonLabels(zomboidServer)
+
+		if secret.Data == nil {
+			secret.Data = map[string][]byte{}
+		}
+
+		password := string(secret.Data["password"])
+		if password == "" {
+			generated, err := generatePassword()
+			if err != nil {
+				return err
+			}
+			password = generated
+		}
+
+		passwordHash := sha256.Sum256([]byte(password))
+		config := fmt.Sprintf(`auth:
+  mode: HTTP
+  byCredentials:
+    - user: %s
+      hashedPassword: %s
+`, sqliteUsername, hex.EncodeToString(passwordHash[:]))
+
+		secret.Data["username"] = []byte(sqliteUsername)
+		secret.Data["password"] = []byte(password)
+		secret.Data[zomboidServer.Name+".yaml"] = []byte(config)
+
+		return ctrl.SetControllerReference(zomboidServer, secret, r.Scheme)
+	})
+
+	return err
+}
+
+// rconPasswordSelector returns the secret key holding the server's RCON
+// password, falling back to the operator-generated Secret
+func rconPasswordSelector(zomboidServer *zomboidv1.ZomboidServer) corev1.SecretKeySelector {
+	if zomboidServer.Spec.RCON != nil && zomboidServer.Spec.RCON.Password != nil {
+		return *zomboidServer.Spec.RCON.Password
+	}
+	return corev1.SecretKeySelector{
+		LocalObjectReference: corev1.LocalObjectReference{
+			Name: generatedRCONSecretName(zomboidServer),
+		},
+		Key: "password",
+	}
+}
+
+func generatedRCONSecretName(zomboidServer *zomboidv1.ZomboidServer) string {
+	return zomboidServer.Name + "-rcon"
+}
+
+// reconcileRCONPassword generates a random RCON password when none is
+// referenced in the spec, and removes the generated Secret once one is.
+func (r *ZomboidServerReconciler) reconcileRCONPassword(ctx context.Context, zomboidServer *zomboidv1.ZomboidServer) error {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      generatedRCONSecretName(zomboidServer),
+			Namespace: zomboidServer.Namespace,
+		},
+	}
+
+	if zomboidServer.Spec.RCON != nil && zomboidServer.Spec.RCON.Password != nil {
+		if zomboidServer.Spec.RCON.Password.Name == secret.Name {
+			return nil
+		}
+		err := r.Get(ctx, client.ObjectKeyFromObject(secret), secret)
+		if errors.IsNotFound(err) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if !metav1.IsControlledBy(secret, zomboidServer) {
+			return nil
+		}
+		return client.IgnoreNotFound(r.Delete(ctx, secret))
+	}
+
+	_, err := controllerutil.CreateOrUpdate(ctx, r.Client, secret, func() error {
+		secret.Labels = commonLabels(zomboidServer)
+
+		if len(secret.Data["password"]) == 0 {
+			password, err := generatePassword()
+			if err != nil {
+				return err
+			}
+			secret.Data = map[string][]byte{"password": []byte(password)}
+		}
+
+		return ctrl.SetControllerReference(zomboidServer, secret, r.Scheme)
+	})
+
+	return err
+}
+
+// generatePassword returns a random password suitable for service credentials
+func generatePassword() (string, error) {
+	buf := make([]byte, 24)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate password: %w", err)
+	}
+	return hex.EncodeToString(buf), nil
+}
+
 func (r *ZomboidServerReconciler) reconcileDeployment(ctx context.Context, zomboidServer *zomboidv1.ZomboidServer) error {
 	deployment := &appsv1.Deployment{
 		ObjectMeta: metav1.ObjectMeta{
@@ -287,6 +481,25 @@ func (r *ZomboidServerReconciler) reconcileDeployment(ctx context.Context, zombo
 			},
 		)
 
+		rconPassword := rconPasswordSelector(zomboidServer)
+		rconSecret := &corev1.Secret{}
+		err = r.Get(ctx, client.ObjectKey{
+			Namespace: zomboidServer.Namespace,
+			Name:      rconPassword.Name,
+		}, rconSecret)
+		if err != nil {
+			return fmt.Errorf("failed to get RCON password secret: %w", err)
+		}
+		rconHash := sha256.Sum256(rconSecret.Data[rconPassword.Key])
+		annotations["secret/rcon"] = hex.EncodeToString(rconHash[:])
+
+		envVars = append(envVars, corev1.EnvVar{
+			Name: "ZOMBOID_SERVER_RCON_PASSWORD",
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &rconPassword,
+			},
+		})
+
 		// Server password if configured
 		if zomboidServer.Spec.Password != nil {
 			serverSecret := &corev1.Secret{}
@@ -370,6 +583,17 @@ func (r *ZomboidServerReconciler) reconcileDeployment(ctx context.Context, zombo
 			}
 		}
 
+		sqliteSecret := &corev1.Secret{}
+		err = r.Get(ctx, client.ObjectKey{
+			Namespace: zomboidServer.Namespace,
+			Name:      sqliteCredentialsSecretName(zomboidServer),
+		}, sqliteSecret)
+		if err != nil {
+			return fmt.Errorf("failed to get SQLite credentials secret: %w", err)
+		}
+		sqliteHash := sha256.Sum256(sqliteSecret.Data["password"])
+		annotations["secret/sqlite"] = hex.EncodeToString(sqliteHash[:])
+
 		image := fmt.Sprintf("zomboidhost/zomboid-server:%s", zomboidServer.Spec.Version)
 
 		var workshopVolumeSource corev1.VolumeSource
@@ -474,6 +698,20 @@ func (r *ZomboidServerReconciler) reconcileDeployment(ctx context.Context, zombo
 				Name:         "workshop",
 				VolumeSource: workshopVolumeSource,
 			},
+			{
+				Name: "ws4sqlite-config",
+				VolumeSource: corev1.VolumeSource{
+					Secret: &corev1.SecretVolumeSource{
+						SecretName: sqliteCredentialsSecretName(zomboidServer),
+						Items: []corev1.KeyToPath{
+							{
+								Key:  zomboidServer.Name + ".yaml",
+								Path: zomboidServer.Name + ".yaml",
+							},
+						},
+					},
+				},
+			},
 		}
 
 		// Create volume mounts slice with existing mounts
@@ -488,6 +726,45 @@ func (r *ZomboidServerReconciler) reconcileDeployment(ctx context.Context, zombo
 			},
 		}
 
+		// Mount the spawn regions over the server's own file.  The game data
+		// is chowned before the mount, so make sure the directory it lands in
+		// belongs to the server rather than being created by the runtime.
+		if zomboidServer.Spec.Spawn != nil {
+			spawnRegionsFile := settings.SpawnRegionsFile(zomboidServer.Name)
+			initContainers = append(initContainers, corev1.Container{
+				Name:            "spawn-regions-directory",
+				Image:           image,
+				ImagePullPolicy: corev1.PullIfNotPresent,
+				Command:         []string{"/usr/bin/mkdir", "-p", "/game-data/Server"},
+				SecurityContext: &corev1.SecurityContext{
+					RunAsUser:  ptr.To(int64(1000)),
+					RunAsGroup: ptr.To(int64(1000)),
+				},
+				VolumeMounts: []corev1.VolumeMount{{Name: "game-data", MountPath: "/game-data"}},
+			})
+			volumes = append(volumes, corev1.Volume{
+				Name: "spawn-regions",
+				VolumeSource: corev1.VolumeSource{
+					ConfigMap: &corev1.ConfigMapVolumeSource{
+						LocalObjectReference: corev1.LocalObjectReference{
+							Name: settings.SpawnRegionsConfigMapName(zomboidServer.Name),
+						},
+					},
+				},
+			})
+			volumeMounts = append(volumeMounts, corev1.VolumeMount{
+				Name:      "spawn-regions",
+				MountPath: "/game-data/Server/" + spawnRegionsFile,
+				SubPath:   spawnRegionsFile,
+				ReadOnly:  true,
+			})
+
+			// subPath mounts never see the ConfigMap change, so roll the pod
+			// when the regions do
+			spawnRegionsHash := sha256.Sum256(settings.RenderSpawnRegions(settings.SpawnRegions(zomboidServer)))
+			annotations["configmap/spawn-regions"] = hex.EncodeToString(spawnRegionsHash[:])
+		}
+
 		// Add backup volume and mount if requested
 		if zomboidServer.Spec.Backups.Request != nil {
 			volumes = append(volumes, corev1.Volume{
@@ -581,7 +858,10 @@ func (r *ZomboidServerReconciler) reconcileDeployment(ctx context.Context, zombo
 							Name:            "ws4sqlite",
 							Image:           "germanorizzo/ws4sqlite:v0.16.2",
 							ImagePullPolicy: corev1.PullIfNotPresent,
-							Args:            []string{"--db", fmt.Sprintf("/game-data/db/%s.db", zomboidServer.Name)},
+							Args: []string{
+								"--db", fmt.Sprintf("/game-data/db/%s.db", zomboidServer.Name),
+								"--cfg-dir", "/ws4sqlite-config",
+							},
 							SecurityContext: &corev1.SecurityContext{
 								RunAsUser:  ptr.To(int64(1000)),
 								RunAsGroup: ptr.To(int64(1000)),
@@ -598,6 +878,11 @@ func (r *ZomboidServerReconciler) reconcileDeployment(ctx context.Context, zombo
 									Name:      "game-data",
 									MountPath: "/game-data",
 								},
+								{
+									Name:      "ws4sqlite-config",
+									MountPath: "/ws4sqlite-config",
+									ReadOnly:  true,
+								},
 							},
 						},
 						{
@@ -617,12 +902,29 @@ func (r *ZomboidServerReconciler) reconcileDeployment(ctx context.Context, zombo
 								},
 								{
 									Name: "RCON_PASSWORD",
+									ValueFrom: &corev1.EnvVarSource{
+										SecretKeyRef: &rconPassword,
+									},
+								},
+								{
+									Name: "SQLITE_USERNAME",
 									ValueFrom: &corev1.EnvVarSource{
 										SecretKeyRef: &corev1.SecretKeySelector{
 											LocalObjectReference: corev1.LocalObjectReference{
-												Name: zomboidServer.Spec.Administrator.Password.Name,
+												Name: sqliteCredentialsSecretName(zomboidServer),
 											},
-											Key: zomboidServer.Spec.Administrator.Password.Key,
+											Key: "username",
+										},
+									},
+								},
+								{
+									Name: "SQLITE_PASSWORD",
+									ValueFrom: &corev1.EnvVarSource{
+										SecretKeyRef: &corev1.SecretKeySelector{
+											LocalObjectReference: corev1.LocalObjectReference{
+												Name: sqliteCredentialsSecretName(zomboidServer),
+											},
+											Key: "password",
 										},
 									},
 								},
@@ -740,3 +1042,222 @@ func (r *ZomboidServerReconciler) reconcileGameService(ctx context.Context, zomb
 
 	return err
 }
+
+func (r *ZomboidServerReconciler) reconcileNetworkPolicy(ctx context.Context, zomboidServer *zomboidv1.ZomboidServer) error {
+	networkPolicy := &networkingv1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      zomboidServer.Name,
+			Namespace: zomboidServer.Namespace,
+		},
+	}
+
+	if zomboidServer.Spec.NetworkPolicy == nil || !zomboidServer.Spec.NetworkPolicy.Enabled {
+		err := r.Get(ctx, client.ObjectKeyFromObject(networkPolicy), networkPolicy)
+		if errors.IsNotFound(err) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if !metav1.IsControlledBy(networkPolicy, zomboidServer) {
+			return nil
+		}
+		return client.IgnoreNotFound(r.Delete(ctx, networkPolicy))
+	}
+
+	policy := zomboidServer.Spec.NetworkPolicy
+
+	operatorNamespace := policy.OperatorNamespace
+	if operatorNamespace == "" {
+		operatorNamespace = "zomboid-system"
+	}
+
+	operatorPodSelector := policy.OperatorPodSelector
+	if operatorPodSelector == nil {
+		operatorPodSelector = &metav1.LabelSelector{
+			MatchLabels: map[string]string{"control-plane": "controller-manager"},
+		}
+	}
+
+	prometheusNamespaceSelector := policy.PrometheusNamespaceSelector
+	if prometheusNamespaceSelector == nil {
+		prometheusNamespaceSelector = &metav1.LabelSelector{
+			MatchLabels: map[string]string{"metrics": "enabled"},
+		}
+	}
+
+	prometheusPodSelector := policy.PrometheusPodSelector
+	if prometheusPodSelector == nil {
+		prometheusPodSelector = &metav1.LabelSelector{}
+	}
+
+	serverPort := int32(16261)
+	if zomboidServer.Spec.ServerPort != nil {
+		serverPort = *zomboidServer.Spec.ServerPort
+	}
+
+	udpPort := int32(16262)
+	if zomboidServer.Spec.UDPPort != nil {
+		udpPort = *zomboidServer.Spec.UDPPort
+	}
+
+	_, err := controllerutil.CreateOrUpdate(ctx, r.Client, networkPolicy, func() error {
+		labels := commonLabels(zomboidServer)
+		networkPolicy.Labels = labels
+
+		networkPolicy.Spec = networkingv1.NetworkPolicySpec{
+			PodSelector: metav1.LabelSelector{
+				MatchLabels: labels,
+			},
+			PolicyTypes: []networkingv1.PolicyType{
+				networkingv1.PolicyTypeIngress,
+			},
+			Ingress: []networkingv1.NetworkPolicyIngressRule{
+				// Game traffic is allowed from anywhere
+				{
+					Ports: []networkingv1.NetworkPolicyPort{
+						{
+							Protocol: ptr.To(corev1.ProtocolUDP),
+							Port:     ptr.To(intstr.FromInt32(serverPort)),
+						},
+						{
+							Protocol: ptr.To(corev1.ProtocolUDP),
+							Port:     ptr.To(intstr.FromInt32(udpPort)),
+						},
+					},
+				},
+				{
+					From: []networkingv1.NetworkPolicyPeer{
+						{
+							NamespaceSelector: &metav1.LabelSelector{
+								MatchLabels: map[string]string{
+									"kubernetes.io/metadata.name": operatorNamespace,
+								},
+							},
+							PodSelector: operatorPodSelector,
+						},
+					},
+					Ports: []networkingv1.NetworkPolicyPort{
+						{
+							Protocol: ptr.To(corev1.ProtocolTCP),
+							Port:     ptr.To(intstr.FromInt32(27015)),
+						},
+						{
+							Protocol: ptr.To(corev1.ProtocolTCP),
+							Port:     ptr.To(intstr.FromInt32(12321)),
+						},
+						{
+							Protocol: ptr.To(corev1.ProtocolTCP),
+							Port:     ptr.To(intstr.FromInt32(9090)),
+						},
+					},
+				},
+				{
+					From: []networkingv1.NetworkPolicyPeer{
+						{
+							NamespaceSelector: prometheusNamespaceSelector,
+							PodSelector:       prometheusPodSelector,
+						},
+					},
+					Ports: []networkingv1.NetworkPolicyPort{
+						{
+							Protocol: ptr.To(corev1.ProtocolTCP),
+							Port:     ptr.To(intstr.FromInt32(9090)),
+						},
+					},
+				},
+			},
+		}
+		return ctrl.SetControllerReference(zomboidServer, networkPolicy, r.Scheme)
+	})
+
+	return err
+}
+
+func (r *ZomboidServerReconciler) reconcileSettingsConfigMap(ctx context.Context, zomboidServer *zomboidv1.ZomboidServer) error {
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      zomboidServer.Name + "-settings",
+			Namespace: zomboidServer.Namespace,
+		},
+	}
+
+	if zomboidServer.Spec.SettingsConfigMap == nil || !zomboidServer.Spec.SettingsConfigMap.Enabled {
+		err := r.Get(ctx, client.ObjectKeyFromObject(configMap), configMap)
+		if errors.IsNotFound(err) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if !metav1.IsControlledBy(configMap, zomboidServer) {
+			return nil
+		}
+		return client.IgnoreNotFound(r.Delete(ctx, configMap))
+	}
+
+	_, err := controllerutil.CreateOrUpdate(ctx, r.Client, configMap, func() error {
+		configMap.Labels = commonLabels(zomboidServer)
+		configMap.Data = map[string]string{
+			zomboidServer.Name + ".ini": string(settings.ExportServerINI(zomboidServer)),
+		}
+		return ctrl.SetControllerReference(zomboidServer, configMap, r.Scheme)
+	})
+
+	return err
+}
+
+// reconcileSpawnRegionsConfigMap keeps the server's spawn regions in the
+// ConfigMap mounted as its spawnregions.lua.  The server only reads them as
+// it starts, so any change waits in status.pendingRestart.
+func (r *ZomboidServerReconciler) reconcileSpawnRegionsConfigMap(ctx context.Context, zomboidServer *zomboidv1.ZomboidServer) error {
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      settings.SpawnRegionsConfigMapName(zomboidServer.Name),
+			Namespace: zomboidServer.Namespace,
+		},
+	}
+
+	if zomboidServer.Spec.Spawn == nil {
+		err := r.Get(ctx, client.ObjectKeyFromObject(configMap), configMap)
+		if errors.IsNotFound(err) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if !metav1.IsControlledBy(configMap, zomboidServer) {
+			return nil
+		}
+		err = r.Delete(ctx, configMap)
+		if errors.IsNotFound(err) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		addPendingRestart(zomboidServer, "SpawnRegions", "managed by the server")
+		return nil
+	}
+
+	regions := settings.SpawnRegions(zomboidServer)
+	result, err := controllerutil.CreateOrUpdate(ctx, r.Client, configMap, func() error {
+		configMap.Labels = commonLabels(zomboidServer)
+		configMap.Data = map[string]string{
+			settings.SpawnRegionsFile(zomboidServer.Name): string(settings.RenderSpawnRegions(regions)),
+		}
+		return ctrl.SetControllerReference(zomboidServer, configMap, r.Scheme)
+	})
+	if err != nil {
+		return err
+	}
+
+	if result != controllerutil.OperationResultNone {
+		names := make([]string, 0, len(regions))
+		for _, region := range regions {
+			names = append(names, region.Name)
+		}
+		addPendingRestart(zomboidServer, "SpawnRegions", strings.Join(names, ";"))
+	}
+	return nil
+}
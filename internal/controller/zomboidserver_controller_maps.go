@@ -0,0 +1,91 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	zomboidv1 "github.com/zomboidhost/zomboid-operator/api/v1"
+	"github.com/zomboidhost/zomboid-operator/internal/settings"
+	"github.com/zomboidhost/zomboid-operator/internal/workshop"
+)
+
+// listInstalledMaps lists the map folders in the installed workshop items,
+// which lay them out as <item>/mods/<mod>/media/maps/<map>
+var listInstalledMaps = []string{"/bin/sh", "-c",
+	"find " + workshop.ContentDir + " -mindepth 6 -maxdepth 6 -type d -path '*/mods/*/media/maps/*' 2>/dev/null || true"}
+
+// observeInstalledMaps checks the server's installed workshop items have the
+// folders of the maps it loads.  Maps are only checked again once they
+// change or weren't all found, and failing to check doesn't hold up the rest
+// of the reconcile.
+func (r *ZomboidServerReconciler) observeInstalledMaps(ctx context.Context, zomboidServer *zomboidv1.ZomboidServer) {
+	custom := settings.CustomMaps(settings.Desired(zomboidServer))
+	if len(custom) == 0 {
+		meta.SetStatusCondition(&zomboidServer.Status.Conditions, metav1.Condition{
+			Type:               zomboidv1.TypeMapsInstalled,
+			ObservedGeneration: zomboidServer.Generation,
+			Status:             metav1.ConditionTrue,
+			Reason:             zomboidv1.ReasonVanillaMapOnly,
+			Message:            "The server only loads the vanilla map",
+		})
+		return
+	}
+
+	found := fmt.Sprintf("Found the folders of %s", strings.Join(custom, ", "))
+	if condition := meta.FindStatusCondition(zomboidServer.Status.Conditions, zomboidv1.TypeMapsInstalled); condition != nil &&
+		condition.Status == metav1.ConditionTrue && condition.Message == found {
+		return
+	}
+
+	output, err := r.exec(ctx, zomboidServer, listInstalledMaps)
+	if err != nil {
+		log.FromContext(ctx).Error(err, "failed to list installed maps", "name", zomboidServer.Name)
+		meta.SetStatusCondition(&zomboidServer.Status.Conditions, metav1.Condition{
+			Type:               zomboidv1.TypeMapsInstalled,
+			ObservedGeneration: zomboidServer.Generation,
+			Status:             metav1.ConditionUnknown,
+			Reason:             zomboidv1.ReasonMapsNotChecked,
+			Message:            fmt.Sprintf("Failed to list installed maps: %v", err),
+		})
+		return
+	}
+
+	installed := map[string]bool{}
+	for _, line := range strings.Split(string(output), "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			installed[path.Base(line)] = true
+		}
+	}
+
+	var missing []string
+	for _, mapName := range custom {
+		if !installed[mapName] {
+			missing = append(missing, mapName)
+		}
+	}
+	if len(missing) > 0 {
+		meta.SetStatusCondition(&zomboidServer.Status.Conditions, metav1.Condition{
+			Type:               zomboidv1.TypeMapsInstalled,
+			ObservedGeneration: zomboidServer.Generation,
+			Status:             metav1.ConditionFalse,
+			Reason:             zomboidv1.ReasonMapsMissing,
+			Message: fmt.Sprintf("No installed workshop item has the folders of %s; newly added workshop mods are downloaded when the server restarts",
+				strings.Join(missing, ", ")),
+		})
+		return
+	}
+
+	meta.SetStatusCondition(&zomboidServer.Status.Conditions, metav1.Condition{
+		Type:               zomboidv1.TypeMapsInstalled,
+		ObservedGeneration: zomboidServer.Generation,
+		Status:             metav1.ConditionTrue,
+		Reason:             zomboidv1.ReasonMapsFound,
+		Message:            found,
+	})
+}
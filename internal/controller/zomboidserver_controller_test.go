@@ -2,6 +2,8 @@ package controller
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 
 	"github.com/google/uuid"
@@ -9,10 +11,14 @@ import (
 	. "github.com/onsi/gomega"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -31,8 +37,9 @@ var _ = Describe("ZomboidServer Controller", func() {
 	BeforeEach(func() {
 		ctx = context.Background()
 		reconciler = &ZomboidServerReconciler{
-			Client: k8sClient,
-			Scheme: k8sClient.Scheme(),
+			Client:   k8sClient,
+			Scheme:   k8sClient.Scheme(),
+			Recorder: &record.FakeRecorder{},
 		}
 	})
 
@@ -140,8 +147,9 @@ var _ = Describe("ZomboidServer Controller", func() {
 			}
 
 			reconciler = &ZomboidServerReconciler{
-				Client: k8sClient,
-				Scheme: k8sClient.Scheme(),
+				Client:   k8sClient,
+				Scheme:   k8sClient.Scheme(),
+				Recorder: &record.FakeRecorder{},
 			}
 
 			Expect(k8sClient.Create(ctx, zomboidServer)).To(Succeed())
@@ -426,7 +434,67 @@ var _ = Describe("ZomboidServer Controller", func() {
 
 				It("should set the correct command", func() {
 					expectedDBPath := fmt.Sprintf("/game-data/db/%s.db", zomboidServer.Name)
-					Expect(container.Args).To(Equal([]string{"--db", expectedDBPath}))
+					Expect(container.Args).To(Equal([]string{
+						"--db", expectedDBPath,
+						"--cfg-dir", "/ws4sqlite-config",
+					}))
+				})
+
+				It("should mount the generated ws4sqlite config", func() {
+					Expect(container.VolumeMounts).To(ContainElement(corev1.VolumeMount{
+						Name:      "ws4sqlite-config",
+						MountPath: "/ws4sqlite-config",
+						ReadOnly:  true,
+					}))
+				})
+
+				Context("credentials", func() {
+					var secret *corev1.Secret
+
+					BeforeEach(func() {
+						secret = &corev1.Secret{}
+						Expect(k8sClient.Get(ctx, types.NamespacedName{
+							Name:      zomboidServer.Name + "-sqlite-credentials",
+							Namespace: zomboidServer.Namespace,
+						}, secret)).To(Succeed())
+					})
+
+					It("should generate a username and password", func() {
+						Expect(string(secret.Data["username"])).To(Equal("zomboid-operator"))
+						Expect(secret.Data["password"]).To(HaveLen(48))
+					})
+
+					It("should generate a ws4sqlite config requiring the credentials", func() {
+						hash := sha256.Sum256(secret.Data["password"])
+						Expect(string(secret.Data[zomboidServer.Name+".yaml"])).To(Equal(fmt.Sprintf(`auth:
+  mode: HTTP
+  byCredentials:
+    - user: zomboid-operator
+      hashedPassword: %s
+`, hex.EncodeToString(hash[:]))))
+					})
+
+					It("should keep the password across reconciles", func() {
+						password := string(secret.Data["password"])
+
+						updateAndReconcile(ctx, k8sClient, reconciler, zomboidServer)
+
+						Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(secret), secret)).To(Succeed())
+						Expect(string(secret.Data["password"])).To(Equal(password))
+					})
+
+					It("should be owned by the ZomboidServer", func() {
+						Expect(secret.OwnerReferences).To(HaveLen(1))
+						Expect(secret.OwnerReferences[0].Name).To(Equal(zomboidServer.Name))
+					})
+
+					It("should set the SQLite credentials annotation", func() {
+						deployment := &appsv1.Deployment{}
+						Expect(k8sClient.Get(ctx, zomboidServerName, deployment)).To(Succeed())
+
+						hash := sha256.Sum256(secret.Data["password"])
+						Expect(deployment.Spec.Template.Annotations["secret/sqlite"]).To(Equal(hex.EncodeToString(hash[:])))
+					})
 				})
 
 				It("should mount the game-data volume", func() {
@@ -533,9 +601,31 @@ var _ = Describe("ZomboidServer Controller", func() {
 							ValueFrom: &corev1.EnvVarSource{
 								SecretKeyRef: &corev1.SecretKeySelector{
 									LocalObjectReference: corev1.LocalObjectReference{
-										Name: zomboidServer.Spec.Administrator.Password.Name,
+										Name: zomboidServer.Name + "-rcon",
+									},
+									Key: "password",
+								},
+							},
+						},
+						corev1.EnvVar{
+							Name: "SQLITE_USERNAME",
+							ValueFrom: &corev1.EnvVarSource{
+								SecretKeyRef: &corev1.SecretKeySelector{
+									LocalObjectReference: corev1.LocalObjectReference{
+										Name: zomboidServer.Name + "-sqlite-credentials",
+									},
+									Key: "username",
+								},
+							},
+						},
+						corev1.EnvVar{
+							Name: "SQLITE_PASSWORD",
+							ValueFrom: &corev1.EnvVarSource{
+								SecretKeyRef: &corev1.SecretKeySelector{
+									LocalObjectReference: corev1.LocalObjectReference{
+										Name: zomboidServer.Name + "-sqlite-credentials",
 									},
-									Key: zomboidServer.Spec.Administrator.Password.Key,
+									Key: "password",
 								},
 							},
 						},
@@ -702,6 +792,373 @@ var _ = Describe("ZomboidServer Controller", func() {
 			})
 		})
 
+		Context("RCON password", func() {
+			It("should generate an RCON password separate from the administrator password", func() {
+				secret := &corev1.Secret{}
+				Expect(k8sClient.Get(ctx, types.NamespacedName{
+					Name:      zomboidServer.Name + "-rcon",
+					Namespace: zomboidServer.Namespace,
+				}, secret)).To(Succeed())
+				Expect(secret.Data["password"]).To(HaveLen(48))
+				Expect(string(secret.Data["password"])).NotTo(Equal("the-extremely-secure-password"))
+			})
+
+			It("should pass the generated RCON password to the server", func() {
+				deployment := &appsv1.Deployment{}
+				Expect(k8sClient.Get(ctx, zomboidServerName, deployment)).To(Succeed())
+
+				Expect(deployment.Spec.Template.Spec.Containers[0].Env).To(ContainElement(corev1.EnvVar{
+					Name: "ZOMBOID_SERVER_RCON_PASSWORD",
+					ValueFrom: &corev1.EnvVarSource{
+						SecretKeyRef: &corev1.SecretKeySelector{
+							LocalObjectReference: corev1.LocalObjectReference{
+								Name: zomboidServer.Name + "-rcon",
+							},
+							Key: "password",
+						},
+					},
+				}))
+				Expect(deployment.Spec.Template.Annotations).To(HaveKey("secret/rcon"))
+			})
+
+			When("an RCON password is referenced", func() {
+				BeforeEach(func() {
+					rconSecret := &corev1.Secret{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      "custom-rcon",
+							Namespace: zomboidServer.Namespace,
+						},
+						StringData: map[string]string{
+							"rcon": "rcon-password",
+						},
+					}
+					Expect(k8sClient.Create(ctx, rconSecret)).To(Succeed())
+
+					zomboidServer.Spec.RCON = &zomboidv1.RCON{
+						Password: &corev1.SecretKeySelector{
+							LocalObjectReference: corev1.LocalObjectReference{
+								Name: rconSecret.Name,
+							},
+							Key: "rcon",
+						},
+					}
+					updateAndReconcile(ctx, k8sClient, reconciler, zomboidServer)
+				})
+
+				It("should use the referenced password for the server and metrics sidecar", func() {
+					deployment := &appsv1.Deployment{}
+					Expect(k8sClient.Get(ctx, zomboidServerName, deployment)).To(Succeed())
+
+					expected := &corev1.EnvVarSource{
+						SecretKeyRef: &corev1.SecretKeySelector{
+							LocalObjectReference: corev1.LocalObjectReference{
+								Name: "custom-rcon",
+							},
+							Key: "rcon",
+						},
+					}
+					Expect(deployment.Spec.Template.Spec.Containers[0].Env).To(ContainElement(corev1.EnvVar{
+						Name:      "ZOMBOID_SERVER_RCON_PASSWORD",
+						ValueFrom: expected,
+					}))
+					Expect(deployment.Spec.Template.Spec.Containers[2].Env).To(ContainElement(corev1.EnvVar{
+						Name:      "RCON_PASSWORD",
+						ValueFrom: expected,
+					}))
+				})
+
+				It("should set the RCON password annotation", func() {
+					deployment := &appsv1.Deployment{}
+					Expect(k8sClient.Get(ctx, zomboidServerName, deployment)).To(Succeed())
+
+					hash := sha256.Sum256([]byte("rcon-password"))
+					Expect(deployment.Spec.Template.Annotations["secret/rcon"]).To(Equal(hex.EncodeToString(hash[:])))
+				})
+
+				It("should remove the generated RCON secret", func() {
+					err := k8sClient.Get(ctx, types.NamespacedName{
+						Name:      zomboidServer.Name + "-rcon",
+						Namespace: zomboidServer.Namespace,
+					}, &corev1.Secret{})
+					Expect(errors.IsNotFound(err)).To(BeTrue())
+				})
+			})
+		})
+
+		Context("network policy", func() {
+			It("should not create a NetworkPolicy by default", func() {
+				networkPolicy := &networkingv1.NetworkPolicy{}
+				err := k8sClient.Get(ctx, zomboidServerName, networkPolicy)
+				Expect(errors.IsNotFound(err)).To(BeTrue())
+			})
+
+			When("network policies are enabled", func() {
+				var networkPolicy *networkingv1.NetworkPolicy
+
+				BeforeEach(func() {
+					zomboidServer.Spec.NetworkPolicy = &zomboidv1.NetworkPolicy{Enabled: true}
+					updateAndReconcile(ctx, k8sClient, reconciler, zomboidServer)
+
+					networkPolicy = &networkingv1.NetworkPolicy{}
+					Expect(k8sClient.Get(ctx, zomboidServerName, networkPolicy)).To(Succeed())
+				})
+
+				It("should select the server's pods", func() {
+					Expect(networkPolicy.Spec.PodSelector.MatchLabels).To(Equal(map[string]string{
+						"app.kubernetes.io/name":       "zomboidserver",
+						"app.kubernetes.io/instance":   zomboidServer.Name,
+						"app.kubernetes.io/managed-by": "zomboid-operator",
+					}))
+					Expect(networkPolicy.Spec.PolicyTypes).To(ConsistOf(networkingv1.PolicyTypeIngress))
+				})
+
+				It("should allow game traffic from anywhere", func() {
+					rule := networkPolicy.Spec.Ingress[0]
+					Expect(rule.From).To(BeEmpty())
+					Expect(rule.Ports).To(ConsistOf(
+						networkingv1.NetworkPolicyPort{
+							Protocol: ptr.To(corev1.ProtocolUDP),
+							Port:     ptr.To(intstr.FromInt32(16261)),
+						},
+						networkingv1.NetworkPolicyPort{
+							Protocol: ptr.To(corev1.ProtocolUDP),
+							Port:     ptr.To(intstr.FromInt32(16262)),
+						},
+					))
+				})
+
+				It("should only allow the operator to reach RCON, ws4sqlite and metrics", func() {
+					rule := networkPolicy.Spec.Ingress[1]
+					Expect(rule.From).To(ConsistOf(networkingv1.NetworkPolicyPeer{
+						NamespaceSelector: &metav1.LabelSelector{
+							MatchLabels: map[string]string{"kubernetes.io/metadata.name": "zomboid-system"},
+						},
+						PodSelector: &metav1.LabelSelector{
+							MatchLabels: map[string]string{"control-plane": "controller-manager"},
+						},
+					}))
+					Expect(rule.Ports).To(ConsistOf(
+						networkingv1.NetworkPolicyPort{
+							Protocol: ptr.To(corev1.ProtocolTCP),
+							Port:     ptr.To(intstr.FromInt32(27015)),
+						},
+						networkingv1.NetworkPolicyPort{
+							Protocol: ptr.To(corev1.ProtocolTCP),
+							Port:     ptr.To(intstr.FromInt32(12321)),
+						},
+						networkingv1.NetworkPolicyPort{
+							Protocol: ptr.To(corev1.ProtocolTCP),
+							Port:     ptr.To(intstr.FromInt32(9090)),
+						},
+					))
+				})
+
+				It("should allow Prometheus to scrape metrics", func() {
+					rule := networkPolicy.Spec.Ingress[2]
+					Expect(rule.From).To(ConsistOf(networkingv1.NetworkPolicyPeer{
+						NamespaceSelector: &metav1.LabelSelector{
+							MatchLabels: map[string]string{"metrics": "enabled"},
+						},
+						PodSelector: &metav1.LabelSelector{},
+					}))
+					Expect(rule.Ports).To(ConsistOf(networkingv1.NetworkPolicyPort{
+						Protocol: ptr.To(corev1.ProtocolTCP),
+						Port:     ptr.To(intstr.FromInt32(9090)),
+					}))
+				})
+
+				It("should delete the NetworkPolicy when disabled again", func() {
+					Expect(k8sClient.Get(ctx, zomboidServerName, zomboidServer)).To(Succeed())
+					zomboidServer.Spec.NetworkPolicy.Enabled = false
+					updateAndReconcile(ctx, k8sClient, reconciler, zomboidServer)
+
+					err := k8sClient.Get(ctx, zomboidServerName, &networkingv1.NetworkPolicy{})
+					Expect(errors.IsNotFound(err)).To(BeTrue())
+				})
+			})
+
+			It("should leave a NetworkPolicy it doesn't own alone", func() {
+				networkPolicy := &networkingv1.NetworkPolicy{
+					ObjectMeta: metav1.ObjectMeta{Name: zomboidServerName.Name, Namespace: zomboidServerName.Namespace},
+					Spec: networkingv1.NetworkPolicySpec{
+						PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress},
+					},
+				}
+				Expect(k8sClient.Create(ctx, networkPolicy)).To(Succeed())
+				DeferCleanup(k8sClient.Delete, networkPolicy)
+
+				updateAndReconcile(ctx, k8sClient, reconciler, zomboidServer)
+
+				Expect(k8sClient.Get(ctx, zomboidServerName, networkPolicy)).To(Succeed())
+				Expect(networkPolicy.OwnerReferences).To(BeEmpty())
+			})
+		})
+
+		Context("settings ConfigMap", func() {
+			configMapName := func() types.NamespacedName {
+				return types.NamespacedName{Name: zomboidServer.Name + "-settings", Namespace: zomboidServer.Namespace}
+			}
+
+			It("should not create a settings ConfigMap by default", func() {
+				err := k8sClient.Get(ctx, configMapName(), &corev1.ConfigMap{})
+				Expect(errors.IsNotFound(err)).To(BeTrue())
+			})
+
+			It("should keep the effective server.ini in the ConfigMap while enabled", func() {
+				zomboidServer.Spec.SettingsConfigMap = &zomboidv1.SettingsConfigMap{Enabled: true}
+				zomboidServer.Spec.Settings.Player.MaxPlayers = ptr.To(int32(12))
+				updateAndReconcile(ctx, k8sClient, reconciler, zomboidServer)
+
+				configMap := &corev1.ConfigMap{}
+				Expect(k8sClient.Get(ctx, configMapName(), configMap)).To(Succeed())
+				Expect(configMap.OwnerReferences).To(HaveLen(1))
+				Expect(configMap.Data[zomboidServer.Name+".ini"]).To(ContainSubstring("\nMaxPlayers=12\n"))
+
+				Expect(k8sClient.Get(ctx, zomboidServerName, zomboidServer)).To(Succeed())
+				zomboidServer.Spec.SettingsConfigMap.Enabled = false
+				updateAndReconcile(ctx, k8sClient, reconciler, zomboidServer)
+
+				err := k8sClient.Get(ctx, configMapName(), &corev1.ConfigMap{})
+				Expect(errors.IsNotFound(err)).To(BeTrue())
+			})
+
+			It("should leave a ConfigMap it doesn't own alone", func() {
+				configMap := &corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{Name: configMapName().Name, Namespace: configMapName().Namespace},
+					Data:       map[string]string{"notes": "kept by an admin"},
+				}
+				Expect(k8sClient.Create(ctx, configMap)).To(Succeed())
+				DeferCleanup(k8sClient.Delete, configMap)
+
+				updateAndReconcile(ctx, k8sClient, reconciler, zomboidServer)
+
+				Expect(k8sClient.Get(ctx, configMapName(), configMap)).To(Succeed())
+				Expect(configMap.Data).To(HaveKeyWithValue("notes", "kept by an admin"))
+			})
+		})
+
+		Context("spawn regions", func() {
+			configMapName := func() types.NamespacedName {
+				return types.NamespacedName{Name: zomboidServer.Name + "-spawn-regions", Namespace: zomboidServer.Namespace}
+			}
+
+			It("should leave the spawn regions to the server by default", func() {
+				err := k8sClient.Get(ctx, configMapName(), &corev1.ConfigMap{})
+				Expect(errors.IsNotFound(err)).To(BeTrue())
+			})
+
+			It("should mount the rendered spawn regions over the server's own", func() {
+				zomboidServer.Spec.Spawn = &zomboidv1.Spawn{Regions: []zomboidv1.SpawnRegion{
+					{Name: "Mall", Points: map[string][]zomboidv1.SpawnPoint{
+						"unemployed": {{WorldX: 45, WorldY: 33, PosX: 120, PosY: 150}},
+					}},
+				}}
+				updateAndReconcile(ctx, k8sClient, reconciler, zomboidServer)
+
+				file := zomboidServer.Name + "_spawnregions.lua"
+				configMap := &corev1.ConfigMap{}
+				Expect(k8sClient.Get(ctx, configMapName(), configMap)).To(Succeed())
+				Expect(configMap.OwnerReferences).To(HaveLen(1))
+				Expect(configMap.Data[file]).To(ContainSubstring(`{ name = "Mall", points = {`))
+				Expect(configMap.Data[file]).To(ContainSubstring(`{ name = "Riverside, KY", file = "media/maps/Riverside, KY/spawnpoints.lua" },`))
+
+				deployment := &appsv1.Deployment{}
+				Expect(k8sClient.Get(ctx, zomboidServerName, deployment)).To(Succeed())
+				container := deployment.Spec.Template.Spec.Containers[0]
+				Expect(container.VolumeMounts).To(ContainElement(corev1.VolumeMount{
+					Name:      "spawn-regions",
+					MountPath: "/game-data/Server/" + file,
+					SubPath:   file,
+					ReadOnly:  true,
+				}))
+
+				Expect(k8sClient.Get(ctx, zomboidServerName, zomboidServer)).To(Succeed())
+				Expect(zomboidServer.Status.PendingRestart).To(ContainElement(HaveField("Name", "SpawnRegions")))
+
+				// The subPath mount doesn't see ConfigMap updates, so changing
+				// the regions rolls the pod
+				hash := deployment.Spec.Template.Annotations["configmap/spawn-regions"]
+				Expect(hash).NotTo(BeEmpty())
+				zomboidServer.Spec.Spawn.Regions[0].Name = "Gas Station"
+				updateAndReconcile(ctx, k8sClient, reconciler, zomboidServer)
+				Expect(k8sClient.Get(ctx, zomboidServerName, deployment)).To(Succeed())
+				Expect(deployment.Spec.Template.Annotations["configmap/spawn-regions"]).NotTo(Equal(hash))
+
+				Expect(k8sClient.Get(ctx, zomboidServerName, zomboidServer)).To(Succeed())
+				zomboidServer.Spec.Spawn = nil
+				updateAndReconcile(ctx, k8sClient, reconciler, zomboidServer)
+
+				err := k8sClient.Get(ctx, configMapName(), &corev1.ConfigMap{})
+				Expect(errors.IsNotFound(err)).To(BeTrue())
+				Expect(k8sClient.Get(ctx, zomboidServerName, deployment)).To(Succeed())
+				Expect(deployment.Spec.Template.Spec.Volumes).NotTo(ContainElement(HaveField("Name", "spawn-regions")))
+			})
+
+			It("should leave a ConfigMap it doesn't own alone", func() {
+				configMap := &corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{Name: configMapName().Name, Namespace: configMapName().Namespace},
+					Data:       map[string]string{"notes": "kept by an admin"},
+				}
+				Expect(k8sClient.Create(ctx, configMap)).To(Succeed())
+				DeferCleanup(k8sClient.Delete, configMap)
+
+				updateAndReconcile(ctx, k8sClient, reconciler, zomboidServer)
+
+				Expect(k8sClient.Get(ctx, configMapName(), configMap)).To(Succeed())
+				Expect(configMap.Data).To(HaveKeyWithValue("notes", "kept by an admin"))
+				Expect(k8sClient.Get(ctx, zomboidServerName, zomboidServer)).To(Succeed())
+				Expect(zomboidServer.Status.PendingRestart).NotTo(ContainElement(HaveField("Name", "SpawnRegions")))
+			})
+		})
+
+		Context("settings profiles", func() {
+			It("should resolve the server's settings from its profiles", func() {
+				clusterProfile := &zomboidv1.ClusterZomboidSettingsProfile{
+					ObjectMeta: metav1.ObjectMeta{Name: "community-" + zomboidServer.Name},
+				}
+				clusterProfile.Spec.Settings.Player.MaxPlayers = ptr.To(int32(24))
+				clusterProfile.Spec.Settings.Player.PingLimit = ptr.To(int32(400))
+				Expect(k8sClient.Create(ctx, clusterProfile)).To(Succeed())
+				DeferCleanup(k8sClient.Delete, clusterProfile)
+
+				zomboidServer.Spec.SettingsFrom = []zomboidv1.SettingsProfileReference{
+					{Kind: "ClusterZomboidSettingsProfile", Name: clusterProfile.Name},
+				}
+				zomboidServer.Spec.Settings.Player.MaxPlayers = ptr.To(int32(16))
+				updateAndReconcile(ctx, k8sClient, reconciler, zomboidServer)
+
+				Expect(k8sClient.Get(ctx, zomboidServerName, zomboidServer)).To(Succeed())
+				Expect(zomboidServer.Status.ResolvedSettings).NotTo(BeNil())
+				Expect(zomboidServer.Status.ResolvedSettings.Player.MaxPlayers).To(Equal(ptr.To(int32(16))))
+				Expect(zomboidServer.Status.ResolvedSettings.Player.PingLimit).To(Equal(ptr.To(int32(400))))
+
+				requests := reconciler.findZomboidServersForSettingsProfile(ctx, clusterProfile)
+				Expect(requests).To(ContainElement(reconcile.Request{NamespacedName: zomboidServerName}))
+			})
+
+			It("should report profiles that don't exist", func() {
+				zomboidServer.Spec.SettingsFrom = []zomboidv1.SettingsProfileReference{{Name: "missing"}}
+				updateAndReconcile(ctx, k8sClient, reconciler, zomboidServer)
+
+				Expect(k8sClient.Get(ctx, zomboidServerName, zomboidServer)).To(Succeed())
+				condition := meta.FindStatusCondition(zomboidServer.Status.Conditions, zomboidv1.TypeSettingsSynced)
+				Expect(condition).NotTo(BeNil())
+				Expect(condition.Reason).To(Equal(zomboidv1.ReasonSettingsProfileMissing))
+				Expect(condition.Message).To(ContainSubstring("ZomboidSettingsProfile missing"))
+			})
+
+			It("should keep reconciling the infrastructure while a profile is missing", func() {
+				zomboidServer.Spec.SettingsFrom = []zomboidv1.SettingsProfileReference{{Name: "missing"}}
+				updateAndReconcile(ctx, k8sClient, reconciler, zomboidServer)
+
+				Expect(k8sClient.Get(ctx, zomboidServerName, zomboidServer)).To(Succeed())
+				condition := meta.FindStatusCondition(zomboidServer.Status.Conditions, zomboidv1.TypeInfrastructureReady)
+				Expect(condition).NotTo(BeNil())
+				Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+				Expect(condition.ObservedGeneration).To(Equal(zomboidServer.Generation))
+			})
+		})
+
 		Context("Updating an existing ZomboidServer", func() {
 			BeforeEach(func() {
 				Expect(k8sClient.Get(ctx, zomboidServerName, zomboidServer)).To(Succeed())
@@ -0,0 +1,145 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	zomboidv1 "github.com/zomboidhost/zomboid-operator/api/v1"
+	"github.com/zomboidhost/zomboid-operator/internal/settings"
+	"github.com/zomboidhost/zomboid-operator/internal/workshop"
+)
+
+// defaultWorkshopUpdateInterval is how often the Steam Workshop is checked
+// when spec.workshopUpdates.interval is unset
+const defaultWorkshopUpdateInterval = 15 * time.Minute
+
+// pendingWorkshopUpdates is the name updated workshop items wait under in
+// status.pendingRestart
+const pendingWorkshopUpdates = "WorkshopUpdates"
+
+// readInstalledWorkshopItems prints the manifest of the installed workshop
+// items, or nothing before the server has installed any
+var readInstalledWorkshopItems = []string{"/bin/sh", "-c", "cat " + workshop.InstalledManifest + " 2>/dev/null || true"}
+
+// checkWorkshopUpdates compares the installed versions of the server's
+// workshop items with those on the Steam Workshop, as often as
+// spec.workshopUpdates asks.  Items updated since they were installed wait in
+// status.pendingRestart for the server to restart and download them, unless
+// the update was already waiting for an earlier restart that didn't download
+// it.  Failing to check doesn't hold up the rest of the reconcile.
+func (r *ZomboidServerReconciler) checkWorkshopUpdates(ctx context.Context, zomboidServer *zomboidv1.ZomboidServer) {
+	updates := zomboidServer.Spec.WorkshopUpdates
+	workshopIDs := settings.WorkshopItems(settings.Desired(zomboidServer))
+	if updates == nil || !updates.Enabled || len(workshopIDs) == 0 {
+		zomboidServer.Status.WorkshopItems = nil
+		zomboidServer.Status.WorkshopLastChecked = nil
+		meta.RemoveStatusCondition(&zomboidServer.Status.Conditions, zomboidv1.TypeWorkshopUpToDate)
+		return
+	}
+
+	if lastChecked := zomboidServer.Status.WorkshopLastChecked; lastChecked != nil && time.Since(lastChecked.Time) < workshopUpdateInterval(zomboidServer) {
+		return
+	}
+	now := metav1.Now()
+	zomboidServer.Status.WorkshopLastChecked = &now
+
+	installed, available, err := r.workshopVersions(ctx, zomboidServer, workshopIDs)
+	if err != nil {
+		log.FromContext(ctx).Error(err, "failed to check for workshop updates", "name", zomboidServer.Name)
+		meta.SetStatusCondition(&zomboidServer.Status.Conditions, metav1.Condition{
+			Type:               zomboidv1.TypeWorkshopUpToDate,
+			ObservedGeneration: zomboidServer.Generation,
+			Status:             metav1.ConditionUnknown,
+			Reason:             zomboidv1.ReasonWorkshopNotChecked,
+			Message:            fmt.Sprintf("Failed to check for workshop updates: %v", err),
+		})
+		return
+	}
+
+	previous := map[string]zomboidv1.WorkshopItemStatus{}
+	for _, item := range zomboidServer.Status.WorkshopItems {
+		previous[item.WorkshopID] = item
+	}
+
+	var items []zomboidv1.WorkshopItemStatus
+	var outdated, updated []string
+	for _, workshopID := range workshopIDs {
+		item := zomboidv1.WorkshopItemStatus{WorkshopID: workshopID}
+		if installedAt, ok := installed[workshopID]; ok {
+			item.Installed = &metav1.Time{Time: installedAt}
+		}
+		if published, ok := available[workshopID]; ok {
+			item.Available = &metav1.Time{Time: published.Updated}
+		}
+		items = append(items, item)
+
+		// Items that aren't installed yet are downloaded on the next restart anyway
+		if item.Installed == nil || item.Available == nil || !item.Available.After(item.Installed.Time) {
+			continue
+		}
+		outdated = append(outdated, workshopID)
+		if last, ok := previous[workshopID]; !ok || last.Available == nil || !last.Available.Equal(item.Available) {
+			updated = append(updated, workshopID)
+			r.Recorder.Eventf(zomboidServer, corev1.EventTypeNormal, EventReasonWorkshopItemUpdated,
+				"Workshop item %s was updated at %s", workshopID, item.Available.UTC().Format(time.RFC3339))
+		}
+	}
+	zomboidServer.Status.WorkshopItems = items
+
+	if len(updated) > 0 {
+		addPendingRestart(zomboidServer, pendingWorkshopUpdates, strings.Join(outdated, ";"))
+	}
+
+	if len(outdated) > 0 {
+		meta.SetStatusCondition(&zomboidServer.Status.Conditions, metav1.Condition{
+			Type:               zomboidv1.TypeWorkshopUpToDate,
+			ObservedGeneration: zomboidServer.Generation,
+			Status:             metav1.ConditionFalse,
+			Reason:             zomboidv1.ReasonWorkshopOutdated,
+			Message:            fmt.Sprintf("Updates to %s are downloaded once the server restarts", strings.Join(outdated, ", ")),
+		})
+		return
+	}
+	meta.SetStatusCondition(&zomboidServer.Status.Conditions, metav1.Condition{
+		Type:               zomboidv1.TypeWorkshopUpToDate,
+		ObservedGeneration: zomboidServer.Generation,
+		Status:             metav1.ConditionTrue,
+		Reason:             zomboidv1.ReasonWorkshopUpToDate,
+		Message:            "The server runs the latest versions of its workshop items",
+	})
+}
+
+// workshopUpdateInterval returns how often the Steam Workshop is checked for
+// a server
+func workshopUpdateInterval(zomboidServer *zomboidv1.ZomboidServer) time.Duration {
+	if updates := zomboidServer.Spec.WorkshopUpdates; updates != nil && updates.Interval != nil && updates.Interval.Duration > 0 {
+		return updates.Interval.Duration
+	}
+	return defaultWorkshopUpdateInterval
+}
+
+// workshopVersions returns when the installed and latest versions of a
+// server's workshop items were published
+func (r *ZomboidServerReconciler) workshopVersions(ctx context.Context, zomboidServer *zomboidv1.ZomboidServer, workshopIDs []string) (map[string]time.Time, map[string]workshop.Item, error) {
+	manifest, err := r.exec(ctx, zomboidServer, readInstalledWorkshopItems)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read the installed workshop items: %w", err)
+	}
+	installed, err := workshop.ParseInstalled(manifest)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	available, err := r.Workshop.Items(ctx, workshopIDs)
+	if err != nil {
+		return nil, nil, err
+	}
+	return installed, available, nil
+}
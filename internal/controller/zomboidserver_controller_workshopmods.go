@@ -0,0 +1,235 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	zomboidv1 "github.com/zomboidhost/zomboid-operator/api/v1"
+	"github.com/zomboidhost/zomboid-operator/internal/settings"
+	"github.com/zomboidhost/zomboid-operator/internal/workshop"
+)
+
+// resolveWorkshopMods records what the workshop items of the server's mods
+// contain and require in status.workshopMods, from the Steam Workshop and the
+// mod.info files of the downloaded items, and whether the server loads
+// everything they require.  They're resolved again once the items or the
+// spec change, or the server has restarted and so downloaded items.  Failing
+// to resolve them doesn't hold up the rest of the reconcile, and is retried
+// as often as the Steam Workshop is checked for updates.
+func (r *ZomboidServerReconciler) resolveWorkshopMods(ctx context.Context, zomboidServer *zomboidv1.ZomboidServer) {
+	configured := settings.Configured(zomboidServer)
+	workshopIDs := settings.WorkshopItems(configured)
+	if len(workshopIDs) == 0 {
+		zomboidServer.Status.WorkshopMods = nil
+		zomboidServer.Status.WorkshopModsResolved = nil
+		meta.RemoveStatusCondition(&zomboidServer.Status.Conditions, zomboidv1.TypeWorkshopDependenciesMet)
+		return
+	}
+	if !r.workshopModsStale(ctx, zomboidServer, workshopIDs) {
+		return
+	}
+
+	now := metav1.Now()
+	zomboidServer.Status.WorkshopModsResolved = &now
+	resolved, err := r.workshopModStatuses(ctx, zomboidServer, configured, workshopIDs)
+	if err != nil {
+		log.FromContext(ctx).Error(err, "failed to resolve workshop mods", "name", zomboidServer.Name)
+		meta.SetStatusCondition(&zomboidServer.Status.Conditions, metav1.Condition{
+			Type:               zomboidv1.TypeWorkshopDependenciesMet,
+			ObservedGeneration: zomboidServer.Generation,
+			Status:             metav1.ConditionUnknown,
+			Reason:             zomboidv1.ReasonWorkshopModsNotResolved,
+			Message:            fmt.Sprintf("Failed to resolve workshop mods: %v", err),
+		})
+		return
+	}
+
+	if zomboidServer.Spec.WorkshopDependencies == zomboidv1.WorkshopDependenciesAdd {
+		for _, item := range resolved {
+			if len(item.RequiredBy) == 0 || slices.ContainsFunc(zomboidServer.Status.WorkshopMods, func(previous zomboidv1.WorkshopModStatus) bool {
+				return previous.WorkshopID == item.WorkshopID && len(previous.RequiredBy) > 0
+			}) {
+				continue
+			}
+			r.Recorder.Eventf(zomboidServer, corev1.EventTypeNormal, EventReasonWorkshopDependencyAdded,
+				"Loading workshop item %s, required by %s", workshopItemName(item), strings.Join(item.RequiredBy, ", "))
+		}
+	}
+
+	zomboidServer.Status.WorkshopMods = resolved
+
+	missing := settings.MissingDependencies(settings.Desired(zomboidServer), resolved)
+	if len(missing) == 0 {
+		meta.SetStatusCondition(&zomboidServer.Status.Conditions, metav1.Condition{
+			Type:               zomboidv1.TypeWorkshopDependenciesMet,
+			ObservedGeneration: zomboidServer.Generation,
+			Status:             metav1.ConditionTrue,
+			Reason:             zomboidv1.ReasonDependenciesMet,
+			Message:            "The server loads everything its workshop mods require",
+		})
+		return
+	}
+
+	message := fmt.Sprintf("The server doesn't load %s", strings.Join(missing, "; "))
+	if zomboidServer.Spec.WorkshopDependencies == zomboidv1.WorkshopDependenciesAdd {
+		message += "; no downloaded workshop item contains them"
+	}
+	meta.SetStatusCondition(&zomboidServer.Status.Conditions, metav1.Condition{
+		Type:               zomboidv1.TypeWorkshopDependenciesMet,
+		ObservedGeneration: zomboidServer.Generation,
+		Status:             metav1.ConditionFalse,
+		Reason:             zomboidv1.ReasonDependenciesMissing,
+		Message:            message,
+	})
+}
+
+// workshopModsStale returns whether a server's workshop mods need resolving
+// again
+func (r *ZomboidServerReconciler) workshopModsStale(ctx context.Context, zomboidServer *zomboidv1.ZomboidServer, workshopIDs []string) bool {
+	resolvedAt := zomboidServer.Status.WorkshopModsResolved
+	condition := meta.FindStatusCondition(zomboidServer.Status.Conditions, zomboidv1.TypeWorkshopDependenciesMet)
+	if resolvedAt == nil || condition == nil || condition.ObservedGeneration != zomboidServer.Generation {
+		return true
+	}
+	if condition.Status == metav1.ConditionUnknown {
+		return time.Since(resolvedAt.Time) >= workshopUpdateInterval(zomboidServer)
+	}
+
+	var listed []string
+	for _, item := range zomboidServer.Status.WorkshopMods {
+		if len(item.RequiredBy) == 0 {
+			listed = append(listed, item.WorkshopID)
+		}
+	}
+	wanted := slices.Clone(workshopIDs)
+	slices.Sort(listed)
+	slices.Sort(wanted)
+	if !slices.Equal(listed, wanted) {
+		return true
+	}
+
+	startedAt, err := r.serverStartedAt(ctx, zomboidServer)
+	return err != nil || (startedAt != nil && startedAt.After(resolvedAt.Time))
+}
+
+// workshopModStatuses describes the workshop items a server's settings list,
+// followed by the items they require that the settings don't, as found on
+// their pages or by the mods they require in the downloaded items
+func (r *ZomboidServerReconciler) workshopModStatuses(ctx context.Context, zomboidServer *zomboidv1.ZomboidServer, configured zomboidv1.ZomboidSettings, workshopIDs []string) ([]zomboidv1.WorkshopModStatus, error) {
+	output, err := r.exec(ctx, zomboidServer, workshop.ListModInfos)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the downloaded mods: %w", err)
+	}
+	installed := workshop.ParseModInfos(output)
+
+	// The first downloaded item, by workshop ID, that contains a mod
+	// provides it
+	downloaded := make([]string, 0, len(installed))
+	for workshopID := range installed {
+		downloaded = append(downloaded, workshopID)
+	}
+	sort.Strings(downloaded)
+	providers := map[string]string{}
+	for _, workshopID := range downloaded {
+		for _, mod := range installed[workshopID] {
+			if _, ok := providers[mod.ID]; !ok {
+				providers[mod.ID] = workshopID
+			}
+		}
+	}
+
+	// Only the mods the server loads count towards what an item requires:
+	// those its settings pick, or every mod of the items they don't pick any
+	// from and of the items they require
+	merged := configured
+	settings.MergeWorkshopMods(&merged)
+	picked := map[string]bool{}
+	if merged.Mods.Mods != nil {
+		for _, modID := range strings.Split(*merged.Mods.Mods, ";") {
+			picked[strings.TrimSpace(modID)] = true
+		}
+	}
+	loadsAll := map[string]bool{}
+	for _, mod := range configured.WorkshopMods {
+		if mod.WorkshopID != nil && (mod.ModID == nil || *mod.ModID == "") {
+			loadsAll[*mod.WorkshopID] = true
+		}
+	}
+
+	listed := map[string]bool{}
+	for _, workshopID := range workshopIDs {
+		listed[workshopID] = true
+	}
+
+	statuses := map[string]*zomboidv1.WorkshopModStatus{}
+	requiredBy := map[string][]string{}
+	var order []string
+	for queue := workshopIDs; len(queue) > 0; {
+		items, err := r.Workshop.Items(ctx, queue)
+		if err != nil {
+			return nil, err
+		}
+
+		var next []string
+		for _, workshopID := range queue {
+			status := &zomboidv1.WorkshopModStatus{WorkshopID: workshopID}
+			if item, ok := items[workshopID]; ok {
+				status.Title = item.Title
+				status.Size = item.Size
+				status.RequiredItems = item.RequiredItems
+			}
+			for _, mod := range installed[workshopID] {
+				status.Mods = append(status.Mods, zomboidv1.ResolvedMod{ID: mod.ID, Name: mod.Name, Requires: mod.Requires})
+			}
+			statuses[workshopID] = status
+			order = append(order, workshopID)
+
+			required := slices.Clone(status.RequiredItems)
+			for _, mod := range status.Mods {
+				if !picked[mod.ID] && listed[workshopID] && !loadsAll[workshopID] {
+					continue
+				}
+				for _, modID := range mod.Requires {
+					if provider, ok := providers[modID]; ok && provider != workshopID {
+						required = append(required, provider)
+					}
+				}
+			}
+			for _, dependency := range required {
+				if listed[dependency] || slices.Contains(requiredBy[dependency], workshopID) {
+					continue
+				}
+				if _, ok := requiredBy[dependency]; !ok && statuses[dependency] == nil {
+					next = append(next, dependency)
+				}
+				requiredBy[dependency] = append(requiredBy[dependency], workshopID)
+			}
+		}
+		queue = next
+	}
+
+	resolved := make([]zomboidv1.WorkshopModStatus, 0, len(order))
+	for _, workshopID := range order {
+		status := statuses[workshopID]
+		status.RequiredBy = requiredBy[workshopID]
+		resolved = append(resolved, *status)
+	}
+	return resolved, nil
+}
+
+// workshopItemName names a workshop item by its ID and, when known, title
+func workshopItemName(item zomboidv1.WorkshopModStatus) string {
+	if item.Title == "" {
+		return item.WorkshopID
+	}
+	return fmt.Sprintf("%s (%s)", item.WorkshopID, item.Title)
+}
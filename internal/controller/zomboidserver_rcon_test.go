@@ -0,0 +1,650 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"net"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+
+	zomboidv1 "github.com/zomboidhost/zomboid-operator/api/v1"
+	"github.com/zomboidhost/zomboid-operator/internal/rcon"
+	"github.com/zomboidhost/zomboid-operator/internal/rcon/fake"
+	"github.com/zomboidhost/zomboid-operator/internal/settings"
+	"github.com/zomboidhost/zomboid-operator/internal/workshop"
+	workshopfake "github.com/zomboidhost/zomboid-operator/internal/workshop/fake"
+)
+
+var _ = Describe("ZomboidServer RCON Tests", func() {
+	var (
+		ctx        context.Context
+		reconciler *ZomboidServerReconciler
+		recorder   *record.FakeRecorder
+		server     *fake.Server
+	)
+
+	Context("When managing a running ZomboidServer", func() {
+		var (
+			zomboidServerName types.NamespacedName
+			zomboidServer     *zomboidv1.ZomboidServer
+		)
+
+		BeforeEach(func() {
+			ctx = context.Background()
+
+			var err error
+			server, err = fake.NewServer("rcon-password")
+			Expect(err).NotTo(HaveOccurred())
+			DeferCleanup(server.Close)
+
+			// Large enough that a first sync of every setting doesn't block
+			recorder = record.NewFakeRecorder(1024)
+			reconciler = &ZomboidServerReconciler{
+				Client:   k8sClient,
+				Scheme:   k8sClient.Scheme(),
+				Recorder: recorder,
+				RCON:     rcon.NewManager(),
+				ServiceEndpoint: func(ctx context.Context, name, namespace string, port int) (string, int, func(), error) {
+					if port == 12321 {
+						hostname, port := server.SQLiteEndpoint()
+						return hostname, port, func() {}, nil
+					}
+
+					hostname, rconPort, err := net.SplitHostPort(server.RCONAddr())
+					if err != nil {
+						return "", 0, func() {}, err
+					}
+					port, err = strconv.Atoi(rconPort)
+					return hostname, port, func() {}, err
+				},
+			}
+		})
+
+		BeforeEach(func() {
+			namespace := &corev1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-rcon-" + uuid.New().String(),
+				},
+			}
+			Expect(k8sClient.Create(ctx, namespace)).Should(Succeed())
+
+			zomboidServerName = types.NamespacedName{
+				Name:      "test-server",
+				Namespace: namespace.Name,
+			}
+
+			for name, password := range map[string]string{
+				"admin-pass": "test123",
+				"rcon-pass":  "rcon-password",
+				"alice-pass": "alice123",
+			} {
+				secret := &corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      name,
+						Namespace: namespace.Name,
+					},
+					StringData: map[string]string{
+						"password": password,
+					},
+				}
+				Expect(k8sClient.Create(ctx, secret)).Should(Succeed())
+			}
+
+			zomboidServer = &zomboidv1.ZomboidServer{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      zomboidServerName.Name,
+					Namespace: namespace.Name,
+				},
+				Spec: zomboidv1.ZomboidServerSpec{
+					Version: "latest",
+					Resources: corev1.ResourceRequirements{
+						Requests: corev1.ResourceList{
+							corev1.ResourceCPU:    resource.MustParse("1"),
+							corev1.ResourceMemory: resource.MustParse("1Gi"),
+						},
+						Limits: corev1.ResourceList{
+							corev1.ResourceCPU:    resource.MustParse("2"),
+							corev1.ResourceMemory: resource.MustParse("2Gi"),
+						},
+					},
+					Storage: zomboidv1.Storage{
+						Request: resource.MustParse("10Gi"),
+					},
+					Administrator: zomboidv1.Administrator{
+						Username: "admin",
+						Password: corev1.SecretKeySelector{
+							LocalObjectReference: corev1.LocalObjectReference{
+								Name: "admin-pass",
+							},
+							Key: "password",
+						},
+					},
+					RCON: &zomboidv1.RCON{
+						Password: &corev1.SecretKeySelector{
+							LocalObjectReference: corev1.LocalObjectReference{
+								Name: "rcon-pass",
+							},
+							Key: "password",
+						},
+					},
+				},
+			}
+			Expect(k8sClient.Create(ctx, zomboidServer)).Should(Succeed())
+
+			Expect(reconcileAndReload(ctx, reconciler, zomboidServerName, zomboidServer)).Should(Succeed())
+
+			credentials := &corev1.Secret{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{
+				Name:      zomboidServer.Name + "-sqlite-credentials",
+				Namespace: zomboidServer.Namespace,
+			}, credentials)).Should(Succeed())
+			server.RequireSQLiteCredentials(string(credentials.Data["username"]), string(credentials.Data["password"]))
+
+			deployment := &appsv1.Deployment{}
+			Expect(k8sClient.Get(ctx, zomboidServerName, deployment)).Should(Succeed())
+			deployment.Status.Replicas = 1
+			deployment.Status.ReadyReplicas = 1
+			Expect(k8sClient.Status().Update(ctx, deployment)).Should(Succeed())
+
+			Expect(reconcileAndReload(ctx, reconciler, zomboidServerName, zomboidServer)).Should(Succeed())
+		})
+
+		It("Should report the RCON session as reachable", func() {
+			rconCondition := meta.FindStatusCondition(zomboidServer.Status.Conditions, zomboidv1.TypeRCONReachable)
+			Expect(rconCondition).NotTo(BeNil())
+			Expect(rconCondition.Status).To(Equal(metav1.ConditionTrue))
+			Expect(rconCondition.Reason).To(Equal(zomboidv1.ReasonRCONConnected))
+		})
+
+		It("Should report settings, mods, the database and users as synced", func() {
+			for _, conditionType := range []string{
+				zomboidv1.TypeSettingsSynced,
+				zomboidv1.TypeModsReady,
+				zomboidv1.TypeDatabaseReachable,
+				zomboidv1.TypeUsersSynced,
+			} {
+				condition := meta.FindStatusCondition(zomboidServer.Status.Conditions, conditionType)
+				Expect(condition).NotTo(BeNil(), conditionType)
+				Expect(condition.Status).To(Equal(metav1.ConditionTrue), conditionType)
+				Expect(condition.ObservedGeneration).To(Equal(zomboidServer.Generation), conditionType)
+			}
+		})
+
+		It("Should observe the server's settings", func() {
+			Expect(zomboidServer.Status.SettingsLastObserved).NotTo(BeNil())
+			Expect(zomboidServer.Status.Settings).NotTo(BeNil())
+			Expect(zomboidServer.Status.Settings.Identity.ResetID).To(Equal(ptr.To(int32(485871306))))
+		})
+
+		It("Should apply desired settings", func() {
+			zomboidServer.Spec.Settings.Identity.PublicName = ptr.To("Operator Server")
+			zomboidServer.Spec.Settings.Player.MaxPlayers = ptr.To(int32(12))
+			Expect(k8sClient.Update(ctx, zomboidServer)).Should(Succeed())
+
+			Expect(reconcileAndReload(ctx, reconciler, zomboidServerName, zomboidServer)).Should(Succeed())
+
+			publicName, _ := server.Option("PublicName")
+			Expect(publicName).To(Equal("Operator Server"))
+			maxPlayers, _ := server.Option("MaxPlayers")
+			Expect(maxPlayers).To(Equal("12"))
+			Expect(zomboidServer.Status.Settings.Identity.PublicName).To(Equal(ptr.To("Operator Server")))
+			Eventually(recorder.Events).Should(Receive(Equal(`Normal SettingChanged Set MaxPlayers to "12"`)))
+		})
+
+		It("Should record applied settings in the settings history", func() {
+			zomboidServer.Spec.Settings.Loot.HoursForLootRespawn = ptr.To(int32(24))
+			Expect(k8sClient.Update(ctx, zomboidServer)).Should(Succeed())
+
+			Expect(reconcileAndReload(ctx, reconciler, zomboidServerName, zomboidServer)).Should(Succeed())
+
+			configMap := &corev1.ConfigMap{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{
+				Name:      settings.HistoryConfigMapName(zomboidServer.Name),
+				Namespace: zomboidServer.Namespace,
+			}, configMap)).Should(Succeed())
+			Expect(configMap.OwnerReferences).To(HaveLen(1))
+
+			history, err := settings.ReadHistory(configMap)
+			Expect(err).NotTo(HaveOccurred())
+			latest := history[len(history)-1]
+			Expect(latest.Generation).To(Equal(zomboidServer.Generation))
+			Expect(latest.Changes).To(ContainElement(settings.HistoryChange{
+				Name: "HoursForLootRespawn", Before: "0", After: "24", Cause: settings.CauseSpec,
+			}))
+
+			server.SetOption("HoursForLootRespawn", "0")
+			Expect(reconcileAndReload(ctx, reconciler, zomboidServerName, zomboidServer)).Should(Succeed())
+
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: configMap.Name, Namespace: configMap.Namespace}, configMap)).Should(Succeed())
+			history, err = settings.ReadHistory(configMap)
+			Expect(err).NotTo(HaveOccurred())
+			latest = history[len(history)-1]
+			Expect(latest.Manager).To(BeEmpty())
+			Expect(latest.Changes).To(ConsistOf(settings.HistoryChange{
+				Name: "HoursForLootRespawn", Before: "0", After: "24", Cause: settings.CauseDriftRevert,
+			}))
+		})
+
+		It("Should apply and observe extra settings", func() {
+			server.SetOption("UltraHardcoreMode", "false")
+			zomboidServer.Spec.Settings.Extra = map[string]string{"UltraHardcoreMode": "true"}
+			Expect(k8sClient.Update(ctx, zomboidServer)).Should(Succeed())
+
+			Expect(reconcileAndReload(ctx, reconciler, zomboidServerName, zomboidServer)).Should(Succeed())
+
+			ultraHardcoreMode, _ := server.Option("UltraHardcoreMode")
+			Expect(ultraHardcoreMode).To(Equal("true"))
+			Expect(zomboidServer.Status.Settings.Extra).To(HaveKeyWithValue("UltraHardcoreMode", "true"))
+			Expect(zomboidServer.Status.SettingsDrift).To(BeEmpty())
+		})
+
+		It("Should only report drift under the ObserveOnly policy", func() {
+			zomboidServer.Spec.SettingsPolicy = zomboidv1.SettingsPolicyObserveOnly
+			zomboidServer.Spec.Settings.Identity.PublicName = ptr.To("Operator Server")
+			Expect(k8sClient.Update(ctx, zomboidServer)).Should(Succeed())
+
+			Expect(reconcileAndReload(ctx, reconciler, zomboidServerName, zomboidServer)).Should(Succeed())
+
+			publicName, _ := server.Option("PublicName")
+			Expect(publicName).NotTo(Equal("Operator Server"))
+			Expect(zomboidServer.Status.SettingsDrift).To(ContainElement(zomboidv1.SettingDrift{
+				Name:     "PublicName",
+				Desired:  "Operator Server",
+				Observed: publicName,
+			}))
+			settingsCondition := meta.FindStatusCondition(zomboidServer.Status.Conditions, zomboidv1.TypeSettingsSynced)
+			Expect(settingsCondition.Status).To(Equal(metav1.ConditionFalse))
+			Expect(settingsCondition.Reason).To(Equal(zomboidv1.ReasonSettingsDrifted))
+		})
+
+		It("Should keep in-game changes to unset settings under the Adopt policy", func() {
+			session, err := reconciler.rconSession(ctx, zomboidServer)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(session.Do(ctx, func(client rcon.Client) error {
+				_, err := client.Execute(`changeoption MaxPlayers "20"`)
+				return err
+			})).To(Succeed())
+
+			zomboidServer.Spec.SettingsPolicy = zomboidv1.SettingsPolicyAdopt
+			zomboidServer.Spec.Settings.Identity.PublicName = ptr.To("Operator Server")
+			Expect(k8sClient.Update(ctx, zomboidServer)).Should(Succeed())
+
+			Expect(reconcileAndReload(ctx, reconciler, zomboidServerName, zomboidServer)).Should(Succeed())
+
+			publicName, _ := server.Option("PublicName")
+			Expect(publicName).To(Equal("Operator Server"))
+			maxPlayers, _ := server.Option("MaxPlayers")
+			Expect(maxPlayers).To(Equal("20"))
+			Expect(zomboidServer.Status.SettingsDrift).To(ConsistOf(HaveField("Name", "MaxPlayers")))
+		})
+
+		It("Should restart the server when mods change", func() {
+			zomboidServer.Spec.Settings.Mods.Mods = ptr.To("mod1")
+			zomboidServer.Spec.Settings.Mods.WorkshopItems = ptr.To("123456")
+			Expect(k8sClient.Update(ctx, zomboidServer)).Should(Succeed())
+
+			Expect(reconcileAndReload(ctx, reconciler, zomboidServerName, zomboidServer)).Should(Succeed())
+			Expect(server.Restarts()).To(Equal(1))
+
+			modsCondition := meta.FindStatusCondition(zomboidServer.Status.Conditions, zomboidv1.TypeModsReady)
+			Expect(modsCondition.Status).To(Equal(metav1.ConditionFalse))
+			Expect(modsCondition.Reason).To(Equal(zomboidv1.ReasonModsRestarting))
+			Expect(zomboidServer.Status.PendingRestart).To(BeEmpty())
+
+			// The next reconcile reconnects after the restart
+			Expect(reconcileAndReload(ctx, reconciler, zomboidServerName, zomboidServer)).Should(Succeed())
+			rconCondition := meta.FindStatusCondition(zomboidServer.Status.Conditions, zomboidv1.TypeRCONReachable)
+			Expect(rconCondition.Status).To(Equal(metav1.ConditionTrue))
+		})
+
+		It("Should wait for an empty server to apply settings it reads on start under the WhenEmpty policy", func() {
+			server.ConnectPlayer("alice")
+			zomboidServer.Spec.RestartPolicy = zomboidv1.RestartPolicyWhenEmpty
+			zomboidServer.Spec.Settings.Map.Map = ptr.To("Riverside, KY")
+			zomboidServer.Spec.Settings.Player.PingLimit = ptr.To(int32(300))
+			Expect(k8sClient.Update(ctx, zomboidServer)).Should(Succeed())
+
+			Expect(reconcileAndReload(ctx, reconciler, zomboidServerName, zomboidServer)).Should(Succeed())
+			Expect(server.Restarts()).To(Equal(0))
+			mapName, _ := server.Option("Map")
+			Expect(mapName).To(Equal("Riverside, KY"))
+			Expect(zomboidServer.Status.PendingRestart).To(ConsistOf(And(
+				HaveField("Name", "Map"),
+				HaveField("Value", "Riverside, KY"),
+			)))
+			settingsCondition := meta.FindStatusCondition(zomboidServer.Status.Conditions, zomboidv1.TypeSettingsSynced)
+			Expect(settingsCondition.Reason).To(Equal(zomboidv1.ReasonSettingsPendingRestart))
+			Expect(settingsCondition.Message).To(ContainSubstring("1 connected players"))
+		})
+
+		It("Should leave restarts to an admin under the Manual policy", func() {
+			zomboidServer.Spec.RestartPolicy = zomboidv1.RestartPolicyManual
+			zomboidServer.Spec.Settings.Mods.Mods = ptr.To("mod1")
+			zomboidServer.Spec.Settings.Mods.WorkshopItems = ptr.To("123456")
+			Expect(k8sClient.Update(ctx, zomboidServer)).Should(Succeed())
+
+			Expect(reconcileAndReload(ctx, reconciler, zomboidServerName, zomboidServer)).Should(Succeed())
+			Expect(reconcileAndReload(ctx, reconciler, zomboidServerName, zomboidServer)).Should(Succeed())
+			Expect(server.Restarts()).To(Equal(0))
+			Expect(zomboidServer.Status.PendingRestart).To(ConsistOf(HaveField("Name", "WorkshopItems"), HaveField("Name", "Mods")))
+
+			modsCondition := meta.FindStatusCondition(zomboidServer.Status.Conditions, zomboidv1.TypeModsReady)
+			Expect(modsCondition.Status).To(Equal(metav1.ConditionFalse))
+			Expect(modsCondition.Reason).To(Equal(zomboidv1.ReasonModsPendingRestart))
+		})
+
+		Context("With workshop updates enabled", func() {
+			var workshopServer *workshopfake.Server
+
+			BeforeEach(func() {
+				workshopServer = workshopfake.NewServer()
+				DeferCleanup(workshopServer.Close)
+				workshopServer.SetItem(workshop.Item{WorkshopID: "498441420", Title: "Hydrocraft", Updated: time.Unix(1731877836, 0)})
+
+				reconciler.Workshop = workshop.NewClient(workshopServer.URL())
+				reconciler.Exec = func(ctx context.Context, zomboidServer *zomboidv1.ZomboidServer, command []string) ([]byte, error) {
+					return []byte(`"AppWorkshop" { "WorkshopItemsInstalled" { "498441420" { "timeupdated" "1700000000" } } }`), nil
+				}
+
+				server.SetOption("Mods", "Hydrocraft")
+				server.SetOption("WorkshopItems", "498441420")
+				zomboidServer.Spec.WorkshopUpdates = &zomboidv1.WorkshopUpdates{Enabled: true}
+				zomboidServer.Spec.Settings.WorkshopMods = []zomboidv1.WorkshopMod{
+					{ModID: ptr.To("Hydrocraft"), WorkshopID: ptr.To("498441420")},
+				}
+			})
+
+			It("Should restart the server to download updated workshop items", func() {
+				Expect(k8sClient.Update(ctx, zomboidServer)).Should(Succeed())
+
+				Expect(reconcileAndReload(ctx, reconciler, zomboidServerName, zomboidServer)).Should(Succeed())
+				Expect(server.Restarts()).To(Equal(1))
+				Expect(zomboidServer.Status.WorkshopItems).To(HaveLen(1))
+				item := zomboidServer.Status.WorkshopItems[0]
+				Expect(item.WorkshopID).To(Equal("498441420"))
+				Expect(item.Installed.Time).To(BeTemporally("==", time.Unix(1700000000, 0)))
+				Expect(item.Available.Time).To(BeTemporally("==", time.Unix(1731877836, 0)))
+				condition := meta.FindStatusCondition(zomboidServer.Status.Conditions, zomboidv1.TypeWorkshopUpToDate)
+				Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+				Expect(condition.Reason).To(Equal(zomboidv1.ReasonWorkshopOutdated))
+				Eventually(recorder.Events).Should(Receive(ContainSubstring("WorkshopItemUpdated Workshop item 498441420 was updated")))
+
+				// One request resolves the workshop mods and one checks for
+				// updates, and neither is repeated until the server restarts or
+				// the interval passes
+				Expect(reconcileAndReload(ctx, reconciler, zomboidServerName, zomboidServer)).Should(Succeed())
+				Expect(workshopServer.Requests()).To(Equal(2))
+				Expect(server.Restarts()).To(Equal(1))
+			})
+
+			It("Should warn connected players before restarting", func() {
+				server.ConnectPlayer("alice")
+				zomboidServer.Spec.RestartWarning = &metav1.Duration{Duration: 5 * time.Minute}
+				Expect(k8sClient.Update(ctx, zomboidServer)).Should(Succeed())
+
+				Expect(reconcileAndReload(ctx, reconciler, zomboidServerName, zomboidServer)).Should(Succeed())
+				Expect(server.Restarts()).To(Equal(0))
+				Expect(server.Messages()).To(ContainElement("The server will restart in 5 minutes"))
+				Expect(zomboidServer.Status.RestartAt).NotTo(BeNil())
+				Expect(zomboidServer.Status.PendingRestart).To(ConsistOf(HaveField("Name", "WorkshopUpdates")))
+				modsCondition := meta.FindStatusCondition(zomboidServer.Status.Conditions, zomboidv1.TypeModsReady)
+				Expect(modsCondition.Status).To(Equal(metav1.ConditionFalse))
+				Expect(modsCondition.Reason).To(Equal(zomboidv1.ReasonModsPendingRestart))
+
+				zomboidServer.Status.RestartAt = &metav1.Time{Time: time.Now().Add(-time.Second)}
+				Expect(k8sClient.Status().Update(ctx, zomboidServer)).Should(Succeed())
+
+				Expect(reconcileAndReload(ctx, reconciler, zomboidServerName, zomboidServer)).Should(Succeed())
+				Expect(server.Restarts()).To(Equal(1))
+				Expect(zomboidServer.Status.RestartAt).To(BeNil())
+			})
+		})
+
+		Context("With workshop mods to resolve", func() {
+			var workshopServer *workshopfake.Server
+
+			BeforeEach(func() {
+				workshopServer = workshopfake.NewServer()
+				DeferCleanup(workshopServer.Close)
+				workshopServer.SetKey("secret")
+				workshopServer.SetItem(workshop.Item{WorkshopID: "2169435993", Title: "Mod Manager", Size: 1258291,
+					RequiredItems: []string{"2200148440"}})
+				workshopServer.SetItem(workshop.Item{WorkshopID: "2200148440", Title: "Mod Options", Size: 40960})
+
+				reconciler.Workshop = workshop.NewClient(workshopServer.URL())
+				reconciler.Workshop.DetailsEndpoint = workshopServer.URL()
+				reconciler.Workshop.Key = "secret"
+				reconciler.Exec = func(ctx context.Context, zomboidServer *zomboidv1.ZomboidServer, command []string) ([]byte, error) {
+					if !slices.Equal(command, workshop.ListModInfos) {
+						return nil, nil
+					}
+					return []byte("==> " + workshop.ContentDir + "/2169435993/mods/ModManager/mod.info\n" +
+						"id=ModManager\n" +
+						"==> " + workshop.ContentDir + "/2169435993/mods/ModManagerServer/mod.info\n" +
+						"id=ModManagerServer\n" +
+						"require=ModManager,modoptions\n"), nil
+				}
+
+				zomboidServer.Spec.RestartPolicy = zomboidv1.RestartPolicyManual
+				zomboidServer.Spec.Settings.WorkshopMods = []zomboidv1.WorkshopMod{{WorkshopID: ptr.To("2169435993")}}
+			})
+
+			It("Should load the mods of the workshop items and report what they require", func() {
+				Expect(k8sClient.Update(ctx, zomboidServer)).Should(Succeed())
+
+				Expect(reconcileAndReload(ctx, reconciler, zomboidServerName, zomboidServer)).Should(Succeed())
+
+				Expect(zomboidServer.Status.WorkshopMods).To(HaveLen(2))
+				Expect(zomboidServer.Status.WorkshopMods[0].Title).To(Equal("Mod Manager"))
+				Expect(zomboidServer.Status.WorkshopMods[0].Size).To(Equal(int64(1258291)))
+				Expect(zomboidServer.Status.WorkshopMods[0].Mods).To(ConsistOf(HaveField("ID", "ModManager"), HaveField("ID", "ModManagerServer")))
+				Expect(zomboidServer.Status.WorkshopMods[1].WorkshopID).To(Equal("2200148440"))
+				Expect(zomboidServer.Status.WorkshopMods[1].RequiredBy).To(Equal([]string{"2169435993"}))
+
+				mods, _ := server.Option("Mods")
+				Expect(mods).To(Equal("ModManager;ModManagerServer"))
+				workshopItems, _ := server.Option("WorkshopItems")
+				Expect(workshopItems).To(Equal("2169435993"))
+
+				condition := meta.FindStatusCondition(zomboidServer.Status.Conditions, zomboidv1.TypeWorkshopDependenciesMet)
+				Expect(condition).NotTo(BeNil())
+				Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+				Expect(condition.Reason).To(Equal(zomboidv1.ReasonDependenciesMissing))
+				Expect(condition.Message).To(ContainSubstring("mod modoptions, required by ModManagerServer"))
+				Expect(condition.Message).To(ContainSubstring("workshop item 2200148440 (Mod Options), required by 2169435993"))
+			})
+
+			It("Should add the workshop items the mods require under the Add policy", func() {
+				zomboidServer.Spec.WorkshopDependencies = zomboidv1.WorkshopDependenciesAdd
+				Expect(k8sClient.Update(ctx, zomboidServer)).Should(Succeed())
+
+				Expect(reconcileAndReload(ctx, reconciler, zomboidServerName, zomboidServer)).Should(Succeed())
+
+				workshopItems, _ := server.Option("WorkshopItems")
+				Expect(workshopItems).To(Equal("2169435993;2200148440"))
+				Expect(zomboidServer.Status.PendingRestart).To(ContainElement(HaveField("Name", "WorkshopItems")))
+				Eventually(recorder.Events).Should(Receive(ContainSubstring("WorkshopDependencyAdded Loading workshop item 2200148440 (Mod Options), required by 2169435993")))
+
+				// Mod Options isn't downloaded yet, so its mod is still missing
+				condition := meta.FindStatusCondition(zomboidServer.Status.Conditions, zomboidv1.TypeWorkshopDependenciesMet)
+				Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+				Expect(condition.Message).To(ContainSubstring("mod modoptions, required by ModManagerServer; no downloaded workshop item contains them"))
+
+				// Nothing is resolved again until the server restarts
+				Expect(reconcileAndReload(ctx, reconciler, zomboidServerName, zomboidServer)).Should(Succeed())
+				Expect(workshopServer.Requests()).To(Equal(2))
+			})
+
+			It("Should wait for the update interval before retrying a failed resolution", func() {
+				attempts := 0
+				reconciler.Exec = func(ctx context.Context, zomboidServer *zomboidv1.ZomboidServer, command []string) ([]byte, error) {
+					if slices.Equal(command, workshop.ListModInfos) {
+						attempts++
+					}
+					return nil, errors.New("pod not ready")
+				}
+				Expect(k8sClient.Update(ctx, zomboidServer)).Should(Succeed())
+
+				Expect(reconcileAndReload(ctx, reconciler, zomboidServerName, zomboidServer)).Should(Succeed())
+				condition := meta.FindStatusCondition(zomboidServer.Status.Conditions, zomboidv1.TypeWorkshopDependenciesMet)
+				Expect(condition.Status).To(Equal(metav1.ConditionUnknown))
+				Expect(condition.Reason).To(Equal(zomboidv1.ReasonWorkshopModsNotResolved))
+
+				Expect(reconcileAndReload(ctx, reconciler, zomboidServerName, zomboidServer)).Should(Succeed())
+				Expect(attempts).To(Equal(1))
+				Expect(workshopServer.Requests()).To(BeZero())
+
+				zomboidServer.Status.WorkshopModsResolved = &metav1.Time{Time: time.Now().Add(-time.Hour)}
+				Expect(k8sClient.Status().Update(ctx, zomboidServer)).Should(Succeed())
+
+				Expect(reconcileAndReload(ctx, reconciler, zomboidServerName, zomboidServer)).Should(Succeed())
+				Expect(attempts).To(Equal(2))
+			})
+		})
+
+		It("Should report maps whose folders aren't installed", func() {
+			reconciler.Exec = func(ctx context.Context, zomboidServer *zomboidv1.ZomboidServer, command []string) ([]byte, error) {
+				return []byte(workshop.ContentDir + "/2463499011/mods/Bedford Falls/media/maps/Bedford Falls\n"), nil
+			}
+			zomboidServer.Spec.RestartPolicy = zomboidv1.RestartPolicyManual
+			zomboidServer.Spec.Settings.Maps = []string{"Bedford Falls", "RavenCreek"}
+			Expect(k8sClient.Update(ctx, zomboidServer)).Should(Succeed())
+
+			Expect(reconcileAndReload(ctx, reconciler, zomboidServerName, zomboidServer)).Should(Succeed())
+
+			mapName, _ := server.Option("Map")
+			Expect(mapName).To(Equal("Bedford Falls;RavenCreek;Muldraugh, KY"))
+			condition := meta.FindStatusCondition(zomboidServer.Status.Conditions, zomboidv1.TypeMapsInstalled)
+			Expect(condition).NotTo(BeNil())
+			Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+			Expect(condition.Reason).To(Equal(zomboidv1.ReasonMapsMissing))
+			Expect(condition.Message).To(ContainSubstring("folders of RavenCreek;"))
+
+			zomboidServer.Spec.Settings.Maps = []string{"Bedford Falls"}
+			Expect(k8sClient.Update(ctx, zomboidServer)).Should(Succeed())
+
+			Expect(reconcileAndReload(ctx, reconciler, zomboidServerName, zomboidServer)).Should(Succeed())
+
+			condition = meta.FindStatusCondition(zomboidServer.Status.Conditions, zomboidv1.TypeMapsInstalled)
+			Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+			Expect(condition.Reason).To(Equal(zomboidv1.ReasonMapsFound))
+		})
+
+		It("Should add the administrator to the allowlist", func() {
+			admin, ok := server.User("admin")
+			Expect(ok).To(BeTrue())
+			Expect(admin.Password).To(Equal("test123"))
+
+			Expect(reconcileAndReload(ctx, reconciler, zomboidServerName, zomboidServer)).Should(Succeed())
+
+			admin, _ = server.User("admin")
+			Expect(admin.AccessLevel).To(Equal("admin"))
+			Expect(zomboidServer.Status.Allowlist).To(ContainElement(HaveField("Username", "admin")))
+		})
+
+		It("Should add, promote and ban declared users", func() {
+			zomboidServer.Spec.Users = []zomboidv1.User{{
+				Username: "alice",
+				Password: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{
+						Name: "alice-pass",
+					},
+					Key: "password",
+				},
+				AccessLevel: "Moderator",
+			}}
+			Expect(k8sClient.Update(ctx, zomboidServer)).Should(Succeed())
+
+			Expect(reconcileAndReload(ctx, reconciler, zomboidServerName, zomboidServer)).Should(Succeed())
+			Expect(reconcileAndReload(ctx, reconciler, zomboidServerName, zomboidServer)).Should(Succeed())
+
+			alice, ok := server.User("alice")
+			Expect(ok).To(BeTrue())
+			Expect(alice.Password).To(Equal("alice123"))
+			Expect(alice.AccessLevel).To(Equal("Moderator"))
+
+			zomboidServer.Spec.Users[0].Banned = true
+			Expect(k8sClient.Update(ctx, zomboidServer)).Should(Succeed())
+
+			Expect(reconcileAndReload(ctx, reconciler, zomboidServerName, zomboidServer)).Should(Succeed())
+
+			alice, _ = server.User("alice")
+			Expect(alice.Banned).To(BeTrue())
+			Eventually(recorder.Events).Should(Receive(Equal("Normal UserBanned Banned alice")))
+		})
+
+		It("Should report users whose password secret is missing", func() {
+			zomboidServer.Spec.Users = []zomboidv1.User{{
+				Username: "bob",
+				Password: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{
+						Name: "bob-pass",
+					},
+					Key: "password",
+				},
+			}}
+			Expect(k8sClient.Update(ctx, zomboidServer)).Should(Succeed())
+
+			Expect(reconcileAndReload(ctx, reconciler, zomboidServerName, zomboidServer)).To(HaveOccurred())
+
+			usersCondition := meta.FindStatusCondition(zomboidServer.Status.Conditions, zomboidv1.TypeUsersSynced)
+			Expect(usersCondition.Status).To(Equal(metav1.ConditionFalse))
+			Expect(usersCondition.Reason).To(Equal(zomboidv1.ReasonUserSecretMissing))
+			Expect(usersCondition.Message).To(ContainSubstring("bob"))
+			Expect(usersCondition.ObservedGeneration).To(Equal(zomboidServer.Generation))
+		})
+
+		It("Should remove unlisted users from closed servers", func() {
+			zomboidServer.Spec.Settings.Player.Open = ptr.To(false)
+			Expect(k8sClient.Update(ctx, zomboidServer)).Should(Succeed())
+			Expect(reconcileAndReload(ctx, reconciler, zomboidServerName, zomboidServer)).Should(Succeed())
+
+			session, err := reconciler.rconSession(ctx, zomboidServer)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(session.Do(ctx, func(client rcon.Client) error {
+				_, err := client.Execute(`adduser "mallory" "mallory123"`)
+				return err
+			})).To(Succeed())
+
+			Expect(reconcileAndReload(ctx, reconciler, zomboidServerName, zomboidServer)).Should(Succeed())
+
+			_, ok := server.User("mallory")
+			Expect(ok).To(BeFalse())
+			_, ok = server.User("admin")
+			Expect(ok).To(BeTrue())
+		})
+
+		It("Should observe connected players", func() {
+			server.ConnectPlayer("alice")
+
+			Expect(reconcileAndReload(ctx, reconciler, zomboidServerName, zomboidServer)).Should(Succeed())
+
+			Expect(zomboidServer.Status.ConnectedPlayers).To(Equal([]zomboidv1.ConnectedPlayer{{Username: "alice"}}))
+		})
+
+		It("Should report the RCON session as unreachable when the server is gone", func() {
+			server.Close()
+			reconciler.RCON.Close(zomboidServerName)
+
+			Expect(reconcileAndReload(ctx, reconciler, zomboidServerName, zomboidServer)).To(HaveOccurred())
+
+			rconCondition := meta.FindStatusCondition(zomboidServer.Status.Conditions, zomboidv1.TypeRCONReachable)
+			Expect(rconCondition).NotTo(BeNil())
+			Expect(rconCondition.Status).To(Equal(metav1.ConditionFalse))
+			Expect(rconCondition.Reason).To(Equal(zomboidv1.ReasonRCONUnreachable))
+		})
+	})
+})
@@ -13,6 +13,8 @@ import (
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 
 	zomboidv1 "github.com/zomboidhost/zomboid-operator/api/v1"
@@ -33,8 +35,9 @@ var _ = Describe("ZomboidServer Status Tests", func() {
 		BeforeEach(func() {
 			ctx = context.Background()
 			reconciler = &ZomboidServerReconciler{
-				Client: k8sClient,
-				Scheme: k8sClient.Scheme(),
+				Client:   k8sClient,
+				Scheme:   k8sClient.Scheme(),
+				Recorder: &record.FakeRecorder{},
 			}
 		})
 
@@ -134,6 +137,39 @@ var _ = Describe("ZomboidServer Status Tests", func() {
 			Expect(infraCondition.Message).To(ContainSubstring("Failed to reconcile Deployment"))
 		})
 
+		It("Should report backups as disabled without a backups volume", func() {
+			backupsCondition := meta.FindStatusCondition(zomboidServer.Status.Conditions, zomboidv1.TypeBackupsConfigured)
+			Expect(backupsCondition).NotTo(BeNil())
+			Expect(backupsCondition.Status).To(Equal(metav1.ConditionFalse))
+			Expect(backupsCondition.Reason).To(Equal(zomboidv1.ReasonBackupsDisabled))
+		})
+
+		It("Should report the backup plans that copy a server's backups", func() {
+			zomboidServer.Spec.Backups.Request = ptr.To(resource.MustParse("10Gi"))
+			Expect(k8sClient.Update(ctx, zomboidServer)).Should(Succeed())
+
+			Expect(reconcileAndReload(ctx, reconciler, zomboidServerName, zomboidServer)).Should(Succeed())
+
+			backupsCondition := meta.FindStatusCondition(zomboidServer.Status.Conditions, zomboidv1.TypeBackupsConfigured)
+			Expect(backupsCondition.Status).To(Equal(metav1.ConditionTrue))
+			Expect(backupsCondition.Reason).To(Equal(zomboidv1.ReasonBackupVolumeOnly))
+
+			Expect(k8sClient.Create(ctx, &zomboidv1.ZomboidBackupPlan{
+				ObjectMeta: metav1.ObjectMeta{Name: "nightly", Namespace: zomboidServerName.Namespace},
+				Spec: zomboidv1.ZomboidBackupPlanSpec{
+					Server:      corev1.LocalObjectReference{Name: zomboidServerName.Name},
+					Destination: corev1.LocalObjectReference{Name: "dropbox"},
+					Schedule:    "@daily",
+				},
+			})).Should(Succeed())
+
+			Expect(reconcileAndReload(ctx, reconciler, zomboidServerName, zomboidServer)).Should(Succeed())
+
+			backupsCondition = meta.FindStatusCondition(zomboidServer.Status.Conditions, zomboidv1.TypeBackupsConfigured)
+			Expect(backupsCondition.Reason).To(Equal(zomboidv1.ReasonBackupPlansConfigured))
+			Expect(backupsCondition.Message).To(ContainSubstring("nightly"))
+		})
+
 		It("Should update ready for players condition when deployment is not ready", func() {
 			deployment := &appsv1.Deployment{}
 			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: zomboidServer.Name, Namespace: zomboidServerName.Namespace}, deployment)).Should(Succeed())
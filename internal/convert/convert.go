@@ -0,0 +1,133 @@
+package convert
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/yaml"
+
+	"github.com/zomboidhost/zomboid-operator/internal/settings"
+)
+
+// Run converts an existing server's server.ini into a ZomboidServer.  The
+// ZomboidServer and any Secrets are written to out as YAML, ready for kubectl
+// apply, and everything that needs attention is reported to report.  Sandbox
+// settings are out of scope, as the operator doesn't manage SandboxVars.lua.
+func Run(args []string, out, report io.Writer) error {
+	flags := flag.NewFlagSet("convert", flag.ContinueOnError)
+	flags.SetOutput(report)
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "Usage: %s convert [flags] SERVER.INI\n\n", filepath.Base(os.Args[0]))
+		flags.PrintDefaults()
+	}
+
+	var options settings.ConvertOptions
+	var cpu, memory, storage string
+	flags.StringVar(&options.Name, "name", "", "The name of the ZomboidServer. Defaults to the name of the server.ini file.")
+	flags.StringVar(&options.Namespace, "namespace", "", "The namespace of the ZomboidServer.")
+	flags.StringVar(&options.Namespace, "n", "", "Shorthand for --namespace.")
+	flags.StringVar(&options.Version, "version", "latest", "The version of the game to run.")
+	flags.StringVar(&cpu, "cpu", "1", "The CPU the server requests.")
+	flags.StringVar(&memory, "memory", "4Gi", "The memory the server requests and is limited to, which sizes the game's heap.")
+	flags.StringVar(&storage, "storage", "10Gi", "The size of the server's game data volume.")
+	flags.BoolVar(&options.Secrets, "secrets", false, "Generate a Secret with the passwords and tokens found in server.ini.")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() != 1 {
+		flags.Usage()
+		return fmt.Errorf("expected the path to a server.ini")
+	}
+
+	iniPath := flags.Arg(0)
+	if options.Name == "" {
+		options.Name = filepath.Base(iniPath[:len(iniPath)-len(filepath.Ext(iniPath))])
+	}
+	quantities := map[string]resource.Quantity{}
+	for name, value := range map[string]string{"cpu": cpu, "memory": memory, "storage": storage} {
+		quantity, err := resource.ParseQuantity(value)
+		if err != nil {
+			return fmt.Errorf("invalid --%s: %w", name, err)
+		}
+		quantities[name] = quantity
+	}
+	options.Resources = corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{
+			corev1.ResourceCPU:    quantities["cpu"],
+			corev1.ResourceMemory: quantities["memory"],
+		},
+		Limits: corev1.ResourceList{
+			corev1.ResourceMemory: quantities["memory"],
+		},
+	}
+	options.Storage = quantities["storage"]
+
+	serverINI, err := os.Open(iniPath)
+	if err != nil {
+		return err
+	}
+	defer serverINI.Close()
+
+	conversion, err := settings.Convert(serverINI, options)
+	if err != nil {
+		return fmt.Errorf("failed to convert %s: %w", iniPath, err)
+	}
+
+	for _, secret := range conversion.Secrets {
+		if err := writeObject(out, secret); err != nil {
+			return err
+		}
+	}
+	if err := writeObject(out, conversion.Server); err != nil {
+		return err
+	}
+
+	for _, note := range conversion.Notes {
+		fmt.Fprintln(report, note)
+	}
+	if len(conversion.Unknown) > 0 {
+		fmt.Fprintf(report, "Kept %d settings the operator doesn't model in spec.settings.extra:\n", len(conversion.Unknown))
+		for _, name := range conversion.Unknown {
+			fmt.Fprintf(report, "  %s\n", name)
+		}
+	}
+	return nil
+}
+
+// writeObject writes obj to out as a YAML document, leaving out its status
+// and the empty fields Go's zero values would otherwise fill it with
+func writeObject(out io.Writer, obj runtime.Object) error {
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		return err
+	}
+	delete(content, "status")
+	prune(content)
+
+	data, err := yaml.Marshal(content)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(out, "---\n%s", data)
+	return err
+}
+
+// prune removes the nil values and empty maps from content
+func prune(content map[string]interface{}) {
+	for key, value := range content {
+		if nested, ok := value.(map[string]interface{}); ok {
+			prune(nested)
+			if len(nested) == 0 {
+				delete(content, key)
+			}
+		} else if value == nil {
+			delete(content, key)
+		}
+	}
+}
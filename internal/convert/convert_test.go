@@ -0,0 +1,66 @@
+package convert_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"sigs.k8s.io/yaml"
+
+	zomboidv1 "github.com/zomboidhost/zomboid-operator/api/v1"
+	"github.com/zomboidhost/zomboid-operator/internal/convert"
+)
+
+var _ = Describe("Convert", func() {
+	var dir string
+
+	BeforeEach(func() {
+		dir = GinkgoT().TempDir()
+		Expect(os.WriteFile(filepath.Join(dir, "servertest.ini"), []byte(
+			"PublicName=Knox Survivors\n"+
+				"MaxPlayers=32\n"+
+				"Password=hunter2\n"+
+				"UltraHardcoreMode=true\n",
+		), 0o600)).To(Succeed())
+	})
+
+	It("should write a ZomboidServer ready to apply", func() {
+		var out, report bytes.Buffer
+		Expect(convert.Run([]string{
+			"--namespace", "games",
+			filepath.Join(dir, "servertest.ini"),
+		}, &out, &report)).To(Succeed())
+
+		Expect(out.String()).NotTo(ContainSubstring("status"))
+		Expect(out.String()).NotTo(ContainSubstring("kind: Secret"))
+		Expect(out.String()).NotTo(ContainSubstring("hunter2"))
+
+		zomboidServer := &zomboidv1.ZomboidServer{}
+		Expect(yaml.UnmarshalStrict(bytes.TrimPrefix(out.Bytes(), []byte("---\n")), zomboidServer)).To(Succeed())
+		Expect(zomboidServer.Name).To(Equal("servertest"))
+		Expect(zomboidServer.Namespace).To(Equal("games"))
+		Expect(*zomboidServer.Spec.Settings.Identity.PublicName).To(Equal("Knox Survivors"))
+		Expect(zomboidServer.Spec.Settings.Player.MaxPlayers).To(BeNil())
+		Expect(zomboidServer.Spec.Password.Name).To(Equal("servertest-passwords"))
+		Expect(zomboidServer.Spec.Resources.Limits.Memory().String()).To(Equal("4Gi"))
+
+		Expect(report.String()).To(ContainSubstring("Create the Secret servertest-admin"))
+		Expect(report.String()).To(ContainSubstring("spec.settings.extra:\n  UltraHardcoreMode\n"))
+	})
+
+	It("should generate Secrets when asked", func() {
+		var out, report bytes.Buffer
+		Expect(convert.Run([]string{"--secrets", "--name", "knox", filepath.Join(dir, "servertest.ini")}, &out, &report)).To(Succeed())
+		Expect(out.String()).To(HavePrefix("---\napiVersion: v1\n"))
+		Expect(out.String()).To(ContainSubstring("name: knox-passwords"))
+		Expect(out.String()).To(ContainSubstring("password: hunter2"))
+		Expect(out.String()).To(ContainSubstring("kind: ZomboidServer"))
+	})
+
+	It("should require a server.ini", func() {
+		var out, report bytes.Buffer
+		Expect(convert.Run(nil, &out, &report)).To(MatchError(ContainSubstring("server.ini")))
+	})
+})
@@ -0,0 +1,214 @@
+package kubectl
+
+import (
+	"fmt"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	zomboidv1 "github.com/zomboidhost/zomboid-operator/api/v1"
+	"github.com/zomboidhost/zomboid-operator/internal/controller"
+)
+
+func newBackupCommand(o *Options) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "backup",
+		Short: "Manage a server's backups",
+	}
+
+	var plan string
+	now := &cobra.Command{
+		Use:   "now SERVER",
+		Short: "Copy a server's backups to its backup destinations now, rather than waiting for the schedule",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+
+			zomboidServer, err := o.getServer(ctx, args[0])
+			if err != nil {
+				return err
+			}
+
+			backupPlans := &zomboidv1.ZomboidBackupPlanList{}
+			if err := o.Client.List(ctx, backupPlans, client.InNamespace(zomboidServer.Namespace)); err != nil {
+				return fmt.Errorf("failed to list ZomboidBackupPlans: %w", err)
+			}
+
+			started := 0
+			for _, backupPlan := range backupPlans.Items {
+				if backupPlan.Spec.Server.Name != zomboidServer.Name || (plan != "" && backupPlan.Name != plan) {
+					continue
+				}
+
+				job, err := controller.RunBackupPlan(ctx, o.Client, &backupPlan)
+				if err != nil {
+					return err
+				}
+				fmt.Fprintf(o.Out, "Started job %s for ZomboidBackupPlan %s\n", job.Name, backupPlan.Name)
+				started++
+			}
+
+			if started == 0 {
+				return fmt.Errorf("no ZomboidBackupPlans found for ZomboidServer %s", zomboidServer.Name)
+			}
+			return nil
+		},
+	}
+	now.Flags().StringVar(&plan, "plan", "", "Only run the named ZomboidBackupPlan")
+
+	cmd.AddCommand(now)
+	return cmd
+}
+
+func newRestoreCommand(o *Options) *cobra.Command {
+	var timeout time.Duration
+
+	cmd := &cobra.Command{
+		Use:   "restore SERVER BACKUP",
+		Short: "Restore a server's world from one of its backups",
+		Long: "Restore a server's world from a backup the server made, given as its path in the " +
+			"backups volume, like startup/backup_1.zip.  The server is suspended while the " +
+			"backup is restored, and resumed afterwards unless it was already suspended.",
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+
+			backup := path.Clean(args[1])
+			if path.IsAbs(backup) || backup == ".." || strings.HasPrefix(backup, "../") {
+				return fmt.Errorf("backup must be a path within the backups volume")
+			}
+
+			zomboidServer, err := o.getServer(ctx, args[0])
+			if err != nil {
+				return err
+			}
+			if zomboidServer.Spec.Backups.Request == nil {
+				return fmt.Errorf("ZomboidServer %s doesn't keep backups in a volume", zomboidServer.Name)
+			}
+
+			wasSuspended := zomboidServer.Spec.Suspended != nil && *zomboidServer.Spec.Suspended
+			if !wasSuspended {
+				if err := o.setSuspended(ctx, zomboidServer, true); err != nil {
+					return err
+				}
+				fmt.Fprintf(o.Out, "Suspended %s\n", zomboidServer.Name)
+			}
+
+			deadline := time.Now().Add(timeout)
+			for {
+				pods, err := o.serverPods(ctx, zomboidServer)
+				if err != nil {
+					return err
+				}
+				if len(pods) == 0 {
+					break
+				}
+				if time.Now().After(deadline) {
+					return fmt.Errorf("timed out waiting for %s to stop", zomboidServer.Name)
+				}
+				o.Sleep(2 * time.Second)
+			}
+
+			job := restoreJob(zomboidServer, backup)
+			if err := o.Client.Create(ctx, job); err != nil {
+				return fmt.Errorf("failed to create restore job: %w", err)
+			}
+			fmt.Fprintf(o.Out, "Restoring %s with job %s\n", backup, job.Name)
+
+			for {
+				if err := o.Client.Get(ctx, client.ObjectKeyFromObject(job), job); err != nil {
+					return fmt.Errorf("failed to get restore job: %w", err)
+				}
+				if job.Status.Succeeded > 0 {
+					break
+				}
+				if job.Status.Failed > 0 {
+					return fmt.Errorf("restore job %s failed; %s is left suspended", job.Name, zomboidServer.Name)
+				}
+				if time.Now().After(deadline) {
+					return fmt.Errorf("timed out waiting for restore job %s; %s is left suspended", job.Name, zomboidServer.Name)
+				}
+				o.Sleep(2 * time.Second)
+			}
+
+			if !wasSuspended {
+				if err := o.setSuspended(ctx, zomboidServer, false); err != nil {
+					return err
+				}
+				fmt.Fprintf(o.Out, "Resumed %s\n", zomboidServer.Name)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().DurationVar(&timeout, "timeout", 15*time.Minute, "How long to wait for the server to stop and the backup to be restored")
+	return cmd
+}
+
+// restoreJob returns a job that unpacks a backup over a server's game data.
+// The server's backups contain its save, database and configuration, laid
+// out relative to the game data directory.
+func restoreJob(zomboidServer *zomboidv1.ZomboidServer, backup string) *batchv1.Job {
+	script := `set -e
+rm -rf "/game-data/Saves/Multiplayer/$SERVER_NAME"
+unzip -o "/backups/$BACKUP" -d /game-data
+chown -R 1000:1000 /game-data`
+
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf("%s-restore-%d", zomboidServer.Name, time.Now().Unix()),
+			Namespace: zomboidServer.Namespace,
+			Labels: map[string]string{
+				"app.kubernetes.io/instance":   zomboidServer.Name,
+				"app.kubernetes.io/managed-by": "kubectl-zomboid",
+			},
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit: ptr.To(int32(0)),
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					RestartPolicy: corev1.RestartPolicyNever,
+					Containers: []corev1.Container{{
+						Name:    "restore",
+						Image:   "busybox:1.37",
+						Command: []string{"/bin/sh", "-c", script},
+						Env: []corev1.EnvVar{
+							{Name: "SERVER_NAME", Value: zomboidServer.Name},
+							{Name: "BACKUP", Value: backup},
+						},
+						VolumeMounts: []corev1.VolumeMount{
+							{Name: "game-data", MountPath: "/game-data"},
+							{Name: "backups", MountPath: "/backups", ReadOnly: true},
+						},
+					}},
+					Volumes: []corev1.Volume{
+						{
+							Name: "game-data",
+							VolumeSource: corev1.VolumeSource{
+								PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+									ClaimName: zomboidServer.Name + "-game-data",
+								},
+							},
+						},
+						{
+							Name: "backups",
+							VolumeSource: corev1.VolumeSource{
+								PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+									ClaimName: zomboidServer.Name + "-backups",
+									ReadOnly:  true,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,201 @@
+// Package kubectl implements kubectl-zomboid, a kubectl plugin for the
+// day-to-day administration of ZomboidServers.
+package kubectl
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	zomboidv1 "github.com/zomboidhost/zomboid-operator/api/v1"
+	"github.com/zomboidhost/zomboid-operator/internal/controller"
+	"github.com/zomboidhost/zomboid-operator/internal/rcon"
+)
+
+// Options holds the clients and settings shared by every command.  Anything
+// left unset is filled in from the kubeconfig before a command runs.
+type Options struct {
+	Kubeconfig string
+	Context    string
+	Namespace  string
+
+	Config    *rest.Config
+	Client    client.Client
+	Clientset kubernetes.Interface
+
+	// ServiceEndpoint returns the address of a Service's port.  It defaults to
+	// the in-cluster address, port-forwarding when run outside the cluster.
+	ServiceEndpoint func(ctx context.Context, name, namespace string, port int) (string, func(), error)
+
+	// Sleep waits between steps of long-running commands
+	Sleep func(time.Duration)
+
+	Out    io.Writer
+	ErrOut io.Writer
+}
+
+// NewCommand returns the kubectl-zomboid root command
+func NewCommand(o *Options) *cobra.Command {
+	if o.Out == nil {
+		o.Out = os.Stdout
+	}
+	if o.ErrOut == nil {
+		o.ErrOut = os.Stderr
+	}
+	if o.Sleep == nil {
+		o.Sleep = time.Sleep
+	}
+
+	cmd := &cobra.Command{
+		Use:           "kubectl-zomboid",
+		Annotations:   map[string]string{cobra.CommandDisplayNameAnnotation: "kubectl zomboid"},
+		Short:         "Administer Project Zomboid servers run by the zomboid-operator",
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			return o.complete()
+		},
+	}
+
+	cmd.PersistentFlags().StringVar(&o.Kubeconfig, "kubeconfig", o.Kubeconfig, "Path to the kubeconfig file to use")
+	cmd.PersistentFlags().StringVar(&o.Context, "context", o.Context, "The kubeconfig context to use")
+	cmd.PersistentFlags().StringVarP(&o.Namespace, "namespace", "n", o.Namespace, "The namespace of the ZomboidServer")
+
+	cmd.AddCommand(
+		newStatusCommand(o),
+		newPlayersCommand(o),
+		newKickCommand(o),
+		newBanCommand(o, true),
+		newBanCommand(o, false),
+		newMessageCommand(o),
+		newRestartCommand(o),
+		newSuspendCommand(o, true),
+		newSuspendCommand(o, false),
+		newBackupCommand(o),
+		newRestoreCommand(o),
+		newSettingsCommand(o),
+		newLogsCommand(o),
+	)
+
+	return cmd
+}
+
+// complete loads whatever clients haven't been provided from the kubeconfig
+func (o *Options) complete() error {
+	if o.Client != nil && o.Clientset != nil && o.ServiceEndpoint != nil && o.Namespace != "" {
+		return nil
+	}
+
+	loadingRules := clientcmd.NewDefaultClientConfigLoadingRules()
+	loadingRules.ExplicitPath = o.Kubeconfig
+	clientConfig := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(loadingRules, &clientcmd.ConfigOverrides{
+		CurrentContext: o.Context,
+		Context:        clientcmdapi.Context{Namespace: o.Namespace},
+	})
+
+	namespace, _, err := clientConfig.Namespace()
+	if err != nil {
+		return fmt.Errorf("failed to determine namespace: %w", err)
+	}
+	o.Namespace = namespace
+
+	if o.Config == nil {
+		if o.Config, err = clientConfig.ClientConfig(); err != nil {
+			return fmt.Errorf("failed to load kubeconfig: %w", err)
+		}
+	}
+
+	if o.Client == nil {
+		scheme := runtime.NewScheme()
+		if err := clientgoscheme.AddToScheme(scheme); err != nil {
+			return err
+		}
+		if err := zomboidv1.AddToScheme(scheme); err != nil {
+			return err
+		}
+		if o.Client, err = client.New(o.Config, client.Options{Scheme: scheme}); err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+	}
+
+	if o.Clientset == nil {
+		if o.Clientset, err = kubernetes.NewForConfig(o.Config); err != nil {
+			return fmt.Errorf("failed to create clientset: %w", err)
+		}
+	}
+
+	if o.ServiceEndpoint == nil {
+		o.ServiceEndpoint = func(ctx context.Context, name, namespace string, port int) (string, func(), error) {
+			hostname, port, cleanup, err := controller.GetServiceEndpoint(ctx, o.Config, o.Client, name, namespace, port)
+			if err != nil {
+				return "", cleanup, err
+			}
+			return fmt.Sprintf("%s:%d", hostname, port), cleanup, nil
+		}
+	}
+
+	return nil
+}
+
+func (o *Options) getServer(ctx context.Context, name string) (*zomboidv1.ZomboidServer, error) {
+	zomboidServer := &zomboidv1.ZomboidServer{}
+	if err := o.Client.Get(ctx, types.NamespacedName{Name: name, Namespace: o.Namespace}, zomboidServer); err != nil {
+		return nil, fmt.Errorf("failed to get ZomboidServer %s: %w", name, err)
+	}
+	return zomboidServer, nil
+}
+
+// rconSession opens a session to a server's console, connecting the same way
+// the operator does.  The caller must close it.
+func (o *Options) rconSession(ctx context.Context, zomboidServer *zomboidv1.ZomboidServer) (*rcon.Session, error) {
+	password, err := controller.GetRCONPassword(ctx, o.Client, zomboidServer)
+	if err != nil {
+		return nil, err
+	}
+
+	return rcon.NewSession(zomboidServer.Name, password, func(ctx context.Context) (string, func(), error) {
+		return o.ServiceEndpoint(ctx, zomboidServer.Name+"-rcon", zomboidServer.Namespace, 27015)
+	}), nil
+}
+
+// withRCON runs fn with a client connected to a server's console
+func (o *Options) withRCON(ctx context.Context, zomboidServer *zomboidv1.ZomboidServer, fn func(client rcon.Client) error) error {
+	session, err := o.rconSession(ctx, zomboidServer)
+	if err != nil {
+		return err
+	}
+	defer session.Close()
+
+	return session.Do(ctx, fn)
+}
+
+// serverPods lists the pods running a server
+func (o *Options) serverPods(ctx context.Context, zomboidServer *zomboidv1.ZomboidServer) ([]corev1.Pod, error) {
+	deployment := &appsv1.Deployment{}
+	if err := o.Client.Get(ctx, types.NamespacedName{Name: zomboidServer.Name, Namespace: zomboidServer.Namespace}, deployment); err != nil {
+		return nil, fmt.Errorf("failed to get deployment: %w", err)
+	}
+
+	pods := &corev1.PodList{}
+	if err := o.Client.List(ctx, pods,
+		client.InNamespace(zomboidServer.Namespace),
+		client.MatchingLabels(deployment.Spec.Selector.MatchLabels),
+	); err != nil {
+		return nil, fmt.Errorf("failed to list pods: %w", err)
+	}
+	return pods.Items, nil
+}
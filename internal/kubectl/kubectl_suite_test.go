@@ -0,0 +1,13 @@
+package kubectl_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestKubectl(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "kubectl-zomboid Suite")
+}
@@ -0,0 +1,409 @@
+package kubectl
+
+import (
+	"bytes"
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	clientfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	zomboidv1 "github.com/zomboidhost/zomboid-operator/api/v1"
+	"github.com/zomboidhost/zomboid-operator/internal/players"
+	"github.com/zomboidhost/zomboid-operator/internal/rcon"
+	"github.com/zomboidhost/zomboid-operator/internal/rcon/fake"
+	"github.com/zomboidhost/zomboid-operator/internal/settings"
+)
+
+var _ = Describe("kubectl-zomboid", func() {
+	const namespace = "games"
+
+	var (
+		ctx       context.Context
+		server    *fake.Server
+		k8sClient client.Client
+		options   *Options
+		out       *bytes.Buffer
+		sleeps    []time.Duration
+		onSleep   func()
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+
+		var err error
+		server, err = fake.NewServer("rcon-password")
+		Expect(err).NotTo(HaveOccurred())
+		DeferCleanup(server.Close)
+
+		scheme := runtime.NewScheme()
+		Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+		Expect(zomboidv1.AddToScheme(scheme)).To(Succeed())
+
+		labels := map[string]string{"app.kubernetes.io/instance": "test-server"}
+		k8sClient = clientfake.NewClientBuilder().WithScheme(scheme).WithObjects(
+			&zomboidv1.ZomboidServer{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-server", Namespace: namespace},
+				Spec: zomboidv1.ZomboidServerSpec{
+					Version: "latest",
+					Storage: zomboidv1.Storage{Request: resource.MustParse("10Gi")},
+					Backups: zomboidv1.Backups{Request: ptr.To(resource.MustParse("10Gi"))},
+					Administrator: zomboidv1.Administrator{
+						Username: "admin",
+						Password: corev1.SecretKeySelector{
+							LocalObjectReference: corev1.LocalObjectReference{Name: "passwords"},
+							Key:                  "admin",
+						},
+					},
+					RCON: &zomboidv1.RCON{
+						Password: &corev1.SecretKeySelector{
+							LocalObjectReference: corev1.LocalObjectReference{Name: "passwords"},
+							Key:                  "rcon",
+						},
+					},
+					Users: []zomboidv1.User{{Username: "alice"}},
+					Settings: zomboidv1.ZomboidSettings{
+						Player: zomboidv1.Player{MaxPlayers: ptr.To(int32(16))},
+					},
+				},
+				Status: zomboidv1.ZomboidServerStatus{
+					Ready:            true,
+					ConnectedPlayers: []zomboidv1.ConnectedPlayer{{Username: "alice"}},
+					Conditions: []metav1.Condition{{
+						Type:    zomboidv1.TypeUsersSynced,
+						Status:  metav1.ConditionFalse,
+						Reason:  zomboidv1.ReasonUserSecretMissing,
+						Message: "failed to get user secret for bob",
+					}},
+					Settings: &zomboidv1.ZomboidSettings{
+						Player: zomboidv1.Player{MaxPlayers: ptr.To(int32(32))},
+					},
+				},
+			},
+			&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "passwords", Namespace: namespace},
+				Data: map[string][]byte{
+					"admin": []byte("admin-password"),
+					"rcon":  []byte("rcon-password"),
+				},
+			},
+			&appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-server", Namespace: namespace},
+				Spec: appsv1.DeploymentSpec{
+					Selector: &metav1.LabelSelector{MatchLabels: labels},
+				},
+			},
+			&corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-server-abc123", Namespace: namespace, Labels: labels},
+			},
+		).Build()
+
+		out = &bytes.Buffer{}
+		sleeps = nil
+		onSleep = nil
+		options = &Options{
+			Namespace: namespace,
+			Client:    k8sClient,
+			Clientset: kubefake.NewSimpleClientset(&corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-server-abc123", Namespace: namespace},
+			}),
+			ServiceEndpoint: func(ctx context.Context, name, namespace string, port int) (string, func(), error) {
+				return server.RCONAddr(), func() {}, nil
+			},
+			Sleep: func(d time.Duration) {
+				sleeps = append(sleeps, d)
+				if onSleep != nil {
+					onSleep()
+				}
+			},
+			Out:    out,
+			ErrOut: out,
+		}
+	})
+
+	run := func(args ...string) error {
+		cmd := NewCommand(options)
+		cmd.SetArgs(args)
+		return cmd.ExecuteContext(ctx)
+	}
+
+	getServer := func() *zomboidv1.ZomboidServer {
+		zomboidServer := &zomboidv1.ZomboidServer{}
+		Expect(k8sClient.Get(ctx, types.NamespacedName{Name: "test-server", Namespace: namespace}, zomboidServer)).To(Succeed())
+		return zomboidServer
+	}
+
+	It("should summarize a server's status", func() {
+		Expect(run("status", "test-server")).To(Succeed())
+
+		Expect(out.String()).To(MatchRegexp(`Ready:\s+true`))
+		Expect(out.String()).To(MatchRegexp(`Players:\s+1/32 \(alice\)`))
+		Expect(out.String()).To(ContainSubstring("MaxPlayers"))
+		Expect(out.String()).To(MatchRegexp(`UsersSynced:\s+False\s+UserSecretMissing\s+failed to get user secret for bob`))
+		Expect(out.String()).NotTo(ContainSubstring("Restart:"))
+	})
+
+	It("should list settings waiting for a restart", func() {
+		zomboidServer := getServer()
+		zomboidServer.Status.PendingRestart = []zomboidv1.PendingRestartSetting{
+			{Name: "Map", Value: "Riverside, KY", ChangedAt: metav1.Now()},
+			{Name: "MaxPlayers", Value: "16", ChangedAt: metav1.Now()},
+		}
+		Expect(k8sClient.Update(ctx, zomboidServer)).To(Succeed())
+
+		Expect(run("status", "test-server")).To(Succeed())
+		Expect(out.String()).To(MatchRegexp(`Restart:\s+Map, MaxPlayers take effect once the server restarts`))
+
+		restartAt := metav1.NewTime(time.Date(2024, 11, 17, 21, 10, 0, 0, time.UTC))
+		zomboidServer.Status.RestartAt = &restartAt
+		Expect(k8sClient.Update(ctx, zomboidServer)).To(Succeed())
+
+		out.Reset()
+		Expect(run("status", "test-server")).To(Succeed())
+		Expect(out.String()).To(MatchRegexp(`Restart:\s+Map, MaxPlayers take effect when the server restarts at 2024-11-17T21:10:00Z`))
+	})
+
+	It("should describe the workshop mods", func() {
+		zomboidServer := getServer()
+		zomboidServer.Status.WorkshopMods = []zomboidv1.WorkshopModStatus{
+			{WorkshopID: "2169435993", Title: "Mod Manager", Size: 1258291, Mods: []zomboidv1.ResolvedMod{
+				{ID: "ModManager"}, {ID: "ModManagerServer"},
+			}},
+			{WorkshopID: "2200148440", Title: "Mod Options", Size: 40960, RequiredBy: []string{"2169435993"}},
+		}
+		Expect(k8sClient.Update(ctx, zomboidServer)).To(Succeed())
+
+		Expect(run("status", "test-server")).To(Succeed())
+		Expect(out.String()).To(MatchRegexp(`2169435993:\s+Mod Manager\s+1\.2 MiB\s+ModManager, ModManagerServer\n`))
+		Expect(out.String()).To(MatchRegexp(`2200148440:\s+Mod Options\s+40\.0 KiB\s+not downloaded \(required by 2169435993\)\n`))
+	})
+
+	It("should summarize drift the operator leaves alone", func() {
+		zomboidServer := getServer()
+		zomboidServer.Spec.SettingsPolicy = zomboidv1.SettingsPolicyObserveOnly
+		Expect(k8sClient.Update(ctx, zomboidServer)).To(Succeed())
+
+		Expect(run("status", "test-server")).To(Succeed())
+		Expect(out.String()).To(MatchRegexp(`Settings:\s+\d+ drifted under ObserveOnly policy \(.*MaxPlayers`))
+		Expect(out.String()).NotTo(ContainSubstring("pending"))
+	})
+
+	It("should list connected players", func() {
+		server.ConnectPlayer("alice")
+		server.ConnectPlayer("bob")
+
+		Expect(run("players", "test-server")).To(Succeed())
+		Expect(out.String()).To(Equal("alice\nbob\n"))
+	})
+
+	It("should kick players and broadcast messages", func() {
+		server.ConnectPlayer("griefer")
+
+		Expect(run("kick", "test-server", "griefer", "--reason", "Griefing")).To(Succeed())
+		Expect(run("msg", "test-server", "Be", "nice")).To(Succeed())
+
+		Expect(server.Commands()).To(ContainElements(`kickuser "griefer" -r "Griefing"`, `servermsg "Be nice"`))
+		Expect(server.Messages()).To(Equal([]string{"Be nice"}))
+	})
+
+	It("should ban declared users through the spec", func() {
+		Expect(run("ban", "test-server", "alice")).To(Succeed())
+
+		Expect(getServer().Spec.Users[0].Banned).To(BeTrue())
+		Expect(server.Commands()).To(BeEmpty())
+	})
+
+	It("should ban other users over RCON", func() {
+		Expect(options.complete()).To(Succeed())
+		zomboidServer := getServer()
+		Expect(options.withRCON(ctx, zomboidServer, func(client rcon.Client) error {
+			return players.AddUser(ctx, client, "mallory", "password")
+		})).To(Succeed())
+
+		Expect(run("ban", "test-server", "mallory")).To(Succeed())
+		mallory, _ := server.User("mallory")
+		Expect(mallory.Banned).To(BeTrue())
+
+		Expect(run("unban", "test-server", "mallory")).To(Succeed())
+		mallory, _ = server.User("mallory")
+		Expect(mallory.Banned).To(BeFalse())
+	})
+
+	It("should warn players before a graceful restart", func() {
+		server.ConnectPlayer("alice")
+
+		Expect(run("restart", "test-server", "--graceful", "--warning", "1m")).To(Succeed())
+
+		Expect(server.Messages()).To(Equal([]string{
+			"The server will restart in 1 minute",
+			"The server will restart in 30 seconds",
+			"The server will restart in 10 seconds",
+		}))
+		Expect(sleeps).To(Equal([]time.Duration{30 * time.Second, 20 * time.Second, 10 * time.Second}))
+		Expect(server.Saves()).To(Equal(1))
+		Expect(server.Restarts()).To(Equal(1))
+	})
+
+	It("should restart an empty server gracefully without waiting", func() {
+		Expect(run("restart", "test-server", "--graceful")).To(Succeed())
+
+		Expect(server.Messages()).To(BeEmpty())
+		Expect(sleeps).To(BeEmpty())
+		Expect(server.Restarts()).To(Equal(1))
+	})
+
+	It("should restart a server by deleting its pod", func() {
+		Expect(run("restart", "test-server")).To(Succeed())
+
+		pods := &corev1.PodList{}
+		Expect(k8sClient.List(ctx, pods, client.InNamespace(namespace))).To(Succeed())
+		Expect(pods.Items).To(BeEmpty())
+	})
+
+	It("should suspend and resume a server", func() {
+		Expect(run("suspend", "test-server")).To(Succeed())
+		Expect(getServer().Spec.Suspended).To(Equal(ptr.To(true)))
+
+		Expect(run("resume", "test-server")).To(Succeed())
+		Expect(getServer().Spec.Suspended).To(Equal(ptr.To(false)))
+	})
+
+	It("should start backups from a backup plan's CronJob", func() {
+		Expect(k8sClient.Create(ctx, &zomboidv1.ZomboidBackupPlan{
+			ObjectMeta: metav1.ObjectMeta{Name: "nightly", Namespace: namespace},
+			Spec: zomboidv1.ZomboidBackupPlanSpec{
+				Server:      corev1.LocalObjectReference{Name: "test-server"},
+				Destination: corev1.LocalObjectReference{Name: "dropbox"},
+				Schedule:    "0 4 * * *",
+			},
+		})).To(Succeed())
+		Expect(k8sClient.Create(ctx, &batchv1.CronJob{
+			ObjectMeta: metav1.ObjectMeta{Name: "nightly", Namespace: namespace},
+			Spec: batchv1.CronJobSpec{
+				Schedule: "0 4 * * *",
+				JobTemplate: batchv1.JobTemplateSpec{
+					Spec: batchv1.JobSpec{
+						Template: corev1.PodTemplateSpec{
+							Spec: corev1.PodSpec{
+								Containers: []corev1.Container{{Name: "backup", Image: "rclone/rclone:1.68.1"}},
+							},
+						},
+					},
+				},
+			},
+		})).To(Succeed())
+
+		Expect(run("backup", "now", "test-server")).To(Succeed())
+
+		jobs := &batchv1.JobList{}
+		Expect(k8sClient.List(ctx, jobs, client.InNamespace(namespace))).To(Succeed())
+		Expect(jobs.Items).To(HaveLen(1))
+		Expect(jobs.Items[0].Spec.Template.Spec.Containers[0].Image).To(Equal("rclone/rclone:1.68.1"))
+		Expect(jobs.Items[0].OwnerReferences[0].Name).To(Equal("nightly"))
+	})
+
+	It("should restore a backup while the server is suspended", func() {
+		// Stand in for the deployment controller and the restore job
+		onSleep = func() {
+			pods := &corev1.PodList{}
+			Expect(k8sClient.List(ctx, pods, client.InNamespace(namespace))).To(Succeed())
+			for i := range pods.Items {
+				Expect(k8sClient.Delete(ctx, &pods.Items[i])).To(Succeed())
+			}
+
+			jobs := &batchv1.JobList{}
+			Expect(k8sClient.List(ctx, jobs, client.InNamespace(namespace))).To(Succeed())
+			for i := range jobs.Items {
+				jobs.Items[i].Status.Succeeded = 1
+				Expect(k8sClient.Status().Update(ctx, &jobs.Items[i])).To(Succeed())
+			}
+		}
+
+		Expect(run("restore", "test-server", "startup/backup_1.zip")).To(Succeed())
+
+		jobs := &batchv1.JobList{}
+		Expect(k8sClient.List(ctx, jobs, client.InNamespace(namespace))).To(Succeed())
+		Expect(jobs.Items).To(HaveLen(1))
+		Expect(jobs.Items[0].Spec.Template.Spec.Containers[0].Env).To(ContainElement(
+			corev1.EnvVar{Name: "BACKUP", Value: "startup/backup_1.zip"},
+		))
+		Expect(getServer().Spec.Suspended).To(Equal(ptr.To(false)))
+	})
+
+	It("should refuse to restore backups from outside the backups volume", func() {
+		Expect(run("restore", "test-server", "../game-data/db/test-server.db")).NotTo(Succeed())
+	})
+
+	It("should get, set and diff settings", func() {
+		Expect(run("settings", "get", "test-server", "MaxPlayers")).To(Succeed())
+		Expect(out.String()).To(Equal("MaxPlayers=32\n"))
+
+		out.Reset()
+		Expect(run("settings", "diff", "test-server")).To(Succeed())
+		Expect(out.String()).To(MatchRegexp(`MaxPlayers\s+32\s+16\s+required`))
+
+		Expect(run("settings", "set", "test-server", "MaxPlayers=32", "PublicName=Zomboid")).To(Succeed())
+		zomboidServer := getServer()
+		Expect(zomboidServer.Spec.Settings.Player.MaxPlayers).To(Equal(ptr.To(int32(32))))
+		Expect(zomboidServer.Spec.Settings.Identity.PublicName).To(Equal(ptr.To("Zomboid")))
+
+		Expect(run("settings", "set", "test-server", "NotASetting=1")).NotTo(Succeed())
+	})
+
+	It("should show the settings history", func() {
+		Expect(run("settings", "history", "test-server")).To(Succeed())
+		Expect(out.String()).To(Equal("The operator hasn't changed the settings of test-server\n"))
+
+		configMap := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: settings.HistoryConfigMapName("test-server"), Namespace: namespace},
+		}
+		Expect(settings.AppendHistory(configMap, settings.HistoryEntry{
+			Time:       metav1.NewTime(time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)),
+			Generation: 4,
+			Manager:    "kubectl-zomboid",
+			Changes: []settings.HistoryChange{
+				{Name: "HoursForLootRespawn", Before: "0", After: "24", Cause: settings.CauseSpec},
+				{Name: "MaxPlayers", Before: "32", After: "16", Cause: settings.CauseDriftRevert},
+			},
+		})).To(Succeed())
+		Expect(k8sClient.Create(ctx, configMap)).To(Succeed())
+
+		out.Reset()
+		Expect(run("settings", "history", "test-server", "HoursForLootRespawn")).To(Succeed())
+		Expect(out.String()).To(MatchRegexp(`2024-05-01T12:00:00Z\s+4\s+Spec\s+kubectl-zomboid\s+HoursForLootRespawn\s+0\s+24`))
+		Expect(out.String()).NotTo(ContainSubstring("MaxPlayers"))
+
+		out.Reset()
+		Expect(run("settings", "history", "test-server", "MaxPlayers")).To(Succeed())
+		Expect(out.String()).To(MatchRegexp(`4\s+DriftRevert\s+MaxPlayers\s+32\s+16`))
+	})
+
+	It("should export the effective settings as a server.ini", func() {
+		Expect(run("settings", "export", "test-server")).To(Succeed())
+		Expect(out.String()).To(HavePrefix("DefaultPort=16261\nUDPPort=16262\nPublic=false\n"))
+		Expect(out.String()).To(ContainSubstring("\nMaxPlayers=16\n"))
+		Expect(out.String()).To(ContainSubstring("\nVoiceMinDistance=10.0\n"))
+	})
+
+	It("should read settings from the running server", func() {
+		Expect(run("settings", "get", "test-server", "PublicName", "--live")).To(Succeed())
+		Expect(out.String()).To(Equal("PublicName=My PZ Server\n"))
+	})
+
+	It("should print the server's logs", func() {
+		Expect(run("logs", "test-server", "--tail", "10")).To(Succeed())
+		Expect(out.String()).To(Equal("fake logs"))
+	})
+})
@@ -0,0 +1,153 @@
+package kubectl
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	zomboidv1 "github.com/zomboidhost/zomboid-operator/api/v1"
+	"github.com/zomboidhost/zomboid-operator/internal/players"
+	"github.com/zomboidhost/zomboid-operator/internal/rcon"
+	"github.com/zomboidhost/zomboid-operator/internal/settings"
+)
+
+// restartWarnings are when players are reminded of a graceful restart, as
+// the time left before it
+var restartWarnings = []time.Duration{10 * time.Minute, 5 * time.Minute, time.Minute, 30 * time.Second, 10 * time.Second}
+
+func newRestartCommand(o *Options) *cobra.Command {
+	var graceful bool
+	var warning time.Duration
+
+	cmd := &cobra.Command{
+		Use:   "restart SERVER",
+		Short: "Restart a server",
+		Long: "Restart a server by deleting its pod.  With --graceful, connected players are " +
+			"warned ahead of time and the world is saved before the server shuts down.",
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+
+			zomboidServer, err := o.getServer(ctx, args[0])
+			if err != nil {
+				return err
+			}
+
+			if graceful {
+				return o.gracefulRestart(ctx, zomboidServer, warning)
+			}
+
+			pods, err := o.serverPods(ctx, zomboidServer)
+			if err != nil {
+				return err
+			}
+			if len(pods) == 0 {
+				return fmt.Errorf("ZomboidServer %s has no running pods", zomboidServer.Name)
+			}
+			for i := range pods {
+				if err := o.Client.Delete(ctx, &pods[i]); client.IgnoreNotFound(err) != nil {
+					return fmt.Errorf("failed to delete pod %s: %w", pods[i].Name, err)
+				}
+				fmt.Fprintf(o.Out, "Deleted pod %s\n", pods[i].Name)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&graceful, "graceful", false, "Warn connected players and save the world before restarting")
+	cmd.Flags().DurationVar(&warning, "warning", 5*time.Minute, "How long to warn connected players before a graceful restart")
+	return cmd
+}
+
+func (o *Options) gracefulRestart(ctx context.Context, zomboidServer *zomboidv1.ZomboidServer, warning time.Duration) error {
+	session, err := o.rconSession(ctx, zomboidServer)
+	if err != nil {
+		return err
+	}
+	defer session.Close()
+
+	var connected []string
+	if err := session.Do(ctx, func(client rcon.Client) (err error) {
+		connected, err = players.GetConnectedPlayers(ctx, client)
+		return err
+	}); err != nil {
+		return err
+	}
+
+	// Nobody needs warning on an empty server
+	if len(connected) > 0 && warning > 0 {
+		countdown := []time.Duration{warning}
+		for _, remaining := range restartWarnings {
+			if remaining < warning {
+				countdown = append(countdown, remaining)
+			}
+		}
+
+		for i, remaining := range countdown {
+			message := fmt.Sprintf("The server will restart in %s", players.FormatDuration(remaining))
+			if err := session.Do(ctx, func(client rcon.Client) error {
+				return players.SendServerMessage(ctx, client, message)
+			}); err != nil {
+				return err
+			}
+			fmt.Fprintln(o.Out, message)
+
+			next := time.Duration(0)
+			if i+1 < len(countdown) {
+				next = countdown[i+1]
+			}
+			o.Sleep(remaining - next)
+		}
+	}
+
+	if err := session.Do(ctx, func(client rcon.Client) error {
+		if err := settings.SaveWorld(ctx, client); err != nil {
+			return err
+		}
+		return settings.RestartServer(ctx, client)
+	}); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(o.Out, "Saved the world and restarted %s\n", zomboidServer.Name)
+	return nil
+}
+
+func newSuspendCommand(o *Options, suspend bool) *cobra.Command {
+	use, short, verb := "resume SERVER", "Start a suspended server", "Resumed"
+	if suspend {
+		use, short, verb = "suspend SERVER", "Stop a server, keeping its data", "Suspended"
+	}
+
+	return &cobra.Command{
+		Use:   use,
+		Short: short,
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			zomboidServer, err := o.getServer(cmd.Context(), args[0])
+			if err != nil {
+				return err
+			}
+
+			if err := o.setSuspended(cmd.Context(), zomboidServer, suspend); err != nil {
+				return err
+			}
+
+			fmt.Fprintf(o.Out, "%s %s\n", verb, zomboidServer.Name)
+			return nil
+		},
+	}
+}
+
+func (o *Options) setSuspended(ctx context.Context, zomboidServer *zomboidv1.ZomboidServer, suspend bool) error {
+	patch := client.MergeFrom(zomboidServer.DeepCopy())
+	zomboidServer.Spec.Suspended = ptr.To(suspend)
+	if err := o.Client.Patch(ctx, zomboidServer, patch); err != nil {
+		return fmt.Errorf("failed to update ZomboidServer: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,57 @@
+package kubectl
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func newLogsCommand(o *Options) *cobra.Command {
+	var follow bool
+	var tail int64
+
+	cmd := &cobra.Command{
+		Use:   "logs SERVER",
+		Short: "Print the logs of a server's game process",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+
+			zomboidServer, err := o.getServer(ctx, args[0])
+			if err != nil {
+				return err
+			}
+
+			pods, err := o.serverPods(ctx, zomboidServer)
+			if err != nil {
+				return err
+			}
+			if len(pods) == 0 {
+				return fmt.Errorf("ZomboidServer %s has no running pods", zomboidServer.Name)
+			}
+
+			options := &corev1.PodLogOptions{
+				Container: "zomboid",
+				Follow:    follow,
+			}
+			if tail >= 0 {
+				options.TailLines = &tail
+			}
+
+			stream, err := o.Clientset.CoreV1().Pods(zomboidServer.Namespace).GetLogs(pods[0].Name, options).Stream(ctx)
+			if err != nil {
+				return fmt.Errorf("failed to get logs: %w", err)
+			}
+			defer stream.Close()
+
+			_, err = io.Copy(o.Out, stream)
+			return err
+		},
+	}
+
+	cmd.Flags().BoolVarP(&follow, "follow", "f", false, "Keep streaming new log lines")
+	cmd.Flags().Int64Var(&tail, "tail", -1, "Only print this many of the most recent lines")
+	return cmd
+}
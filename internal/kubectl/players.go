@@ -0,0 +1,141 @@
+package kubectl
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/zomboidhost/zomboid-operator/internal/players"
+	"github.com/zomboidhost/zomboid-operator/internal/rcon"
+)
+
+func newPlayersCommand(o *Options) *cobra.Command {
+	return &cobra.Command{
+		Use:   "players SERVER",
+		Short: "List the players connected to a server",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			zomboidServer, err := o.getServer(cmd.Context(), args[0])
+			if err != nil {
+				return err
+			}
+
+			var connected []string
+			if err := o.withRCON(cmd.Context(), zomboidServer, func(client rcon.Client) (err error) {
+				connected, err = players.GetConnectedPlayers(cmd.Context(), client)
+				return err
+			}); err != nil {
+				return err
+			}
+
+			for _, username := range connected {
+				fmt.Fprintln(o.Out, username)
+			}
+			return nil
+		},
+	}
+}
+
+func newKickCommand(o *Options) *cobra.Command {
+	var reason string
+
+	cmd := &cobra.Command{
+		Use:   "kick SERVER USERNAME",
+		Short: "Disconnect a player from a server",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			zomboidServer, err := o.getServer(cmd.Context(), args[0])
+			if err != nil {
+				return err
+			}
+
+			if err := o.withRCON(cmd.Context(), zomboidServer, func(client rcon.Client) error {
+				return players.KickUser(cmd.Context(), client, args[1], reason)
+			}); err != nil {
+				return err
+			}
+
+			fmt.Fprintf(o.Out, "Kicked %s\n", args[1])
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&reason, "reason", "r", "", "The reason shown to the player")
+	return cmd
+}
+
+// newBanCommand returns the ban or unban command.  Users declared in the
+// server's spec are banned through the spec, so the operator doesn't undo it.
+func newBanCommand(o *Options, ban bool) *cobra.Command {
+	use, short, verb := "unban SERVER USERNAME", "Allow a banned user back onto a server", "Unbanned"
+	if ban {
+		use, short, verb = "ban SERVER USERNAME", "Ban a user from a server", "Banned"
+	}
+
+	return &cobra.Command{
+		Use:   use,
+		Short: short,
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			username := args[1]
+
+			zomboidServer, err := o.getServer(ctx, args[0])
+			if err != nil {
+				return err
+			}
+
+			for i, user := range zomboidServer.Spec.Users {
+				if user.Username != username {
+					continue
+				}
+
+				patch := client.MergeFrom(zomboidServer.DeepCopy())
+				zomboidServer.Spec.Users[i].Banned = ban
+				if err := o.Client.Patch(ctx, zomboidServer, patch); err != nil {
+					return fmt.Errorf("failed to update ZomboidServer: %w", err)
+				}
+
+				fmt.Fprintf(o.Out, "%s %s in the ZomboidServer spec\n", verb, username)
+				return nil
+			}
+
+			if err := o.withRCON(ctx, zomboidServer, func(client rcon.Client) error {
+				if ban {
+					return players.BanUser(ctx, client, username)
+				}
+				return players.UnbanUser(ctx, client, username)
+			}); err != nil {
+				return err
+			}
+
+			fmt.Fprintf(o.Out, "%s %s\n", verb, username)
+			return nil
+		},
+	}
+}
+
+func newMessageCommand(o *Options) *cobra.Command {
+	return &cobra.Command{
+		Use:   "msg SERVER MESSAGE...",
+		Short: "Broadcast a message to everyone on a server",
+		Args:  cobra.MinimumNArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			zomboidServer, err := o.getServer(cmd.Context(), args[0])
+			if err != nil {
+				return err
+			}
+
+			message := strings.Join(args[1:], " ")
+			if strings.Contains(message, `"`) {
+				return fmt.Errorf("messages can't contain double quotes")
+			}
+
+			return o.withRCON(cmd.Context(), zomboidServer, func(client rcon.Client) error {
+				return players.SendServerMessage(cmd.Context(), client, message)
+			})
+		},
+	}
+}
@@ -0,0 +1,229 @@
+package kubectl
+
+import (
+	"fmt"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	zomboidv1 "github.com/zomboidhost/zomboid-operator/api/v1"
+	"github.com/zomboidhost/zomboid-operator/internal/rcon"
+	"github.com/zomboidhost/zomboid-operator/internal/settings"
+)
+
+func newSettingsCommand(o *Options) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "settings",
+		Short: "Inspect and change a server's settings",
+	}
+
+	cmd.AddCommand(
+		newSettingsGetCommand(o),
+		newSettingsSetCommand(o),
+		newSettingsDiffCommand(o),
+		newSettingsExportCommand(o),
+		newSettingsHistoryCommand(o),
+	)
+	return cmd
+}
+
+func newSettingsGetCommand(o *Options) *cobra.Command {
+	var live bool
+
+	cmd := &cobra.Command{
+		Use:   "get SERVER [SETTING...]",
+		Short: "Show a server's settings, as last observed by the operator",
+		Args:  cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			zomboidServer, err := o.getServer(cmd.Context(), args[0])
+			if err != nil {
+				return err
+			}
+
+			var observed zomboidv1.ZomboidSettings
+			if live {
+				if err := o.withRCON(cmd.Context(), zomboidServer, func(client rcon.Client) error {
+					return settings.ReadServerOptions(cmd.Context(), client, &observed)
+				}); err != nil {
+					return err
+				}
+			} else if zomboidServer.Status.Settings != nil {
+				observed = *zomboidServer.Status.Settings
+			} else {
+				return fmt.Errorf("the operator hasn't observed the settings of %s yet; try --live", zomboidServer.Name)
+			}
+
+			values := settings.Values(observed)
+			if len(args) == 1 {
+				for _, value := range values {
+					fmt.Fprintf(o.Out, "%s=%s\n", value[0], value[1])
+				}
+				return nil
+			}
+
+			for _, name := range args[1:] {
+				if _, ok := settings.LookupSetting(name); !ok {
+					return fmt.Errorf("%w %s", settings.ErrUnknownSetting, name)
+				}
+				for _, value := range values {
+					if value[0] == name {
+						fmt.Fprintf(o.Out, "%s=%s\n", value[0], value[1])
+					}
+				}
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&live, "live", false, "Read the settings from the running server instead")
+	return cmd
+}
+
+func newSettingsSetCommand(o *Options) *cobra.Command {
+	return &cobra.Command{
+		Use:   "set SERVER SETTING=VALUE...",
+		Short: "Change settings in a server's spec, for the operator to apply",
+		Args:  cobra.MinimumNArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			zomboidServer, err := o.getServer(cmd.Context(), args[0])
+			if err != nil {
+				return err
+			}
+
+			patch := client.MergeFrom(zomboidServer.DeepCopy())
+			for _, arg := range args[1:] {
+				name, value, ok := strings.Cut(arg, "=")
+				if !ok {
+					return fmt.Errorf("expected SETTING=VALUE, got %q", arg)
+				}
+				if err := settings.ParseSettingValue(&zomboidServer.Spec.Settings, name, value); err != nil {
+					return err
+				}
+			}
+
+			if err := o.Client.Patch(cmd.Context(), zomboidServer, patch); err != nil {
+				return fmt.Errorf("failed to update ZomboidServer: %w", err)
+			}
+
+			fmt.Fprintf(o.Out, "Updated the settings of %s\n", zomboidServer.Name)
+			return nil
+		},
+	}
+}
+
+func newSettingsDiffCommand(o *Options) *cobra.Command {
+	return &cobra.Command{
+		Use:   "diff SERVER",
+		Short: "Show the settings the operator will change on a server",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			zomboidServer, err := o.getServer(cmd.Context(), args[0])
+			if err != nil {
+				return err
+			}
+			if zomboidServer.Status.Settings == nil {
+				return fmt.Errorf("the operator hasn't observed the settings of %s yet", zomboidServer.Name)
+			}
+
+			observed := map[string]string{}
+			for _, value := range settings.Values(*zomboidServer.Status.Settings) {
+				observed[value[0]] = value[1]
+			}
+
+			updates := settings.ManagedUpdates(zomboidServer.Spec.SettingsPolicy, settings.Desired(zomboidServer), *zomboidServer.Status.Settings)
+			if len(updates) == 0 {
+				fmt.Fprintln(o.Out, "Settings are in sync")
+				return nil
+			}
+
+			w := tabwriter.NewWriter(o.Out, 0, 4, 2, ' ', 0)
+			fmt.Fprintln(w, "SETTING\tOBSERVED\tDESIRED\tRESTART")
+			for _, update := range updates {
+				restart := ""
+				if settings.RequiresRestart(update[0]) {
+					restart = "required"
+				}
+				fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", update[0], observed[update[0]], update[1], restart)
+			}
+			return w.Flush()
+		},
+	}
+}
+
+func newSettingsExportCommand(o *Options) *cobra.Command {
+	return &cobra.Command{
+		Use:   "export SERVER",
+		Short: "Print a server's effective settings as a server.ini",
+		Long: "Print a server's effective settings as a server.ini: the settings last observed by the " +
+			"operator, or the defaults, with the changes the operator is making applied.  Passwords " +
+			"are left out.",
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			zomboidServer, err := o.getServer(cmd.Context(), args[0])
+			if err != nil {
+				return err
+			}
+
+			_, err = o.Out.Write(settings.ExportServerINI(zomboidServer))
+			return err
+		},
+	}
+}
+
+func newSettingsHistoryCommand(o *Options) *cobra.Command {
+	return &cobra.Command{
+		Use:   "history SERVER [SETTING...]",
+		Short: "Show the settings the operator has changed on a server",
+		Long: "Show the settings the operator has changed on a server, oldest first, with the " +
+			"generation of the ZomboidServer each change was made for and the field manager that " +
+			"last changed its spec.  Give setting names to only show their changes.",
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			configMap := &corev1.ConfigMap{}
+			if err := o.Client.Get(cmd.Context(), types.NamespacedName{
+				Name:      settings.HistoryConfigMapName(args[0]),
+				Namespace: o.Namespace,
+			}, configMap); err != nil {
+				if apierrors.IsNotFound(err) {
+					fmt.Fprintf(o.Out, "The operator hasn't changed the settings of %s\n", args[0])
+					return nil
+				}
+				return fmt.Errorf("failed to get the settings history of %s: %w", args[0], err)
+			}
+
+			history, err := settings.ReadHistory(configMap)
+			if err != nil {
+				return err
+			}
+
+			only := map[string]bool{}
+			for _, name := range args[1:] {
+				only[name] = true
+			}
+
+			w := tabwriter.NewWriter(o.Out, 0, 4, 2, ' ', 0)
+			fmt.Fprintln(w, "TIME\tGENERATION\tCAUSE\tMANAGER\tSETTING\tBEFORE\tAFTER")
+			for _, entry := range history {
+				for _, change := range entry.Changes {
+					if len(only) > 0 && !only[change.Name] {
+						continue
+					}
+					// Only changes to the spec were made on its manager's behalf
+					manager := entry.Manager
+					if change.Cause != "" && change.Cause != settings.CauseSpec {
+						manager = ""
+					}
+					fmt.Fprintf(w, "%s\t%d\t%s\t%s\t%s\t%s\t%s\n", entry.Time.Format(time.RFC3339), entry.Generation,
+						change.Cause, manager, change.Name, change.Before, change.After)
+				}
+			}
+			return w.Flush()
+		},
+	}
+}
@@ -0,0 +1,142 @@
+package kubectl
+
+import (
+	"fmt"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	zomboidv1 "github.com/zomboidhost/zomboid-operator/api/v1"
+	"github.com/zomboidhost/zomboid-operator/internal/settings"
+)
+
+func newStatusCommand(o *Options) *cobra.Command {
+	return &cobra.Command{
+		Use:   "status SERVER",
+		Short: "Show a server's readiness, players, version and settings drift",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			zomboidServer, err := o.getServer(cmd.Context(), args[0])
+			if err != nil {
+				return err
+			}
+
+			w := tabwriter.NewWriter(o.Out, 0, 4, 2, ' ', 0)
+			fmt.Fprintf(w, "Name:\t%s\n", zomboidServer.Name)
+			fmt.Fprintf(w, "Namespace:\t%s\n", zomboidServer.Namespace)
+			fmt.Fprintf(w, "Version:\t%s\n", zomboidServer.Spec.Version)
+			fmt.Fprintf(w, "Ready:\t%t\n", zomboidServer.Status.Ready)
+			fmt.Fprintf(w, "Suspended:\t%t\n", zomboidServer.Spec.Suspended != nil && *zomboidServer.Spec.Suspended)
+			fmt.Fprintf(w, "Players:\t%s\n", playerSummary(zomboidServer))
+			fmt.Fprintf(w, "Settings:\t%s\n", driftSummary(zomboidServer))
+			if pending := zomboidServer.Status.PendingRestart; len(pending) > 0 {
+				var names []string
+				for _, setting := range pending {
+					names = append(names, setting.Name)
+				}
+				when := "once the server restarts"
+				if restartAt := zomboidServer.Status.RestartAt; restartAt != nil {
+					when = "when the server restarts at " + restartAt.UTC().Format(time.RFC3339)
+				}
+				fmt.Fprintf(w, "Restart:\t%s take effect %s\n", strings.Join(names, ", "), when)
+			}
+			if mods := zomboidServer.Status.WorkshopMods; len(mods) > 0 {
+				fmt.Fprintf(w, "Workshop:\n")
+				for _, item := range mods {
+					fmt.Fprintf(w, "  %s:\t%s\t%s\t%s\n", item.WorkshopID, item.Title, formatSize(item.Size), workshopModSummary(item))
+				}
+			}
+			if len(zomboidServer.Status.Conditions) > 0 {
+				fmt.Fprintf(w, "Conditions:\n")
+				for _, condition := range zomboidServer.Status.Conditions {
+					fmt.Fprintf(w, "  %s:\t%s\t%s\t%s\n", condition.Type, condition.Status, condition.Reason, condition.Message)
+				}
+			}
+			return w.Flush()
+		},
+	}
+}
+
+func playerSummary(zomboidServer *zomboidv1.ZomboidServer) string {
+	var usernames []string
+	for _, player := range zomboidServer.Status.ConnectedPlayers {
+		usernames = append(usernames, player.Username)
+	}
+
+	summary := fmt.Sprintf("%d", len(usernames))
+	if observed := zomboidServer.Status.Settings; observed != nil && observed.Player.MaxPlayers != nil {
+		summary += fmt.Sprintf("/%d", *observed.Player.MaxPlayers)
+	}
+	if len(usernames) > 0 {
+		summary += " (" + strings.Join(usernames, ", ") + ")"
+	}
+	return summary
+}
+
+func driftSummary(zomboidServer *zomboidv1.ZomboidServer) string {
+	if zomboidServer.Status.Settings == nil {
+		return "not yet observed"
+	}
+
+	updates := settings.ManagedUpdates(zomboidServer.Spec.SettingsPolicy, settings.Desired(zomboidServer), *zomboidServer.Status.Settings)
+	drift := settings.Drift(settings.Desired(zomboidServer), *zomboidServer.Status.Settings)
+	if len(updates) == 0 && len(drift) == 0 {
+		return "in sync"
+	}
+
+	var summaries []string
+	if len(updates) > 0 {
+		var names []string
+		for _, update := range updates {
+			names = append(names, update[0])
+		}
+		summaries = append(summaries, fmt.Sprintf("%d pending (%s)", len(updates), strings.Join(names, ", ")))
+	}
+	if len(drift) > len(updates) {
+		var names []string
+		for _, setting := range drift {
+			names = append(names, setting.Name)
+		}
+		summaries = append(summaries, fmt.Sprintf("%d drifted under %s policy (%s)",
+			len(drift), settingsPolicy(zomboidServer), strings.Join(names, ", ")))
+	}
+	return strings.Join(summaries, "; ")
+}
+
+func settingsPolicy(zomboidServer *zomboidv1.ZomboidServer) zomboidv1.SettingsPolicy {
+	if zomboidServer.Spec.SettingsPolicy == "" {
+		return zomboidv1.SettingsPolicyEnforce
+	}
+	return zomboidServer.Spec.SettingsPolicy
+}
+
+func workshopModSummary(item zomboidv1.WorkshopModStatus) string {
+	var modIDs []string
+	for _, mod := range item.Mods {
+		modIDs = append(modIDs, mod.ID)
+	}
+	summary := "not downloaded"
+	if len(modIDs) > 0 {
+		summary = strings.Join(modIDs, ", ")
+	}
+	if len(item.RequiredBy) > 0 {
+		summary += " (required by " + strings.Join(item.RequiredBy, ", ") + ")"
+	}
+	return summary
+}
+
+// formatSize formats a size in bytes in binary units
+func formatSize(size int64) string {
+	const unit = 1024
+	if size < unit {
+		return fmt.Sprintf("%d B", size)
+	}
+	div, exp := int64(unit), 0
+	for n := size / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(size)/float64(div), "KMGTPE"[exp])
+}
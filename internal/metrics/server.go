@@ -7,21 +7,30 @@ import (
 	"os"
 	"time"
 
-	"github.com/gorcon/rcon"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/zomboidhost/zomboid-operator/internal/players"
+	"github.com/zomboidhost/zomboid-operator/internal/rcon"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 )
 
 type Server struct {
+	rcon *rcon.Session
+
 	playerCount    prometheus.Gauge
 	allowlistCount prometheus.Gauge
 }
 
 func NewServer() *Server {
 	return &Server{
+		rcon: rcon.NewSession(
+			os.Getenv("ZOMBOID_SERVER_NAME"),
+			os.Getenv("RCON_PASSWORD"),
+			func(ctx context.Context) (string, func(), error) {
+				return "localhost:27015", nil, nil
+			},
+		),
 		playerCount: promauto.NewGauge(prometheus.GaugeOpts{
 			Name: "zomboid_connected_players",
 			Help: "Number of players currently connected to the game server",
@@ -44,6 +53,7 @@ func (s *Server) collectMetrics(ctx context.Context) {
 	logger := log.FromContext(ctx)
 	ticker := time.NewTicker(15 * time.Second)
 	defer ticker.Stop()
+	defer s.rcon.Close()
 
 	for {
 		select {
@@ -58,24 +68,21 @@ func (s *Server) collectMetrics(ctx context.Context) {
 }
 
 func (s *Server) updateMetrics(ctx context.Context) error {
-	rconConn, err := rcon.Dial(
-		"localhost:27015",
-		os.Getenv("RCON_PASSWORD"),
-		rcon.SetDialTimeout(5*time.Second),
-		rcon.SetDeadline(5*time.Second),
-	)
-	if err != nil {
-		return fmt.Errorf("failed to connect to RCON: %w", err)
-	}
-	defer rconConn.Close()
-
-	connected, err := players.GetConnectedPlayers(ctx, rconConn)
+	var connected []string
+	err := s.rcon.Do(ctx, func(client rcon.Client) (err error) {
+		connected, err = players.GetConnectedPlayers(ctx, client)
+		return err
+	})
 	if err != nil {
 		return fmt.Errorf("failed to query connected players: %w", err)
 	}
 	s.playerCount.Set(float64(len(connected)))
 
-	count, err := players.GetAllowlistCount("localhost", 12321, os.Getenv("ZOMBOID_SERVER_NAME"))
+	credentials := players.SQLiteCredentials{
+		Username: os.Getenv("SQLITE_USERNAME"),
+		Password: os.Getenv("SQLITE_PASSWORD"),
+	}
+	count, err := players.GetAllowlistCount("localhost", 12321, os.Getenv("ZOMBOID_SERVER_NAME"), credentials)
 	if err != nil {
 		return fmt.Errorf("failed to query allowlist count: %w", err)
 	}
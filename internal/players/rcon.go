@@ -4,14 +4,15 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
-	"github.com/gorcon/rcon"
+	"github.com/zomboidhost/zomboid-operator/internal/rcon"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 )
 
 // GetConnectedPlayers connects to an RCON server and retrieves the list of connected players
-func GetConnectedPlayers(ctx context.Context, conn *rcon.Conn) ([]string, error) {
-	response, err := conn.Execute("players")
+func GetConnectedPlayers(ctx context.Context, client rcon.Client) ([]string, error) {
+	response, err := client.Execute("players")
 	if err != nil {
 		return nil, fmt.Errorf("failed to execute players command: %w", err)
 	}
@@ -42,11 +43,11 @@ func GetConnectedPlayers(ctx context.Context, conn *rcon.Conn) ([]string, error)
 }
 
 // AddUser adds a new user to the server
-func AddUser(ctx context.Context, conn *rcon.Conn, username, password string) error {
+func AddUser(ctx context.Context, client rcon.Client, username, password string) error {
 	logger := log.FromContext(ctx)
 	cmd := fmt.Sprintf("adduser \"%s\" \"%s\"", username, password)
 	logger.Info("Executing RCON command", "command", fmt.Sprintf("adduser \"%s\" \"****\"", username))
-	response, err := conn.Execute(cmd)
+	response, err := client.Execute(cmd)
 	if err == nil {
 		logger.Info("RCON command response", "response", response)
 	}
@@ -54,14 +55,14 @@ func AddUser(ctx context.Context, conn *rcon.Conn, username, password string) er
 }
 
 // SetAccessLevel sets the access level for a user
-func SetAccessLevel(ctx context.Context, conn *rcon.Conn, username, accessLevel string) error {
+func SetAccessLevel(ctx context.Context, client rcon.Client, username, accessLevel string) error {
 	if accessLevel == "" {
 		accessLevel = "Player" // this is how we remove the access level to reset the user back to player
 	}
 	logger := log.FromContext(ctx)
 	cmd := fmt.Sprintf("setaccesslevel \"%s\" \"%s\"", username, accessLevel)
 	logger.Info("Executing RCON command", "command", cmd)
-	response, err := conn.Execute(cmd)
+	response, err := client.Execute(cmd)
 	if err == nil {
 		logger.Info("RCON command response", "response", response)
 	}
@@ -69,11 +70,11 @@ func SetAccessLevel(ctx context.Context, conn *rcon.Conn, username, accessLevel
 }
 
 // BanUser bans a user from the server
-func BanUser(ctx context.Context, conn *rcon.Conn, username string) error {
+func BanUser(ctx context.Context, client rcon.Client, username string) error {
 	logger := log.FromContext(ctx)
 	cmd := fmt.Sprintf("banuser \"%s\"", username)
 	logger.Info("Executing RCON command", "command", cmd)
-	response, err := conn.Execute(cmd)
+	response, err := client.Execute(cmd)
 	if err == nil {
 		logger.Info("RCON command response", "response", response)
 	}
@@ -81,11 +82,11 @@ func BanUser(ctx context.Context, conn *rcon.Conn, username string) error {
 }
 
 // UnbanUser unbans a user from the server
-func UnbanUser(ctx context.Context, conn *rcon.Conn, username string) error {
+func UnbanUser(ctx context.Context, client rcon.Client, username string) error {
 	logger := log.FromContext(ctx)
 	cmd := fmt.Sprintf("unbanuser \"%s\"", username)
 	logger.Info("Executing RCON command", "command", cmd)
-	response, err := conn.Execute(cmd)
+	response, err := client.Execute(cmd)
 	if err == nil {
 		logger.Info("RCON command response", "response", response)
 	}
@@ -93,13 +94,52 @@ func UnbanUser(ctx context.Context, conn *rcon.Conn, username string) error {
 }
 
 // RemoveUser removes a user from the server's whitelist
-func RemoveUser(ctx context.Context, conn *rcon.Conn, username string) error {
+func RemoveUser(ctx context.Context, client rcon.Client, username string) error {
 	logger := log.FromContext(ctx)
 	cmd := fmt.Sprintf("removeuserfromwhitelist \"%s\"", username)
 	logger.Info("Executing RCON command", "command", cmd)
-	response, err := conn.Execute(cmd)
+	response, err := client.Execute(cmd)
 	if err == nil {
 		logger.Info("RCON command response", "response", response)
 	}
 	return err
 }
+
+// KickUser disconnects a user from the server, showing them the reason if one is given
+func KickUser(ctx context.Context, client rcon.Client, username, reason string) error {
+	logger := log.FromContext(ctx)
+	cmd := fmt.Sprintf("kickuser \"%s\"", username)
+	if reason != "" {
+		cmd += fmt.Sprintf(" -r \"%s\"", reason)
+	}
+	logger.Info("Executing RCON command", "command", cmd)
+	response, err := client.Execute(cmd)
+	if err == nil {
+		logger.Info("RCON command response", "response", response)
+	}
+	return err
+}
+
+// SendServerMessage broadcasts a message to every connected player
+func SendServerMessage(ctx context.Context, client rcon.Client, message string) error {
+	logger := log.FromContext(ctx)
+	cmd := fmt.Sprintf("servermsg \"%s\"", message)
+	logger.Info("Executing RCON command", "command", cmd)
+	response, err := client.Execute(cmd)
+	if err == nil {
+		logger.Info("RCON command response", "response", response)
+	}
+	return err
+}
+
+// FormatDuration describes a duration the way players would say it
+func FormatDuration(d time.Duration) string {
+	unit, count := "second", int(d.Round(time.Second)/time.Second)
+	if d >= time.Minute && d%time.Minute == 0 {
+		unit, count = "minute", int(d/time.Minute)
+	}
+	if count != 1 {
+		unit += "s"
+	}
+	return fmt.Sprintf("%d %s", count, unit)
+}
@@ -13,6 +13,12 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/log"
 )
 
+// SQLiteCredentials authenticates requests to a server's ws4sqlite sidecar
+type SQLiteCredentials struct {
+	Username string
+	Password string
+}
+
 type sqliteRequest struct {
 	Transaction []sqliteStatement `json:"transaction"`
 }
@@ -23,8 +29,21 @@ type sqliteStatement struct {
 	Values    map[string]interface{} `json:"values,omitempty"`
 }
 
+// postSQLiteRequest sends a transaction to the ws4sqlite service using HTTP
+// basic authentication
+func postSQLiteRequest(sqliteUrl string, credentials SQLiteCredentials, requestBody []byte) (*http.Response, error) {
+	req, err := http.NewRequest(http.MethodPost, sqliteUrl, bytes.NewReader(requestBody))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.SetBasicAuth(credentials.Username, credentials.Password)
+
+	return http.DefaultClient.Do(req)
+}
+
 // GetAllowlist queries the ws4sqlite service to retrieve the current allowlist
-func GetAllowlist(hostname string, port int, serverName string) ([]zomboidv1.AllowlistUser, error) {
+func GetAllowlist(hostname string, port int, serverName string, credentials SQLiteCredentials) ([]zomboidv1.AllowlistUser, error) {
 	sqliteUrl := fmt.Sprintf("http://%s:%d/%s", hostname, port, serverName)
 
 	request := sqliteRequest{
@@ -40,7 +59,7 @@ func GetAllowlist(hostname string, port int, serverName string) ([]zomboidv1.All
 		return nil, fmt.Errorf("failed to marshal request: %w", err)
 	}
 
-	resp, err := http.Post(sqliteUrl, "application/json", bytes.NewReader(requestBody))
+	resp, err := postSQLiteRequest(sqliteUrl, credentials, requestBody)
 	if err != nil {
 		return nil, fmt.Errorf("failed to make HTTP request: %w", err)
 	}
@@ -93,7 +112,8 @@ func GetAllowlist(hostname string, port int, serverName string) ([]zomboidv1.All
 	return allowlist, nil
 }
 
-func SetPassword(ctx context.Context, hostname string, port int, serverName string, username string, password string) error {
+// SetPassword updates a user's password directly in the server's database
+func SetPassword(ctx context.Context, hostname string, port int, serverName string, credentials SQLiteCredentials, username string, password string) error {
 	sqliteUrl := fmt.Sprintf("http://%s:%d/%s", hostname, port, serverName)
 
 	bcryptHash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
@@ -121,7 +141,7 @@ func SetPassword(ctx context.Context, hostname string, port int, serverName stri
 	logger := log.FromContext(ctx)
 	logger.Info("setting password for %s to %s", username, bcryptHash)
 
-	resp, err := http.Post(sqliteUrl, "application/json", bytes.NewReader(requestBody))
+	resp, err := postSQLiteRequest(sqliteUrl, credentials, requestBody)
 	if err != nil {
 		return fmt.Errorf("failed to send request: %w", err)
 	}
@@ -139,7 +159,7 @@ func SetPassword(ctx context.Context, hostname string, port int, serverName stri
 }
 
 // GetAllowlistCount queries the ws4sqlite service to get the count of allowlisted players
-func GetAllowlistCount(hostname string, port int, serverName string) (int, error) {
+func GetAllowlistCount(hostname string, port int, serverName string, credentials SQLiteCredentials) (int, error) {
 	sqliteUrl := fmt.Sprintf("http://%s:%d/%s", hostname, port, serverName)
 
 	request := sqliteRequest{
@@ -155,7 +175,7 @@ func GetAllowlistCount(hostname string, port int, serverName string) (int, error
 		return 0, fmt.Errorf("failed to marshal request: %w", err)
 	}
 
-	resp, err := http.Post(sqliteUrl, "application/json", bytes.NewReader(requestBody))
+	resp, err := postSQLiteRequest(sqliteUrl, credentials, requestBody)
 	if err != nil {
 		return 0, fmt.Errorf("failed to make HTTP request: %w", err)
 	}
@@ -0,0 +1,9 @@
+package rcon
+
+// Client executes commands against a server's remote console.  It is
+// satisfied by *rcon.Conn from github.com/gorcon/rcon, and lets code that
+// issues commands be exercised against the fake server in
+// internal/rcon/fake.
+type Client interface {
+	Execute(command string) (string, error)
+}
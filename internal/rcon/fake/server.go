@@ -0,0 +1,618 @@
+// Package fake provides an in-process stand-in for a Project Zomboid server,
+// speaking RCON and answering the ws4sqlite queries the operator makes, so
+// that code driving a server can be tested without running one.
+package fake
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+
+	gorcon "github.com/gorcon/rcon"
+)
+
+// User is an entry in the fake server's whitelist table
+type User struct {
+	ID          int
+	Username    string
+	Password    string
+	AccessLevel string
+	Banned      bool
+}
+
+// Server emulates the RCON console and whitelist database of a Project
+// Zomboid server.  Responses follow the formats of a real build 41 server.
+type Server struct {
+	password string
+
+	listener net.Listener
+	sqlite   *httptest.Server
+
+	mu               sync.Mutex
+	options          []string
+	values           map[string]string
+	users            []User
+	nextUserID       int
+	players          []string
+	messages         []string
+	commands         []string
+	restarts         int
+	saves            int
+	sqliteCredential *url.Userinfo
+	conns            map[net.Conn]struct{}
+	wg               sync.WaitGroup
+	closed           bool
+}
+
+// DefaultOptions are the options a new fake server starts with, in the order
+// showoptions lists them.  Every setting the server only reads on start is
+// listed with its default, so that a new fake server needs no restart.
+var DefaultOptions = [][2]string{
+	{"PVP", "true"},
+	{"PauseEmpty", "true"},
+	{"GlobalChat", "true"},
+	{"Open", "true"},
+	{"ServerWelcomeMessage", "Welcome to Project Zomboid Multiplayer!"},
+	{"AutoCreateUserInWhiteList", "false"},
+	{"MaxPlayers", "32"},
+	{"PingLimit", "400"},
+	{"Public", "false"},
+	{"PublicName", "My PZ Server"},
+	{"PublicDescription", ""},
+	{"ResetID", "485871306"},
+	{"ServerPlayerID", "63827612"},
+	{"Map", "Muldraugh, KY"},
+	{"Mods", ""},
+	{"WorkshopItems", ""},
+	{"SteamScoreboard", "true"},
+	{"VoiceEnable", "true"},
+	{"VoiceMinDistance", "10.0"},
+	{"VoiceMaxDistance", "100.0"},
+	{"Voice3D", "true"},
+	{"DoLuaChecksum", "true"},
+	{"AntiCheatProtectionType1", "true"},
+	{"AntiCheatProtectionType2", "true"},
+	{"AntiCheatProtectionType3", "true"},
+	{"AntiCheatProtectionType4", "true"},
+	{"AntiCheatProtectionType5", "true"},
+	{"AntiCheatProtectionType6", "true"},
+	{"AntiCheatProtectionType7", "true"},
+	{"AntiCheatProtectionType8", "true"},
+	{"AntiCheatProtectionType9", "true"},
+	{"AntiCheatProtectionType10", "true"},
+	{"AntiCheatProtectionType11", "true"},
+	{"AntiCheatProtectionType12", "true"},
+	{"AntiCheatProtectionType13", "true"},
+	{"AntiCheatProtectionType14", "true"},
+	{"AntiCheatProtectionType15", "true"},
+	{"AntiCheatProtectionType16", "true"},
+	{"AntiCheatProtectionType17", "true"},
+	{"AntiCheatProtectionType18", "true"},
+	{"AntiCheatProtectionType19", "true"},
+	{"AntiCheatProtectionType20", "true"},
+	{"AntiCheatProtectionType21", "true"},
+	{"AntiCheatProtectionType22", "true"},
+	{"AntiCheatProtectionType23", "true"},
+	{"AntiCheatProtectionType24", "true"},
+	{"AntiCheatProtectionType2ThresholdMultiplier", "3.0"},
+	{"AntiCheatProtectionType3ThresholdMultiplier", "1.0"},
+	{"AntiCheatProtectionType4ThresholdMultiplier", "1.0"},
+	{"AntiCheatProtectionType9ThresholdMultiplier", "1.0"},
+	{"AntiCheatProtectionType15ThresholdMultiplier", "1.0"},
+	{"AntiCheatProtectionType20ThresholdMultiplier", "1.0"},
+	{"AntiCheatProtectionType22ThresholdMultiplier", "1.0"},
+	{"AntiCheatProtectionType24ThresholdMultiplier", "6.0"},
+}
+
+// NewServer starts a fake server accepting RCON connections authenticated
+// with password.
+func NewServer(password string) (*Server, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen for RCON: %w", err)
+	}
+
+	s := &Server{
+		password:   password,
+		listener:   listener,
+		values:     make(map[string]string),
+		nextUserID: 1,
+		conns:      make(map[net.Conn]struct{}),
+	}
+	for _, option := range DefaultOptions {
+		s.options = append(s.options, option[0])
+		s.values[option[0]] = option[1]
+	}
+
+	s.sqlite = httptest.NewServer(http.HandlerFunc(s.serveSQLite))
+
+	s.wg.Add(1)
+	go s.serve()
+
+	return s, nil
+}
+
+// RCONAddr returns the host:port the RCON console listens on
+func (s *Server) RCONAddr() string {
+	return s.listener.Addr().String()
+}
+
+// SQLiteEndpoint returns the hostname and port the ws4sqlite emulation
+// listens on
+func (s *Server) SQLiteEndpoint() (string, int) {
+	addr := s.sqlite.Listener.Addr().(*net.TCPAddr)
+	return addr.IP.String(), addr.Port
+}
+
+// RequireSQLiteCredentials makes the ws4sqlite emulation reject requests not
+// authenticated with the given credentials
+func (s *Server) RequireSQLiteCredentials(username, password string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.sqliteCredential = url.UserPassword(username, password)
+}
+
+// Option returns the current value of a server option
+func (s *Server) Option(name string) (string, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	value, ok := s.values[name]
+	return value, ok
+}
+
+// SetOption sets a server option, as if it had been changed in server.ini.
+// Options the server doesn't start with are accepted, and listed by
+// showoptions after those in DefaultOptions.
+func (s *Server) SetOption(name, value string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.setOption(name, value)
+}
+
+// User returns a user from the whitelist
+func (s *Server) User(username string) (User, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if i := s.findUser(username); i >= 0 {
+		return s.users[i], true
+	}
+	return User{}, false
+}
+
+// Users returns the whitelist, ordered by ID
+func (s *Server) Users() []User {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]User(nil), s.users...)
+}
+
+// ConnectPlayer marks a player as connected to the game
+func (s *Server) ConnectPlayer(username string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.players = append(s.players, username)
+}
+
+// Messages returns every message broadcast with servermsg, in order
+func (s *Server) Messages() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]string(nil), s.messages...)
+}
+
+// Commands returns every RCON command received, in order
+func (s *Server) Commands() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]string(nil), s.commands...)
+}
+
+// Restarts returns how many times the server has been told to quit
+func (s *Server) Restarts() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.restarts
+}
+
+// Saves returns how many times the world has been saved with the save command
+func (s *Server) Saves() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.saves
+}
+
+// Close stops the server and closes any open connections
+func (s *Server) Close() {
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		return
+	}
+	s.closed = true
+	s.listener.Close()
+	for conn := range s.conns {
+		conn.Close()
+	}
+	s.mu.Unlock()
+
+	s.wg.Wait()
+	s.sqlite.Close()
+}
+
+func (s *Server) serve() {
+	defer s.wg.Done()
+
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+
+		s.mu.Lock()
+		if s.closed {
+			s.mu.Unlock()
+			conn.Close()
+			return
+		}
+		s.conns[conn] = struct{}{}
+		s.wg.Add(1)
+		s.mu.Unlock()
+
+		go s.handle(conn)
+	}
+}
+
+func (s *Server) handle(conn net.Conn) {
+	defer s.wg.Done()
+	defer func() {
+		s.mu.Lock()
+		delete(s.conns, conn)
+		s.mu.Unlock()
+		conn.Close()
+	}()
+
+	authenticated := false
+	for {
+		request := &gorcon.Packet{}
+		if _, err := request.ReadFrom(conn); err != nil {
+			return
+		}
+
+		switch request.Type {
+		case gorcon.SERVERDATA_AUTH:
+			authenticated = request.Body() == s.password
+			if !authenticated {
+				_, _ = gorcon.NewPacket(gorcon.SERVERDATA_AUTH_RESPONSE, -1, "").WriteTo(conn)
+				return
+			}
+			_, _ = gorcon.NewPacket(gorcon.SERVERDATA_RESPONSE_VALUE, request.ID, "").WriteTo(conn)
+			_, _ = gorcon.NewPacket(gorcon.SERVERDATA_AUTH_RESPONSE, request.ID, "").WriteTo(conn)
+
+		case gorcon.SERVERDATA_EXECCOMMAND:
+			if !authenticated {
+				return
+			}
+
+			response, quit := s.execute(request.Body())
+			if _, err := gorcon.NewPacket(gorcon.SERVERDATA_RESPONSE_VALUE, request.ID, response).WriteTo(conn); err != nil {
+				return
+			}
+			if quit {
+				return
+			}
+		}
+	}
+}
+
+// execute runs a console command, returning its response and whether the
+// server is shutting down
+func (s *Server) execute(command string) (string, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.commands = append(s.commands, command)
+
+	name, args := splitCommand(command)
+	switch name {
+	case "showoptions":
+		var b strings.Builder
+		b.WriteString("List of Server Options:\n")
+		for _, option := range s.options {
+			fmt.Fprintf(&b, "* %s=%s\n", option, s.values[option])
+		}
+		return b.String(), false
+
+	case "changeoption":
+		if len(args) != 2 {
+			return "changeoption : option name and value expected", false
+		}
+		s.setOption(args[0], args[1])
+		return fmt.Sprintf("Option : %s is now : %s", args[0], args[1]), false
+
+	case "players":
+		var b strings.Builder
+		fmt.Fprintf(&b, "Players connected (%d): \n", len(s.players))
+		for _, player := range s.players {
+			fmt.Fprintf(&b, "-%s\n", player)
+		}
+		return b.String(), false
+
+	case "adduser":
+		if len(args) != 2 {
+			return "Use: /adduser \"username\" \"pwd\"", false
+		}
+		if s.findUser(args[0]) >= 0 {
+			return "A user with this name already exists", false
+		}
+		s.users = append(s.users, User{
+			ID:       s.nextUserID,
+			Username: args[0],
+			Password: args[1],
+		})
+		s.nextUserID++
+		return fmt.Sprintf("User %s created with the password %s", args[0], args[1]), false
+
+	case "setaccesslevel":
+		if len(args) != 2 {
+			return "Use: /setaccesslevel \"username\" \"accesslevel\"", false
+		}
+		i := s.findUser(args[0])
+		if i < 0 {
+			return "User \"" + args[0] + "\" is not in the whitelist, use /adduser first", false
+		}
+		level := strings.ToLower(args[1])
+		if level == "player" || level == "none" {
+			s.users[i].AccessLevel = ""
+			return fmt.Sprintf("User %s no longer has access level", args[0]), false
+		}
+		s.users[i].AccessLevel = args[1]
+		return fmt.Sprintf("User %s is now %s", args[0], args[1]), false
+
+	case "banuser", "unbanuser":
+		if len(args) < 1 {
+			return "Use: /" + name + " \"username\"", false
+		}
+		i := s.findUser(args[0])
+		if i < 0 {
+			return "User \"" + args[0] + "\" is not in the whitelist, use /adduser first", false
+		}
+		s.users[i].Banned = name == "banuser"
+		if s.users[i].Banned {
+			return fmt.Sprintf("User %s is now banned", args[0]), false
+		}
+		return fmt.Sprintf("User %s is now un-banned", args[0]), false
+
+	case "removeuserfromwhitelist":
+		if len(args) != 1 {
+			return "Use: /removeuserfromwhitelist \"username\"", false
+		}
+		i := s.findUser(args[0])
+		if i < 0 {
+			return "User \"" + args[0] + "\" is not in the whitelist", false
+		}
+		s.users = append(s.users[:i], s.users[i+1:]...)
+		return fmt.Sprintf("User %s removed from white list", args[0]), false
+
+	case "kickuser":
+		if len(args) < 1 {
+			return "Use: /kickuser \"username\" -r \"reason\"", false
+		}
+		i := s.findPlayer(args[0])
+		if i < 0 {
+			return "User " + args[0] + " doesn't exist.", false
+		}
+		s.players = append(s.players[:i], s.players[i+1:]...)
+		return fmt.Sprintf("User %s kicked.", args[0]), false
+
+	case "teleport":
+		if len(args) != 2 {
+			return "Use: /teleport \"playername\" \"toplayername\"", false
+		}
+		if s.findPlayer(args[0]) < 0 || s.findPlayer(args[1]) < 0 {
+			return "Can't find player " + args[0], false
+		}
+		return fmt.Sprintf("teleported %s to %s", args[0], args[1]), false
+
+	case "additem":
+		if len(args) < 2 {
+			return "Use: /additem \"username\" \"module.item\" count", false
+		}
+		if s.findPlayer(args[0]) < 0 {
+			return "No such user", false
+		}
+		return fmt.Sprintf("Item %s Added in %s's inventory.", args[1], args[0]), false
+
+	case "servermsg":
+		if len(args) < 1 {
+			return "Use: /servermsg \"message\"", false
+		}
+		s.messages = append(s.messages, args[0])
+		return "Message sent.", false
+
+	case "save":
+		s.saves++
+		return "World saved", false
+
+	case "quit":
+		s.restarts++
+		s.players = nil
+		return "Quit", true
+	}
+
+	return "Unknown command " + name, false
+}
+
+func (s *Server) setOption(name, value string) {
+	if _, ok := s.values[name]; !ok {
+		s.options = append(s.options, name)
+	}
+	s.values[name] = value
+}
+
+func (s *Server) findPlayer(username string) int {
+	for i, player := range s.players {
+		if player == username {
+			return i
+		}
+	}
+	return -1
+}
+
+func (s *Server) findUser(username string) int {
+	for i, user := range s.users {
+		if user.Username == username {
+			return i
+		}
+	}
+	return -1
+}
+
+// splitCommand splits a console command into its name and arguments, which
+// may be quoted to include spaces
+func splitCommand(command string) (string, []string) {
+	var (
+		fields  []string
+		current strings.Builder
+		quoted  bool
+		started bool
+	)
+
+	for _, r := range command {
+		switch {
+		case r == '"':
+			quoted = !quoted
+			started = true
+		case r == ' ' && !quoted:
+			if started {
+				fields = append(fields, current.String())
+				current.Reset()
+				started = false
+			}
+		default:
+			current.WriteRune(r)
+			started = true
+		}
+	}
+	if started {
+		fields = append(fields, current.String())
+	}
+
+	if len(fields) == 0 {
+		return "", nil
+	}
+	return fields[0], fields[1:]
+}
+
+type sqliteRequest struct {
+	Transaction []struct {
+		Query     string                 `json:"query"`
+		Statement string                 `json:"statement"`
+		Values    map[string]interface{} `json:"values"`
+	} `json:"transaction"`
+}
+
+type sqliteResult struct {
+	Success     bool                     `json:"success"`
+	RowsUpdated *int                     `json:"rowsUpdated,omitempty"`
+	ResultSet   []map[string]interface{} `json:"resultSet,omitempty"`
+}
+
+// serveSQLite answers the transactions the operator sends to ws4sqlite
+func (s *Server) serveSQLite(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.sqliteCredential != nil {
+		username, password, ok := r.BasicAuth()
+		expected, _ := s.sqliteCredential.Password()
+		if !ok || username != s.sqliteCredential.Username() || password != expected {
+			http.Error(w, `{"reqIdx":-1,"error":"Unauthorized"}`, http.StatusUnauthorized)
+			return
+		}
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var request sqliteRequest
+	if err := json.Unmarshal(body, &request); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var results []sqliteResult
+	for i, statement := range request.Transaction {
+		result, err := s.executeSQLite(statement.Query+statement.Statement, statement.Values)
+		if err != nil {
+			http.Error(w, fmt.Sprintf(`{"reqIdx":%d,"error":%q}`, i, err.Error()), http.StatusBadRequest)
+			return
+		}
+		results = append(results, result)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{"results": results})
+}
+
+func (s *Server) executeSQLite(sql string, values map[string]interface{}) (sqliteResult, error) {
+	switch sql {
+	case "SELECT * FROM whitelist ORDER BY id ASC":
+		rows := make([]map[string]interface{}, 0, len(s.users))
+		for _, user := range s.users {
+			rows = append(rows, map[string]interface{}{
+				"id":             user.ID,
+				"username":       user.Username,
+				"password":       user.Password,
+				"admin":          strconv.FormatBool(user.AccessLevel == "admin"),
+				"moderator":      "false",
+				"banned":         strconv.FormatBool(user.Banned),
+				"priority":       "false",
+				"lastConnection": "",
+				"steamid":        "",
+				"ownerid":        nil,
+				"accesslevel":    user.AccessLevel,
+				"transactionID":  0,
+				"displayName":    nil,
+			})
+		}
+		return sqliteResult{Success: true, ResultSet: rows}, nil
+
+	case "SELECT COUNT(*) FROM whitelist":
+		return sqliteResult{Success: true, ResultSet: []map[string]interface{}{
+			{"COUNT(*)": len(s.users)},
+		}}, nil
+
+	case "UPDATE whitelist SET password = :password WHERE username = :username":
+		username, _ := values["username"].(string)
+		password, _ := values["password"].(string)
+		updated := 0
+		if i := s.findUser(username); i >= 0 {
+			s.users[i].Password = password
+			updated = 1
+		}
+		return sqliteResult{Success: true, RowsUpdated: &updated}, nil
+	}
+
+	return sqliteResult{}, errors.New("unsupported statement: " + sql)
+}
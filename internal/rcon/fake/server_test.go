@@ -0,0 +1,160 @@
+package fake_test
+
+import (
+	"context"
+
+	gorcon "github.com/gorcon/rcon"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/utils/ptr"
+
+	zomboidv1 "github.com/zomboidhost/zomboid-operator/api/v1"
+	"github.com/zomboidhost/zomboid-operator/internal/players"
+	"github.com/zomboidhost/zomboid-operator/internal/rcon/fake"
+	"github.com/zomboidhost/zomboid-operator/internal/settings"
+)
+
+var _ = Describe("Fake Server", func() {
+	var (
+		ctx    context.Context
+		server *fake.Server
+		conn   *gorcon.Conn
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+
+		var err error
+		server, err = fake.NewServer("rcon-password")
+		Expect(err).NotTo(HaveOccurred())
+
+		conn, err = gorcon.Dial(server.RCONAddr(), "rcon-password")
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		conn.Close()
+		server.Close()
+	})
+
+	It("should reject the wrong RCON password", func() {
+		_, err := gorcon.Dial(server.RCONAddr(), "wrong-password")
+		Expect(err).To(MatchError(gorcon.ErrAuthFailed))
+	})
+
+	Context("settings", func() {
+		It("should report its options through showoptions", func() {
+			server.SetOption("PublicName", "Fake Server")
+
+			observed := zomboidv1.ZomboidSettings{}
+			Expect(settings.ReadServerOptions(ctx, conn, &observed)).To(Succeed())
+			Expect(observed.Identity.PublicName).To(Equal(ptr.To("Fake Server")))
+			Expect(observed.Player.MaxPlayers).To(Equal(ptr.To(int32(32))))
+		})
+
+		It("should apply changeoption", func() {
+			observed := zomboidv1.ZomboidSettings{}
+			updates := [][2]string{{"MaxPlayers", "16"}, {"PublicName", "Changed Name"}}
+
+			Expect(settings.ApplySettingsUpdates(ctx, conn, updates, &observed)).To(Succeed())
+			maxPlayers, _ := server.Option("MaxPlayers")
+			Expect(maxPlayers).To(Equal("16"))
+			publicName, _ := server.Option("PublicName")
+			Expect(publicName).To(Equal("Changed Name"))
+			Expect(observed.Player.MaxPlayers).To(Equal(ptr.To(int32(16))))
+		})
+
+		It("should drop connections and count restarts on quit", func() {
+			Expect(settings.RestartServer(ctx, conn)).To(Succeed())
+			Expect(server.Restarts()).To(Equal(1))
+
+			_, err := conn.Execute("players")
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Context("players", func() {
+		It("should list connected players", func() {
+			connected, err := players.GetConnectedPlayers(ctx, conn)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(connected).To(BeEmpty())
+
+			server.ConnectPlayer("alice")
+			server.ConnectPlayer("bob")
+
+			connected, err = players.GetConnectedPlayers(ctx, conn)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(connected).To(Equal([]string{"alice", "bob"}))
+		})
+
+		It("should manage the whitelist", func() {
+			Expect(players.AddUser(ctx, conn, "alice", "alice-password")).To(Succeed())
+			Expect(players.AddUser(ctx, conn, "bob smith", "bob-password")).To(Succeed())
+			Expect(players.SetAccessLevel(ctx, conn, "alice", "admin")).To(Succeed())
+			Expect(players.BanUser(ctx, conn, "bob smith")).To(Succeed())
+
+			alice, ok := server.User("alice")
+			Expect(ok).To(BeTrue())
+			Expect(alice.AccessLevel).To(Equal("admin"))
+
+			bob, ok := server.User("bob smith")
+			Expect(ok).To(BeTrue())
+			Expect(bob.Banned).To(BeTrue())
+
+			Expect(players.UnbanUser(ctx, conn, "bob smith")).To(Succeed())
+			Expect(players.SetAccessLevel(ctx, conn, "alice", "")).To(Succeed())
+			Expect(players.RemoveUser(ctx, conn, "bob smith")).To(Succeed())
+
+			Expect(server.Users()).To(HaveLen(1))
+			alice, _ = server.User("alice")
+			Expect(alice.AccessLevel).To(BeEmpty())
+		})
+	})
+
+	Context("ws4sqlite", func() {
+		var credentials players.SQLiteCredentials
+
+		BeforeEach(func() {
+			credentials = players.SQLiteCredentials{Username: "zomboid-operator", Password: "sqlite-password"}
+			server.RequireSQLiteCredentials(credentials.Username, credentials.Password)
+
+			Expect(players.AddUser(ctx, conn, "alice", "alice-password")).To(Succeed())
+			Expect(players.SetAccessLevel(ctx, conn, "alice", "admin")).To(Succeed())
+			Expect(players.AddUser(ctx, conn, "bob", "bob-password")).To(Succeed())
+			Expect(players.BanUser(ctx, conn, "bob")).To(Succeed())
+		})
+
+		It("should return the allowlist", func() {
+			hostname, port := server.SQLiteEndpoint()
+			allowlist, err := players.GetAllowlist(hostname, port, "test-server", credentials)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(allowlist).To(HaveLen(2))
+			Expect(allowlist[0].Username).To(Equal("alice"))
+			Expect(allowlist[0].AccessLevel).To(Equal("admin"))
+			Expect(allowlist[1].Username).To(Equal("bob"))
+			Expect(allowlist[1].Banned).To(BeTrue())
+
+			count, err := players.GetAllowlistCount(hostname, port, "test-server", credentials)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(count).To(Equal(2))
+		})
+
+		It("should update passwords", func() {
+			hostname, port := server.SQLiteEndpoint()
+			Expect(players.SetPassword(ctx, hostname, port, "test-server", credentials, "bob", "new-password")).To(Succeed())
+
+			bob, _ := server.User("bob")
+			Expect(bob.Password).NotTo(Equal("bob-password"))
+		})
+
+		It("should reject the wrong credentials", func() {
+			hostname, port := server.SQLiteEndpoint()
+			_, err := players.GetAllowlist(hostname, port, "test-server", players.SQLiteCredentials{
+				Username: "zomboid-operator",
+				Password: "wrong-password",
+			})
+			Expect(err).To(MatchError(ContainSubstring("401")))
+		})
+	})
+})
@@ -0,0 +1,72 @@
+package rcon
+
+import (
+	"context"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// Manager keeps one Session per ZomboidServer, so that connections survive
+// between reconciles instead of being dialed each time.
+type Manager struct {
+	mu       sync.Mutex
+	sessions map[types.NamespacedName]*Session
+}
+
+func NewManager() *Manager {
+	return &Manager{
+		sessions: make(map[types.NamespacedName]*Session),
+	}
+}
+
+// Session returns the session for a server, creating it if needed.  If the
+// password has changed since the session was created, the old session is
+// closed and replaced.
+func (m *Manager) Session(key types.NamespacedName, password string, endpoint EndpointFunc) *Session {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if session, ok := m.sessions[key]; ok {
+		if session.password == password {
+			return session
+		}
+		session.Close()
+	}
+
+	session := NewSession(key.String(), password, endpoint)
+	m.sessions[key] = session
+	return session
+}
+
+// Close closes and forgets the session for a server, if there is one.
+func (m *Manager) Close(key types.NamespacedName) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if session, ok := m.sessions[key]; ok {
+		session.Close()
+		delete(m.sessions, key)
+	}
+}
+
+// Start implements manager.Runnable, closing all sessions when the manager
+// shuts down.
+func (m *Manager) Start(ctx context.Context) error {
+	<-ctx.Done()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for key, session := range m.sessions {
+		session.Close()
+		delete(m.sessions, key)
+	}
+	return nil
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable.  Sessions are
+// opened by whichever replica reconciles, so they are closed on every replica.
+func (m *Manager) NeedLeaderElection() bool {
+	return false
+}
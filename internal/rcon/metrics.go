@@ -0,0 +1,33 @@
+package rcon
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+var (
+	connected = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "zomboid_operator_rcon_connected",
+		Help: "Whether the operator holds an open RCON connection to the server",
+	}, []string{"server"})
+
+	reconnectsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "zomboid_operator_rcon_reconnects_total",
+		Help: "Number of times an RCON connection was re-established after failing",
+	}, []string{"server"})
+
+	commandsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "zomboid_operator_rcon_commands_total",
+		Help: "Number of RCON operations run against the server, by result",
+	}, []string{"server", "result"})
+
+	commandDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "zomboid_operator_rcon_command_duration_seconds",
+		Help:    "Time taken by RCON operations against the server",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"server"})
+)
+
+func init() {
+	metrics.Registry.MustRegister(connected, reconnectsTotal, commandsTotal, commandDuration)
+}
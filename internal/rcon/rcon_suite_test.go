@@ -0,0 +1,13 @@
+package rcon_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestRCON(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "RCON Suite")
+}
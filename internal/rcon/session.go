@@ -0,0 +1,215 @@
+package rcon
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"sync"
+	"time"
+
+	gorcon "github.com/gorcon/rcon"
+)
+
+const (
+	DefaultDialTimeout    = 5 * time.Second
+	DefaultCommandTimeout = 5 * time.Second
+
+	initialBackoff = 1 * time.Second
+	maxBackoff     = 1 * time.Minute
+)
+
+// EndpointFunc resolves the address of a server's RCON port.  The returned
+// cleanup function is called once the connection using the address is closed,
+// and can release anything the address depends on, like a port-forward.
+type EndpointFunc func(ctx context.Context) (address string, cleanup func(), err error)
+
+// Session is a long-lived, authenticated RCON connection to a single server.
+// Commands are serialized, since the RCON protocol does not support more than
+// one request in flight per connection.  If the connection breaks it is
+// re-established on the next use, backing off exponentially between failed
+// attempts.
+type Session struct {
+	name     string
+	password string
+	endpoint EndpointFunc
+
+	dialTimeout    time.Duration
+	commandTimeout time.Duration
+
+	mu       sync.Mutex
+	conn     *gorcon.Conn
+	cleanup  func()
+	failures int
+	retryAt  time.Time
+	lastErr  error
+}
+
+// NewSession creates a session for the named server.  No connection is made
+// until the session is first used.
+func NewSession(name, password string, endpoint EndpointFunc) *Session {
+	return &Session{
+		name:           name,
+		password:       password,
+		endpoint:       endpoint,
+		dialTimeout:    DefaultDialTimeout,
+		commandTimeout: DefaultCommandTimeout,
+	}
+}
+
+// Connect makes sure the session is connected, dialing if needed.  While
+// backing off after a failed attempt it returns the last error without
+// dialing.
+func (s *Session) Connect(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.connect(ctx)
+}
+
+// Do runs fn with exclusive use of the session's connection.  Each command
+// fn executes is bound by the session's command timeout.  If fn fails because
+// the connection broke, the connection is dropped so the next use reconnects.
+func (s *Session) Do(ctx context.Context, fn func(client Client) error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.connect(ctx); err != nil {
+		return err
+	}
+
+	start := time.Now()
+	err := fn(s.conn)
+	commandDuration.WithLabelValues(s.name).Observe(time.Since(start).Seconds())
+
+	if err != nil {
+		commandsTotal.WithLabelValues(s.name, "error").Inc()
+		if isConnectionError(err) {
+			s.disconnect(err)
+		}
+		return err
+	}
+
+	commandsTotal.WithLabelValues(s.name, "success").Inc()
+	return nil
+}
+
+// Reset drops the current connection, if any, without backing off.  This is
+// used when the server is known to be going away, like after a restart.
+func (s *Session) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.close()
+	s.failures = 0
+	s.retryAt = time.Time{}
+	s.lastErr = nil
+}
+
+// Connected reports whether the session currently holds a connection, and
+// the error from the last failed connection attempt or command, if any.
+func (s *Session) Connected() (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.conn != nil, s.lastErr
+}
+
+// Close closes the session's connection.
+func (s *Session) Close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.close()
+	connected.DeleteLabelValues(s.name)
+}
+
+func (s *Session) connect(ctx context.Context) error {
+	if s.conn != nil {
+		return nil
+	}
+
+	if wait := time.Until(s.retryAt); wait > 0 {
+		return fmt.Errorf("RCON unavailable, retrying in %s: %w", wait.Round(time.Second), s.lastErr)
+	}
+
+	address, cleanup, err := s.endpoint(ctx)
+	if err != nil {
+		if cleanup != nil {
+			cleanup()
+		}
+		s.fail(err)
+		return err
+	}
+
+	conn, err := gorcon.Dial(
+		address, s.password,
+		gorcon.SetDialTimeout(s.dialTimeout),
+		gorcon.SetDeadline(s.commandTimeout),
+	)
+	if err != nil {
+		if cleanup != nil {
+			cleanup()
+		}
+		err = fmt.Errorf("failed to connect to RCON: %w", err)
+		s.fail(err)
+		return err
+	}
+
+	if s.failures > 0 || s.lastErr != nil {
+		reconnectsTotal.WithLabelValues(s.name).Inc()
+	}
+
+	s.conn = conn
+	s.cleanup = cleanup
+	s.failures = 0
+	s.retryAt = time.Time{}
+	s.lastErr = nil
+	connected.WithLabelValues(s.name).Set(1)
+
+	return nil
+}
+
+func (s *Session) fail(err error) {
+	backoff := initialBackoff << s.failures
+	if backoff <= 0 || backoff > maxBackoff {
+		backoff = maxBackoff
+	} else {
+		s.failures++
+	}
+
+	s.retryAt = time.Now().Add(backoff)
+	s.lastErr = err
+	connected.WithLabelValues(s.name).Set(0)
+}
+
+func (s *Session) disconnect(err error) {
+	s.close()
+	s.lastErr = err
+	connected.WithLabelValues(s.name).Set(0)
+}
+
+func (s *Session) close() {
+	if s.conn != nil {
+		s.conn.Close()
+		s.conn = nil
+	}
+	if s.cleanup != nil {
+		s.cleanup()
+		s.cleanup = nil
+	}
+}
+
+// isConnectionError reports whether err means the connection can no longer
+// be used, as opposed to the server rejecting or failing a single command.
+func isConnectionError(err error) bool {
+	var netErr net.Error
+	return errors.As(err, &netErr) ||
+		errors.Is(err, io.EOF) ||
+		errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, net.ErrClosed) ||
+		errors.Is(err, gorcon.ErrInvalidPacketID) ||
+		errors.Is(err, gorcon.ErrResponseTooSmall) ||
+		errors.Is(err, gorcon.ErrInvalidPacketPadding)
+}
@@ -0,0 +1,184 @@
+package rcon
+
+import (
+	"context"
+	"net"
+	"sync/atomic"
+	"time"
+
+	gorcon "github.com/gorcon/rcon"
+	"github.com/gorcon/rcon/rcontest"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+var _ = Describe("Session", func() {
+	var (
+		ctx    context.Context
+		server *rcontest.Server
+		auths  atomic.Int32
+	)
+
+	staticEndpoint := func(address string) EndpointFunc {
+		return func(ctx context.Context) (string, func(), error) {
+			return address, nil, nil
+		}
+	}
+
+	execute := func(session *Session, command string) (string, error) {
+		var response string
+		err := session.Do(ctx, func(client Client) (err error) {
+			response, err = client.Execute(command)
+			return err
+		})
+		return response, err
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		auths.Store(0)
+
+		server = rcontest.NewServer(
+			rcontest.SetSettings(rcontest.Settings{Password: "secret"}),
+			rcontest.SetAuthHandler(func(c *rcontest.Context) {
+				auths.Add(1)
+				rcontest.AuthHandler(c)
+			}),
+			rcontest.SetCommandHandler(func(c *rcontest.Context) {
+				packet := gorcon.NewPacket(gorcon.SERVERDATA_RESPONSE_VALUE, c.Request().ID, "ok: "+c.Request().Body())
+				_, _ = packet.WriteTo(c.Conn())
+			}),
+		)
+	})
+
+	Context("when the server is reachable", func() {
+		It("should reuse a single authenticated connection across commands", func() {
+			session := NewSession("default/test", "secret", staticEndpoint(server.Addr()))
+
+			for range 3 {
+				response, err := execute(session, "players")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(response).To(Equal("ok: players"))
+			}
+
+			Expect(auths.Load()).To(Equal(int32(1)))
+
+			connected, lastErr := session.Connected()
+			Expect(connected).To(BeTrue())
+			Expect(lastErr).NotTo(HaveOccurred())
+
+			session.Close()
+			server.Close()
+		})
+
+		It("should reconnect after being reset", func() {
+			session := NewSession("default/test", "secret", staticEndpoint(server.Addr()))
+
+			_, err := execute(session, "players")
+			Expect(err).NotTo(HaveOccurred())
+
+			session.Reset()
+			connected, _ := session.Connected()
+			Expect(connected).To(BeFalse())
+
+			_, err = execute(session, "players")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(auths.Load()).To(Equal(int32(2)))
+
+			session.Close()
+			server.Close()
+		})
+
+		It("should release the endpoint when closed", func() {
+			released := false
+			session := NewSession("default/test", "secret", func(ctx context.Context) (string, func(), error) {
+				return server.Addr(), func() { released = true }, nil
+			})
+
+			Expect(session.Connect(ctx)).To(Succeed())
+			Expect(released).To(BeFalse())
+
+			session.Close()
+			Expect(released).To(BeTrue())
+			server.Close()
+		})
+	})
+
+	Context("when the server is unreachable", func() {
+		It("should back off instead of redialing on every use", func() {
+			listener, err := net.Listen("tcp", "127.0.0.1:0")
+			Expect(err).NotTo(HaveOccurred())
+			address := listener.Addr().String()
+			Expect(listener.Close()).To(Succeed())
+			server.Close()
+
+			dials := 0
+			session := NewSession("default/test", "secret", func(ctx context.Context) (string, func(), error) {
+				dials++
+				return address, nil, nil
+			})
+			session.dialTimeout = 100 * time.Millisecond
+
+			Expect(session.Connect(ctx)).NotTo(Succeed())
+			Expect(session.Connect(ctx)).To(MatchError(ContainSubstring("retrying in")))
+			Expect(dials).To(Equal(1))
+
+			connected, lastErr := session.Connected()
+			Expect(connected).To(BeFalse())
+			Expect(lastErr).To(HaveOccurred())
+		})
+	})
+
+	Context("when a command times out", func() {
+		It("should drop the connection so the next use reconnects", func() {
+			server.Settings.CommandResponseDelay = 200 * time.Millisecond
+
+			session := NewSession("default/test", "secret", staticEndpoint(server.Addr()))
+			session.commandTimeout = 50 * time.Millisecond
+
+			_, err := execute(session, "players")
+			Expect(err).To(HaveOccurred())
+
+			connected, lastErr := session.Connected()
+			Expect(connected).To(BeFalse())
+			Expect(lastErr).To(HaveOccurred())
+
+			session.Close()
+			server.Close()
+		})
+	})
+})
+
+var _ = Describe("Manager", func() {
+	endpoint := func(ctx context.Context) (string, func(), error) {
+		return "localhost:27015", nil, nil
+	}
+
+	It("should return the same session for the same server and password", func() {
+		manager := NewManager()
+		key := types.NamespacedName{Namespace: "default", Name: "test"}
+
+		first := manager.Session(key, "secret", endpoint)
+		Expect(manager.Session(key, "secret", endpoint)).To(BeIdenticalTo(first))
+	})
+
+	It("should replace the session when the password changes", func() {
+		manager := NewManager()
+		key := types.NamespacedName{Namespace: "default", Name: "test"}
+
+		first := manager.Session(key, "secret", endpoint)
+		second := manager.Session(key, "rotated", endpoint)
+		Expect(second).NotTo(BeIdenticalTo(first))
+		Expect(second.password).To(Equal("rotated"))
+	})
+
+	It("should forget closed sessions", func() {
+		manager := NewManager()
+		key := types.NamespacedName{Namespace: "default", Name: "test"}
+
+		first := manager.Session(key, "secret", endpoint)
+		manager.Close(key)
+		Expect(manager.Session(key, "secret", endpoint)).NotTo(BeIdenticalTo(first))
+	})
+})
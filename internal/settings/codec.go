@@ -0,0 +1,234 @@
+package settings
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	zomboidv1 "github.com/zomboidhost/zomboid-operator/api/v1"
+)
+
+// ErrUnknownSetting is returned when a setting isn't one the operator manages
+var ErrUnknownSetting = errors.New("unknown setting")
+
+// Setting describes a server setting the operator manages, as declared by the
+// zomboid struct tags on ZomboidSettings
+type Setting struct {
+	// Name is the setting's name in server.ini and RCON
+	Name string
+	// RestartRequired is true when the server only reads the setting on start
+	RestartRequired bool
+
+	// index is the path to the setting's field in ZomboidSettings
+	index []int
+}
+
+var registry, registryByName = buildRegistry()
+
+// buildRegistry walks ZomboidSettings for the fields tagged with a setting name
+func buildRegistry() ([]Setting, map[string]Setting) {
+	var all []Setting
+	byName := map[string]Setting{}
+
+	settingsType := reflect.TypeOf(zomboidv1.ZomboidSettings{})
+	for i := 0; i < settingsType.NumField(); i++ {
+		group := settingsType.Field(i)
+		if group.Type.Kind() != reflect.Struct {
+			continue
+		}
+		for j := 0; j < group.Type.NumField(); j++ {
+			field := group.Type.Field(j)
+			tag, ok := field.Tag.Lookup("zomboid")
+			if !ok {
+				continue
+			}
+
+			name, options, _ := strings.Cut(tag, ",")
+			setting := Setting{
+				Name:            name,
+				RestartRequired: options == "restart",
+				index:           []int{i, j},
+			}
+			if _, ok := byName[name]; ok {
+				panic(fmt.Sprintf("setting %s is declared twice", name))
+			}
+			switch field.Type {
+			case reflect.TypeOf((*bool)(nil)), reflect.TypeOf((*int32)(nil)),
+				reflect.TypeOf((*float32)(nil)), reflect.TypeOf((*string)(nil)):
+			default:
+				panic(fmt.Sprintf("setting %s has unsupported type %s", name, field.Type))
+			}
+
+			all = append(all, setting)
+			byName[name] = setting
+		}
+	}
+
+	return all, byName
+}
+
+// Settings lists every setting the operator manages, in the order they're
+// declared in ZomboidSettings
+func Settings() []Setting {
+	return append([]Setting(nil), registry...)
+}
+
+// LookupSetting returns the setting with the given name
+func LookupSetting(name string) (Setting, bool) {
+	setting, ok := registryByName[name]
+	return setting, ok
+}
+
+// RequiresRestart reports whether the server has to restart to pick up a
+// change to the named setting
+func RequiresRestart(name string) bool {
+	return registryByName[name].RestartRequired
+}
+
+// field returns the setting's field in settings
+func (s Setting) field(settings reflect.Value) reflect.Value {
+	return settings.FieldByIndex(s.index)
+}
+
+// Render returns the setting's value in settings as the server writes it, or
+// "" when it's unset
+func (s Setting) Render(settings zomboidv1.ZomboidSettings) string {
+	return renderValue(s.field(reflect.ValueOf(settings)))
+}
+
+// IsSet reports whether the setting is set in settings
+func (s Setting) IsSet(settings zomboidv1.ZomboidSettings) bool {
+	return !s.field(reflect.ValueOf(settings)).IsNil()
+}
+
+// Default returns the setting's default value as the server writes it
+func (s Setting) Default() string {
+	return s.Render(zomboidv1.DefaultZomboidSettings)
+}
+
+// Parse sets the setting in settings from a value as the server writes it
+func (s Setting) Parse(settings *zomboidv1.ZomboidSettings, value string) error {
+	field := s.field(reflect.ValueOf(settings).Elem())
+
+	var parsed interface{}
+	switch field.Type().Elem().Kind() {
+	case reflect.Bool:
+		switch {
+		case strings.EqualFold(value, "true"):
+			parsed = true
+		case strings.EqualFold(value, "false"):
+			parsed = false
+		default:
+			return fmt.Errorf("invalid value %q for %s: expected true or false", value, s.Name)
+		}
+	case reflect.Int32:
+		i, err := strconv.ParseInt(value, 10, 32)
+		if err != nil {
+			return fmt.Errorf("invalid value %q for %s: expected an integer", value, s.Name)
+		}
+		parsed = int32(i)
+	case reflect.Float32:
+		f, err := strconv.ParseFloat(value, 32)
+		if err != nil {
+			return fmt.Errorf("invalid value %q for %s: expected a number", value, s.Name)
+		}
+		parsed = float32(f)
+	case reflect.String:
+		parsed = strings.ReplaceAll(value, "\\n", "\n")
+	}
+
+	pointer := reflect.New(field.Type().Elem())
+	pointer.Elem().Set(reflect.ValueOf(parsed))
+	field.Set(pointer)
+	return nil
+}
+
+// Clear unsets the setting in settings
+func (s Setting) Clear(settings *zomboidv1.ZomboidSettings) {
+	s.field(reflect.ValueOf(settings).Elem()).SetZero()
+}
+
+// renderValue formats a setting's field the way the server writes it to
+// server.ini.  Floats always keep a decimal point, and newlines in strings are
+// escaped so that every value fits on one line.
+func renderValue(field reflect.Value) string {
+	if field.IsNil() {
+		return ""
+	}
+
+	switch value := field.Elem().Interface().(type) {
+	case bool:
+		return strconv.FormatBool(value)
+	case int32:
+		return strconv.FormatInt(int64(value), 10)
+	case float32:
+		formatted := strconv.FormatFloat(float64(value), 'f', -1, 32)
+		if !strings.Contains(formatted, ".") {
+			formatted += ".0"
+		}
+		return formatted
+	case string:
+		return strings.ReplaceAll(value, "\n", "\\n")
+	}
+	return ""
+}
+
+// ParseSettingValue sets the named setting in settings from a value as the
+// server writes it.  It returns ErrUnknownSetting for settings the operator
+// doesn't manage.
+func ParseSettingValue(settings *zomboidv1.ZomboidSettings, key, value string) error {
+	setting, ok := LookupSetting(key)
+	if !ok {
+		return fmt.Errorf("%w %s", ErrUnknownSetting, key)
+	}
+	return setting.Parse(settings, value)
+}
+
+// SettingsDiff compares current and desired settings, returning a list of settings that need to be updated
+// Each returned pair contains the setting name and its new value as strings.  Settings desired leaves
+// unset are compared against their defaults, followed by desired's extra settings in name order.
+func SettingsDiff(current, desired zomboidv1.ZomboidSettings) [][2]string {
+	var updates [][2]string
+	for _, setting := range registry {
+		desiredValue := setting.Default()
+		if setting.IsSet(desired) {
+			desiredValue = setting.Render(desired)
+		}
+		if setting.Render(current) != desiredValue {
+			updates = append(updates, [2]string{setting.Name, desiredValue})
+		}
+	}
+
+	for _, name := range extraNames(desired) {
+		if currentValue, ok := current.Extra[name]; !ok || currentValue != desired.Extra[name] {
+			updates = append(updates, [2]string{name, desired.Extra[name]})
+		}
+	}
+	return updates
+}
+
+// extraNames returns the names of the extra settings in settings, in order
+func extraNames(settings zomboidv1.ZomboidSettings) []string {
+	names := make([]string, 0, len(settings.Extra))
+	for name := range settings.Extra {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// setValue sets the named setting in settings from a value as the server
+// writes it, keeping settings the operator doesn't manage in Extra
+func setValue(settings *zomboidv1.ZomboidSettings, key, value string) error {
+	if _, ok := LookupSetting(key); ok {
+		return ParseSettingValue(settings, key, value)
+	}
+	if settings.Extra == nil {
+		settings.Extra = map[string]string{}
+	}
+	settings.Extra[key] = value
+	return nil
+}
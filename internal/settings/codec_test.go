@@ -0,0 +1,102 @@
+package settings
+
+import (
+	"reflect"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/utils/ptr"
+
+	zomboidv1 "github.com/zomboidhost/zomboid-operator/api/v1"
+)
+
+var _ = Describe("Settings Codec", func() {
+	// sampleValues returns values of a setting's type as the server writes them
+	sampleValues := func(setting Setting) []string {
+		field := reflect.TypeOf(zomboidv1.ZomboidSettings{}).FieldByIndex(setting.index)
+		switch field.Type.Elem().Kind() {
+		case reflect.Bool:
+			return []string{"true", "false"}
+		case reflect.Int32:
+			return []string{"0", "-1", "2147483647"}
+		case reflect.Float32:
+			return []string{"0.0", "0.05", "1.5", "10.0", "123.25"}
+		default:
+			return []string{"", "Some value", "First line\\nSecond line"}
+		}
+	}
+
+	It("should declare every field of every settings group", func() {
+		settingsType := reflect.TypeOf(zomboidv1.ZomboidSettings{})
+		fields := 0
+		for i := 0; i < settingsType.NumField(); i++ {
+			if group := settingsType.Field(i).Type; group.Kind() == reflect.Struct {
+				fields += group.NumField()
+			}
+		}
+		Expect(Settings()).To(HaveLen(fields))
+	})
+
+	It("should round-trip every setting", func() {
+		for _, setting := range Settings() {
+			for _, value := range sampleValues(setting) {
+				var settings zomboidv1.ZomboidSettings
+				Expect(ParseSettingValue(&settings, setting.Name, value)).To(Succeed(), setting.Name)
+				Expect(setting.IsSet(settings)).To(BeTrue(), setting.Name)
+				Expect(setting.Render(settings)).To(Equal(value), setting.Name)
+
+				reparsed := zomboidv1.ZomboidSettings{}
+				Expect(setting.Parse(&reparsed, setting.Render(settings))).To(Succeed(), setting.Name)
+				Expect(reparsed).To(Equal(settings), setting.Name)
+			}
+		}
+	})
+
+	It("should take every default from DefaultZomboidSettings", func() {
+		maxPlayers, _ := LookupSetting("MaxPlayers")
+		Expect(maxPlayers.Default()).To(Equal("32"))
+		voiceMinDistance, _ := LookupSetting("VoiceMinDistance")
+		Expect(voiceMinDistance.Default()).To(Equal("10.0"))
+
+		// A server running with the defaults is in sync with an empty spec
+		Expect(SettingsDiff(zomboidv1.DefaultZomboidSettings, zomboidv1.ZomboidSettings{})).To(BeEmpty())
+	})
+
+	It("should format floats exactly", func() {
+		settings := zomboidv1.ZomboidSettings{}
+		settings.Gameplay.MinutesPerPage = ptr.To(float32(0.05))
+		settings.Communication.VoiceMinDistance = ptr.To(float32(10))
+		settings.Communication.VoiceMaxDistance = ptr.To(float32(1.25))
+
+		Expect(SettingsDiff(zomboidv1.ZomboidSettings{}, settings)).To(ContainElements(
+			[2]string{"MinutesPerPage", "0.05"},
+			[2]string{"VoiceMinDistance", "10.0"},
+			[2]string{"VoiceMaxDistance", "1.25"},
+		))
+	})
+
+	It("should reject unknown settings and malformed values", func() {
+		var settings zomboidv1.ZomboidSettings
+		Expect(ParseSettingValue(&settings, "NotASetting", "1")).To(MatchError(ErrUnknownSetting))
+		Expect(ParseSettingValue(&settings, "MaxPlayers", "many")).To(HaveOccurred())
+		Expect(ParseSettingValue(&settings, "Open", "yes")).To(HaveOccurred())
+		Expect(ParseSettingValue(&settings, "MinutesPerPage", "")).To(HaveOccurred())
+		Expect(settings).To(Equal(zomboidv1.ZomboidSettings{}))
+
+		Expect(ParseSettingValue(&settings, "Open", "TRUE")).To(Succeed())
+		Expect(settings.Player.Open).To(Equal(ptr.To(true)))
+	})
+
+	It("should only restart the server for settings it reads on start", func() {
+		Expect(RequiresRestart("Mods")).To(BeTrue())
+		Expect(RequiresRestart("WorkshopItems")).To(BeTrue())
+		Expect(RequiresRestart("Map")).To(BeTrue())
+		Expect(RequiresRestart("Public")).To(BeTrue())
+		Expect(RequiresRestart("MaxPlayers")).To(BeTrue())
+		Expect(RequiresRestart("VoiceEnable")).To(BeTrue())
+		Expect(RequiresRestart("AntiCheatProtectionType1")).To(BeTrue())
+		Expect(RequiresRestart("PingLimit")).To(BeFalse())
+		Expect(RequiresRestart("PVP")).To(BeFalse())
+		Expect(RequiresRestart("NotASetting")).To(BeFalse())
+	})
+})
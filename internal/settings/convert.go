@@ -0,0 +1,260 @@
+package settings
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	zomboidv1 "github.com/zomboidhost/zomboid-operator/api/v1"
+)
+
+// ConvertOptions configures how Convert builds a ZomboidServer
+type ConvertOptions struct {
+	// Name and Namespace are the ZomboidServer's
+	Name      string
+	Namespace string
+
+	// Version is the version of the game to run
+	Version string
+
+	// Resources are the compute resources of the server
+	Resources corev1.ResourceRequirements
+
+	// Storage is the size of the server's game data volume
+	Storage resource.Quantity
+
+	// Secrets generates a Secret holding the passwords and tokens found in
+	// server.ini.  Otherwise the ZomboidServer refers to a Secret that has to
+	// be created separately.
+	Secrets bool
+}
+
+// Conversion is a ZomboidServer converted from an existing server's
+// configuration files
+type Conversion struct {
+	Server *zomboidv1.ZomboidServer
+
+	// Secrets are the Secrets the ZomboidServer refers to.  The administrator's
+	// password isn't in server.ini, so its Secret is never generated.
+	Secrets []*corev1.Secret
+
+	// Unknown lists the server.ini settings the operator doesn't model, which
+	// are kept in spec.settings.extra
+	Unknown []string
+
+	// Notes explain everything else that needs attention before the
+	// ZomboidServer is applied
+	Notes []string
+}
+
+// secretSettings are the server.ini settings holding passwords and tokens,
+// mapped to their keys in the generated Secret
+var secretSettings = map[string]string{
+	"Password":         "password",
+	"RCONPassword":     "rcon-password",
+	"DiscordToken":     "discord-token",
+	"DiscordChannel":   "discord-channel",
+	"DiscordChannelID": "discord-channel-id",
+}
+
+// ignoredSettings are the server.ini settings the operator chooses itself
+var ignoredSettings = map[string]string{
+	"RCONPort":      "the operator chooses the RCON port",
+	"DiscordEnable": "Discord is enabled by setting spec.discord",
+}
+
+// Convert builds a ZomboidServer from an existing server's server.ini.
+// Settings with their default values are left out of the spec.
+func Convert(serverINI io.Reader, options ConvertOptions) (*Conversion, error) {
+	values, err := ReadServerINI(serverINI)
+	if err != nil {
+		return nil, err
+	}
+
+	server := &zomboidv1.ZomboidServer{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: zomboidv1.GroupVersion.String(),
+			Kind:       "ZomboidServer",
+		},
+		ObjectMeta: metav1.ObjectMeta{Name: options.Name, Namespace: options.Namespace},
+		Spec: zomboidv1.ZomboidServerSpec{
+			Version:   options.Version,
+			Resources: options.Resources,
+			Storage:   zomboidv1.Storage{Request: options.Storage},
+			Administrator: zomboidv1.Administrator{
+				Username: "admin",
+				Password: corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: options.Name + "-admin"},
+					Key:                  "password",
+				},
+			},
+		},
+	}
+	conversion := &Conversion{
+		Server: server,
+		Notes:  []string{fmt.Sprintf("Create the Secret %s-admin with the administrator's password in its password key", options.Name)},
+	}
+
+	secretName := options.Name + "-passwords"
+	secret := &corev1.Secret{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Secret"},
+		ObjectMeta: metav1.ObjectMeta{Name: secretName, Namespace: options.Namespace},
+		StringData: map[string]string{},
+	}
+	secretRef := func(key string) *corev1.SecretKeySelector {
+		return &corev1.SecretKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{Name: secretName},
+			Key:                  key,
+		}
+	}
+
+	settings := &server.Spec.Settings
+	for _, value := range values {
+		name := value[0]
+
+		if key, ok := secretSettings[name]; ok {
+			if value[1] == "" {
+				continue
+			}
+			secret.StringData[key] = value[1]
+
+			switch name {
+			case "Password":
+				server.Spec.Password = secretRef(key)
+			case "RCONPassword":
+				server.Spec.RCON = &zomboidv1.RCON{Password: secretRef(key)}
+			default:
+				if server.Spec.Discord == nil {
+					server.Spec.Discord = &zomboidv1.Discord{}
+				}
+				switch name {
+				case "DiscordToken":
+					server.Spec.Discord.DiscordToken = secretRef(key)
+				case "DiscordChannel":
+					server.Spec.Discord.DiscordChannel = secretRef(key)
+				case "DiscordChannelID":
+					server.Spec.Discord.DiscordChannelID = secretRef(key)
+				}
+			}
+			continue
+		}
+
+		if reason, ok := ignoredSettings[name]; ok {
+			conversion.Notes = append(conversion.Notes, fmt.Sprintf("Ignored %s, as %s", name, reason))
+			continue
+		}
+
+		if name == "DefaultPort" || name == "UDPPort" {
+			port, err := strconv.ParseInt(value[1], 10, 32)
+			if err != nil {
+				conversion.Notes = append(conversion.Notes, fmt.Sprintf("Ignored %s, as %q isn't a port", name, value[1]))
+				continue
+			}
+			if name == "DefaultPort" {
+				server.Spec.ServerPort = ptr.To(int32(port))
+			} else {
+				server.Spec.UDPPort = ptr.To(int32(port))
+			}
+			continue
+		}
+
+		setting, ok := LookupSetting(name)
+		if !ok {
+			conversion.Unknown = append(conversion.Unknown, name)
+			if settings.Extra == nil {
+				settings.Extra = map[string]string{}
+			}
+			settings.Extra[name] = value[1]
+			continue
+		}
+
+		if err := setting.Parse(settings, value[1]); err != nil {
+			conversion.Notes = append(conversion.Notes, fmt.Sprintf("Ignored %s: %v", name, err))
+			continue
+		}
+	}
+
+	// Leave out the settings the server would have anyway
+	for _, setting := range Settings() {
+		if setting.IsSet(*settings) && setting.Render(*settings) == setting.Default() {
+			setting.Clear(settings)
+		}
+	}
+
+	conversion.Notes = append(conversion.Notes, convertWorkshopMods(settings)...)
+	convertMaps(settings)
+
+	if len(secret.StringData) > 0 {
+		if options.Secrets {
+			conversion.Secrets = append(conversion.Secrets, secret)
+		} else {
+			var keys []string
+			for key := range secret.StringData {
+				keys = append(keys, key)
+			}
+			sort.Strings(keys)
+			conversion.Notes = append(conversion.Notes, fmt.Sprintf("Create the Secret %s with the passwords from server.ini in its %s keys",
+				secretName, strings.Join(keys, ", ")))
+		}
+	}
+
+	return conversion, nil
+}
+
+// convertWorkshopMods replaces the classic mod lists with structured
+// workshop mods when every mod pairs up with a workshop item
+func convertWorkshopMods(settings *zomboidv1.ZomboidSettings) []string {
+	if settings.Mods.Mods == nil || settings.Mods.WorkshopItems == nil {
+		return nil
+	}
+
+	modIDs := splitList(*settings.Mods.Mods)
+	workshopIDs := splitList(*settings.Mods.WorkshopItems)
+	if len(modIDs) != len(workshopIDs) {
+		return []string{fmt.Sprintf("Kept the classic Mods and WorkshopItems lists, as their %d mods and %d workshop items don't pair up",
+			len(modIDs), len(workshopIDs))}
+	}
+
+	for i := range modIDs {
+		settings.WorkshopMods = append(settings.WorkshopMods, zomboidv1.WorkshopMod{
+			ModID:      ptr.To(modIDs[i]),
+			WorkshopID: ptr.To(workshopIDs[i]),
+		})
+	}
+	settings.Mods.Mods = nil
+	settings.Mods.WorkshopItems = nil
+	return nil
+}
+
+// convertMaps replaces a Map setting listing map mods before the vanilla map
+// with structured maps, which are always loaded before it
+func convertMaps(settings *zomboidv1.ZomboidSettings) {
+	if settings.Map.Map == nil {
+		return
+	}
+
+	maps := splitList(*settings.Map.Map)
+	if len(maps) < 2 || maps[len(maps)-1] != VanillaMap {
+		return
+	}
+	settings.Maps = maps[:len(maps)-1]
+	settings.Map.Map = nil
+}
+
+// splitList splits a semicolon-separated server.ini list, dropping empty items
+func splitList(list string) []string {
+	var items []string
+	for _, item := range strings.Split(list, ";") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
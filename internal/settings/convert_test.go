@@ -0,0 +1,129 @@
+package settings
+
+import (
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/utils/ptr"
+
+	zomboidv1 "github.com/zomboidhost/zomboid-operator/api/v1"
+)
+
+const sampleServerINI = `# Players can hurt and kill other players
+PVP=false
+PauseEmpty=true
+GlobalChat=true
+Open=false
+ServerWelcomeMessage=Welcome to our server!<LINE>Be nice.
+MaxPlayers=16
+PingLimit=400
+Public=true
+PublicName=Knox Survivors
+Password=hunter2
+RCONPort=27015
+RCONPassword=rcon-secret
+DefaultPort=16261
+UDPPort=16262
+Mods=Hydrocraft;BetterSorting
+WorkshopItems=498441420;2313387159
+Map=Muldraugh, KY
+DiscordEnable=false
+DiscordToken=
+UltraHardcoreMode=true
+`
+
+var _ = Describe("Converting server.ini", func() {
+	var options ConvertOptions
+
+	BeforeEach(func() {
+		options = ConvertOptions{
+			Name:      "knox",
+			Namespace: "games",
+			Version:   "latest",
+			Storage:   resource.MustParse("10Gi"),
+		}
+	})
+
+	It("should only keep settings that differ from their defaults", func() {
+		conversion, err := Convert(strings.NewReader(sampleServerINI), options)
+		Expect(err).NotTo(HaveOccurred())
+
+		settings := conversion.Server.Spec.Settings
+		Expect(settings.Player.MaxPlayers).To(Equal(ptr.To(int32(16))))
+		Expect(settings.Identity.PublicName).To(Equal(ptr.To("Knox Survivors")))
+		Expect(settings.Player.PingLimit).To(BeNil())
+		Expect(settings.Map.Map).To(BeNil())
+		Expect(conversion.Server.Spec.ServerPort).To(Equal(ptr.To(int32(16261))))
+	})
+
+	It("should map the mod lists to structured workshop mods", func() {
+		conversion, err := Convert(strings.NewReader(sampleServerINI), options)
+		Expect(err).NotTo(HaveOccurred())
+
+		settings := conversion.Server.Spec.Settings
+		Expect(settings.WorkshopMods).To(Equal([]zomboidv1.WorkshopMod{
+			{ModID: ptr.To("Hydrocraft"), WorkshopID: ptr.To("498441420")},
+			{ModID: ptr.To("BetterSorting"), WorkshopID: ptr.To("2313387159")},
+		}))
+		Expect(settings.Mods.Mods).To(BeNil())
+		Expect(settings.Mods.WorkshopItems).To(BeNil())
+	})
+
+	It("should keep mod lists that don't pair up", func() {
+		ini := strings.Replace(sampleServerINI, "Mods=Hydrocraft;BetterSorting", "Mods=Hydrocraft;HydrocraftTweaks;BetterSorting", 1)
+		conversion, err := Convert(strings.NewReader(ini), options)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(conversion.Server.Spec.Settings.WorkshopMods).To(BeEmpty())
+		Expect(conversion.Server.Spec.Settings.Mods.Mods).To(Equal(ptr.To("Hydrocraft;HydrocraftTweaks;BetterSorting")))
+		Expect(conversion.Notes).To(ContainElement(ContainSubstring("don't pair up")))
+	})
+
+	It("should map the maps loaded before the vanilla map to structured maps", func() {
+		ini := strings.Replace(sampleServerINI, "Map=Muldraugh, KY", "Map=Bedford Falls;RavenCreek;Muldraugh, KY", 1)
+		conversion, err := Convert(strings.NewReader(ini), options)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(conversion.Server.Spec.Settings.Maps).To(Equal([]string{"Bedford Falls", "RavenCreek"}))
+		Expect(conversion.Server.Spec.Settings.Map.Map).To(BeNil())
+	})
+
+	It("should keep unknown settings as extra settings", func() {
+		conversion, err := Convert(strings.NewReader(sampleServerINI), options)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(conversion.Unknown).To(Equal([]string{"UltraHardcoreMode"}))
+		Expect(conversion.Server.Spec.Settings.Extra).To(Equal(map[string]string{"UltraHardcoreMode": "true"}))
+		Expect(conversion.Notes).To(ContainElement(ContainSubstring("Ignored RCONPort")))
+	})
+
+	It("should only generate Secrets for passwords when asked", func() {
+		conversion, err := Convert(strings.NewReader(sampleServerINI), options)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(conversion.Secrets).To(BeEmpty())
+		Expect(conversion.Notes).To(ContainElement("Create the Secret knox-passwords with the passwords from server.ini in its password, rcon-password keys"))
+		Expect(conversion.Server.Spec.Password).To(Equal(&corev1.SecretKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{Name: "knox-passwords"},
+			Key:                  "password",
+		}))
+		Expect(conversion.Server.Spec.Discord).To(BeNil())
+
+		options.Secrets = true
+		conversion, err = Convert(strings.NewReader(sampleServerINI), options)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(conversion.Secrets).To(HaveLen(1))
+		Expect(conversion.Secrets[0].StringData).To(Equal(map[string]string{
+			"password":      "hunter2",
+			"rcon-password": "rcon-secret",
+		}))
+		Expect(conversion.Server.Spec.RCON.Password.Key).To(Equal("rcon-password"))
+	})
+
+	It("should reject malformed files", func() {
+		_, err := Convert(strings.NewReader("MaxPlayers 16\n"), options)
+		Expect(err).To(MatchError(ContainSubstring("line 1")))
+	})
+})
@@ -145,6 +145,18 @@ var _ = Describe("Settings Diff", func() {
 		})
 	})
 
+	Context("when comparing extra settings", func() {
+		It("should only change the extra settings desired sets", func() {
+			current.Extra = map[string]string{"UltraHardcoreMode": "false", "ServerNews": "Hello", "Unmanaged": "1"}
+			desired.Extra = map[string]string{"UltraHardcoreMode": "true", "ServerNews": "Hello", "NewSetting": ""}
+
+			Expect(SettingsDiff(current, desired)).To(Equal([][2]string{
+				{"NewSetting", ""},
+				{"UltraHardcoreMode", "true"},
+			}))
+		})
+	})
+
 	Context("when comparing PVP settings", func() {
 		It("should detect changes in damage modifiers", func() {
 			current.PVP.PVPMeleeDamageModifier = ptr.To(float32(30.0))
@@ -501,4 +513,36 @@ var _ = Describe("Settings Diff", func() {
 			Expect(diff).NotTo(ContainElement(ContainElement("VoiceMaxDistance")))
 		})
 	})
+
+	Context("when computing pending updates", func() {
+		It("should keep the IDs the server picked", func() {
+			current.Identity.ResetID = ptr.To(int32(123))
+			current.Identity.ServerPlayerID = ptr.To(int32(456))
+
+			Expect(PendingUpdates(desired, current)).To(BeEmpty())
+		})
+
+		It("should include structured workshop mods", func() {
+			desired.WorkshopMods = []zomboidv1.WorkshopMod{{
+				ModID:      ptr.To("mod1"),
+				WorkshopID: ptr.To("123456"),
+			}}
+
+			Expect(PendingUpdates(desired, current)).To(ConsistOf(
+				[2]string{"Mods", "mod1"},
+				[2]string{"WorkshopItems", "123456"},
+			))
+		})
+	})
+
+	Context("when listing values", func() {
+		It("should list set values and defaults", func() {
+			values := Values(zomboidv1.ZomboidSettings{
+				Player: zomboidv1.Player{MaxPlayers: ptr.To(int32(12))},
+			})
+
+			Expect(values).To(ContainElement([2]string{"MaxPlayers", "12"}))
+			Expect(values).To(ContainElement([2]string{"PVP", "true"}))
+		})
+	})
 })
@@ -0,0 +1,53 @@
+package settings
+
+import (
+	"bytes"
+	"fmt"
+
+	zomboidv1 "github.com/zomboidhost/zomboid-operator/api/v1"
+)
+
+// Effective returns the settings a server runs with once the operator has
+// made its changes: the observed settings, or the defaults before any are
+// observed, with the updates its settings policy calls for applied
+func Effective(policy zomboidv1.SettingsPolicy, spec zomboidv1.ZomboidSettings, observed *zomboidv1.ZomboidSettings) zomboidv1.ZomboidSettings {
+	effective := *zomboidv1.DefaultZomboidSettings.DeepCopy()
+	if observed != nil {
+		effective = AdoptUnset(*observed, zomboidv1.DefaultZomboidSettings)
+	}
+
+	for _, update := range ManagedUpdates(policy, spec, effective) {
+		// Every update is rendered by the codec, so it always parses
+		_ = setValue(&effective, update[0], update[1])
+	}
+	return effective
+}
+
+// ExportServerINI renders a server's effective settings as a server.ini the
+// game reads: its ports, then every setting in the order ZomboidSettings
+// declares them, then its extra settings by name.  Passwords and tokens are
+// kept in Secrets, so they're left out.
+func ExportServerINI(zomboidServer *zomboidv1.ZomboidServer) []byte {
+	effective := Effective(zomboidServer.Spec.SettingsPolicy, Desired(zomboidServer), zomboidServer.Status.Settings)
+
+	var b bytes.Buffer
+	serverPort, udpPort := int32(16261), int32(16262)
+	if zomboidServer.Spec.ServerPort != nil {
+		serverPort = *zomboidServer.Spec.ServerPort
+	}
+	if zomboidServer.Spec.UDPPort != nil {
+		udpPort = *zomboidServer.Spec.UDPPort
+	}
+	fmt.Fprintf(&b, "DefaultPort=%d\n", serverPort)
+	fmt.Fprintf(&b, "UDPPort=%d\n", udpPort)
+
+	for _, setting := range registry {
+		fmt.Fprintf(&b, "%s=%s\n", setting.Name, setting.Render(effective))
+	}
+
+	for _, name := range extraNames(effective) {
+		fmt.Fprintf(&b, "%s=%s\n", name, effective.Extra[name])
+	}
+
+	return b.Bytes()
+}
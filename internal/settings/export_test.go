@@ -0,0 +1,69 @@
+package settings
+
+import (
+	"bytes"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/utils/ptr"
+
+	zomboidv1 "github.com/zomboidhost/zomboid-operator/api/v1"
+)
+
+var _ = Describe("Exporting server.ini", func() {
+	var zomboidServer *zomboidv1.ZomboidServer
+
+	BeforeEach(func() {
+		zomboidServer = &zomboidv1.ZomboidServer{}
+		zomboidServer.Name = "knox"
+		zomboidServer.Spec.Settings.Player.MaxPlayers = ptr.To(int32(12))
+		zomboidServer.Spec.Settings.Gameplay.MinutesPerPage = ptr.To(float32(0.05))
+		zomboidServer.Spec.Settings.Communication.ServerWelcomeMessage = ptr.To("Welcome\nBe nice")
+		zomboidServer.Spec.Settings.WorkshopMods = []zomboidv1.WorkshopMod{
+			{ModID: ptr.To("Hydrocraft"), WorkshopID: ptr.To("498441420")},
+		}
+		zomboidServer.Spec.Settings.Extra = map[string]string{"UltraHardcoreMode": "true"}
+	})
+
+	It("should render every setting exactly", func() {
+		ini := string(ExportServerINI(zomboidServer))
+		Expect(ini).To(HavePrefix("DefaultPort=16261\nUDPPort=16262\nPublic=false\nPublicName=My PZ Server\nPublicDescription=\n"))
+		Expect(ini).To(ContainSubstring("\nMaxPlayers=12\n"))
+		Expect(ini).To(ContainSubstring("\nMinutesPerPage=0.05\n"))
+		Expect(ini).To(ContainSubstring("\nServerWelcomeMessage=Welcome\\nBe nice\n"))
+		Expect(ini).To(ContainSubstring("\nMods=Hydrocraft\n"))
+		Expect(ini).To(ContainSubstring("\nWorkshopItems=498441420\n"))
+		Expect(ini).To(HaveSuffix("\nUltraHardcoreMode=true\n"))
+
+		values, err := ReadServerINI(bytes.NewReader(ExportServerINI(zomboidServer)))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(values).To(HaveLen(2 + len(Settings()) + 1))
+	})
+
+	It("should convert back into the same settings", func() {
+		conversion, err := Convert(bytes.NewReader(ExportServerINI(zomboidServer)), ConvertOptions{
+			Name:    "knox",
+			Storage: resource.MustParse("10Gi"),
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(conversion.Server.Spec.Settings).To(Equal(zomboidServer.Spec.Settings))
+	})
+
+	It("should start from the observed settings", func() {
+		observed := *zomboidv1.DefaultZomboidSettings.DeepCopy()
+		observed.Identity.PublicName = ptr.To("In-game Name")
+		observed.Player.MaxPlayers = ptr.To(int32(20))
+		zomboidServer.Status.Settings = &observed
+
+		Expect(string(ExportServerINI(zomboidServer))).To(ContainSubstring("\nPublicName=My PZ Server\n"))
+
+		zomboidServer.Spec.SettingsPolicy = zomboidv1.SettingsPolicyAdopt
+		ini := string(ExportServerINI(zomboidServer))
+		Expect(ini).To(ContainSubstring("\nPublicName=In-game Name\n"))
+		Expect(ini).To(ContainSubstring("\nMaxPlayers=12\n"))
+
+		zomboidServer.Spec.SettingsPolicy = zomboidv1.SettingsPolicyObserveOnly
+		Expect(string(ExportServerINI(zomboidServer))).To(ContainSubstring("\nMaxPlayers=20\n"))
+	})
+})
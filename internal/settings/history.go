@@ -0,0 +1,173 @@
+package settings
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	zomboidv1 "github.com/zomboidhost/zomboid-operator/api/v1"
+)
+
+// HistoryKey is the key of the settings history in its ConfigMap
+const HistoryKey = "history.json"
+
+// HistoryLimit is how many batches of changes a server's history keeps
+const HistoryLimit = 50
+
+// HistoryEntry is a batch of settings the operator changed on a server
+type HistoryEntry struct {
+	// Time is when the operator applied the changes
+	Time metav1.Time `json:"time"`
+
+	// Generation is the ZomboidServer's generation the changes were made for
+	Generation int64 `json:"generation"`
+
+	// Manager is the field manager that last changed the ZomboidServer's
+	// spec, such as kubectl-client-side-apply or kubectl-zomboid.  It's only
+	// recorded when a change in the spec caused some of the changes.
+	Manager string `json:"manager,omitempty"`
+
+	// Changes are the settings changed, in the order they were applied
+	Changes []HistoryChange `json:"changes"`
+}
+
+// HistoryChange is a single setting changed on a server
+type HistoryChange struct {
+	Name   string       `json:"name"`
+	Before string       `json:"before"`
+	After  string       `json:"after"`
+	Cause  HistoryCause `json:"cause,omitempty"`
+}
+
+// HistoryCause is why the operator changed a setting
+type HistoryCause string
+
+const (
+	// CauseSpec is a change to the setting in the ZomboidServer's spec
+	CauseSpec HistoryCause = "Spec"
+	// CauseProfile is a change to the setting in a settings profile
+	CauseProfile HistoryCause = "Profile"
+	// CauseDriftRevert puts back a value the operator had already applied,
+	// after the setting was changed on the server itself
+	CauseDriftRevert HistoryCause = "DriftRevert"
+)
+
+// HistoryConfigMapName returns the name of the ConfigMap holding a server's
+// settings history
+func HistoryConfigMapName(serverName string) string {
+	return serverName + "-settings-history"
+}
+
+// NewHistoryEntry records the updates applied to a server whose settings
+// were observed as observed beforehand.  A change is a drift revert when
+// the server's history shows the operator already applied the same value,
+// and otherwise comes from the spec, or from a profile when the spec leaves
+// the setting unset.
+func NewHistoryEntry(zomboidServer *zomboidv1.ZomboidServer, updates [][2]string, observed [][2]string, history []HistoryEntry) HistoryEntry {
+	before := map[string]string{}
+	for _, value := range observed {
+		before[value[0]] = value[1]
+	}
+	applied := map[string]string{}
+	for _, entry := range history {
+		for _, change := range entry.Changes {
+			applied[change.Name] = change.After
+		}
+	}
+	spec := *zomboidServer.Spec.Settings.DeepCopy()
+	MergeWorkshopMods(&spec)
+	MergeMaps(&spec)
+	inSpec := func(name string) bool {
+		if setting, ok := LookupSetting(name); ok {
+			return setting.IsSet(spec)
+		}
+		_, ok := spec.Extra[name]
+		return ok
+	}
+
+	entry := HistoryEntry{
+		Time:       metav1.Now(),
+		Generation: zomboidServer.Generation,
+	}
+	for _, update := range updates {
+		change := HistoryChange{Name: update[0], Before: before[update[0]], After: update[1], Cause: CauseSpec}
+		if value, ok := applied[update[0]]; ok && value == update[1] {
+			change.Cause = CauseDriftRevert
+		} else if len(zomboidServer.Spec.SettingsFrom) > 0 && !inSpec(update[0]) {
+			change.Cause = CauseProfile
+		}
+		if change.Cause == CauseSpec {
+			entry.Manager = SpecManager(zomboidServer)
+		}
+		entry.Changes = append(entry.Changes, change)
+	}
+	return entry
+}
+
+// ReadHistory returns the history kept in configMap, oldest first
+func ReadHistory(configMap *corev1.ConfigMap) ([]HistoryEntry, error) {
+	data, ok := configMap.Data[HistoryKey]
+	if !ok || data == "" {
+		return nil, nil
+	}
+
+	var history []HistoryEntry
+	if err := json.Unmarshal([]byte(data), &history); err != nil {
+		return nil, fmt.Errorf("failed to read the settings history in %s: %w", configMap.Name, err)
+	}
+	return history, nil
+}
+
+// AppendHistory adds entry to the history kept in configMap, dropping the
+// oldest entries beyond HistoryLimit.  A history that can't be read is
+// started over rather than blocking new entries.
+func AppendHistory(configMap *corev1.ConfigMap, entry HistoryEntry) error {
+	history, err := ReadHistory(configMap)
+	if err != nil {
+		history = nil
+	}
+
+	history = append(history, entry)
+	if len(history) > HistoryLimit {
+		history = history[len(history)-HistoryLimit:]
+	}
+
+	data, err := json.Marshal(history)
+	if err != nil {
+		return err
+	}
+	if configMap.Data == nil {
+		configMap.Data = map[string]string{}
+	}
+	configMap.Data[HistoryKey] = string(data)
+	return nil
+}
+
+// SpecManager returns the field manager that most recently changed obj's
+// spec, according to its managed fields, or "" if none is recorded
+func SpecManager(obj metav1.Object) string {
+	var manager string
+	var latest time.Time
+	for _, entry := range obj.GetManagedFields() {
+		if entry.Subresource != "" || entry.FieldsV1 == nil || entry.Time == nil {
+			continue
+		}
+
+		var fields map[string]json.RawMessage
+		if err := json.Unmarshal(entry.FieldsV1.Raw, &fields); err != nil {
+			continue
+		}
+		if _, ok := fields["f:spec"]; !ok {
+			continue
+		}
+
+		if manager == "" || entry.Time.After(latest) {
+			manager = entry.Manager
+			latest = entry.Time.Time
+		}
+	}
+	return manager
+}
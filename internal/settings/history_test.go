@@ -0,0 +1,96 @@
+package settings
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	zomboidv1 "github.com/zomboidhost/zomboid-operator/api/v1"
+)
+
+var _ = Describe("Settings history", func() {
+	It("should record each change with its value beforehand", func() {
+		zomboidServer := &zomboidv1.ZomboidServer{}
+		zomboidServer.Generation = 3
+		zomboidServer.Spec.Settings.Player.MaxPlayers = ptr.To(int32(16))
+
+		entry := NewHistoryEntry(zomboidServer,
+			[][2]string{{"HoursForLootRespawn", "24"}, {"UltraHardcoreMode", "true"}},
+			[][2]string{{"MaxPlayers", "32"}, {"HoursForLootRespawn", "0"}},
+			nil)
+
+		Expect(entry.Generation).To(Equal(int64(3)))
+		Expect(entry.Changes).To(Equal([]HistoryChange{
+			{Name: "HoursForLootRespawn", Before: "0", After: "24", Cause: CauseSpec},
+			{Name: "UltraHardcoreMode", Before: "", After: "true", Cause: CauseSpec},
+		}))
+	})
+
+	It("should record why each setting was changed", func() {
+		updated := metav1.NewTime(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+		zomboidServer := &zomboidv1.ZomboidServer{}
+		zomboidServer.ManagedFields = []metav1.ManagedFieldsEntry{
+			{Manager: "kubectl-zomboid", Time: &updated, FieldsV1: &metav1.FieldsV1{Raw: []byte(`{"f:spec":{"f:settings":{}}}`)}},
+		}
+		zomboidServer.Spec.SettingsFrom = []zomboidv1.SettingsProfileReference{{Name: "shared"}}
+		zomboidServer.Spec.Settings.Player.MaxPlayers = ptr.To(int32(16))
+		zomboidServer.Spec.Settings.Player.PingLimit = ptr.To(int32(400))
+		history := []HistoryEntry{{Changes: []HistoryChange{{Name: "PingLimit", Before: "100", After: "400"}}}}
+
+		entry := NewHistoryEntry(zomboidServer, [][2]string{{"PingLimit", "400"}}, nil, history)
+		Expect(entry.Changes[0].Cause).To(Equal(CauseDriftRevert))
+		Expect(entry.Manager).To(BeEmpty())
+
+		entry = NewHistoryEntry(zomboidServer, [][2]string{{"PingLimit", "400"}, {"PVP", "false"}}, nil, nil)
+		Expect(entry.Changes[0].Cause).To(Equal(CauseSpec))
+		Expect(entry.Changes[1].Cause).To(Equal(CauseProfile))
+		Expect(entry.Manager).To(Equal("kubectl-zomboid"))
+
+		entry = NewHistoryEntry(zomboidServer, [][2]string{{"PVP", "false"}}, nil, history)
+		Expect(entry.Changes[0].Cause).To(Equal(CauseProfile))
+		Expect(entry.Manager).To(BeEmpty())
+	})
+
+	It("should keep a bounded history in a ConfigMap", func() {
+		configMap := &corev1.ConfigMap{}
+		for i := 1; i <= HistoryLimit+5; i++ {
+			Expect(AppendHistory(configMap, HistoryEntry{Generation: int64(i)})).To(Succeed())
+		}
+
+		history, err := ReadHistory(configMap)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(history).To(HaveLen(HistoryLimit))
+		Expect(history[0].Generation).To(Equal(int64(6)))
+		Expect(history[HistoryLimit-1].Generation).To(Equal(int64(HistoryLimit + 5)))
+	})
+
+	It("should start over a history it can't read", func() {
+		configMap := &corev1.ConfigMap{Data: map[string]string{HistoryKey: "not json"}}
+		_, err := ReadHistory(configMap)
+		Expect(err).To(HaveOccurred())
+
+		Expect(AppendHistory(configMap, HistoryEntry{Generation: 1})).To(Succeed())
+		history, err := ReadHistory(configMap)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(history).To(HaveLen(1))
+	})
+
+	It("should find the field manager that last changed the spec", func() {
+		earlier := metav1.NewTime(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+		later := metav1.NewTime(earlier.Add(time.Hour))
+		zomboidServer := &zomboidv1.ZomboidServer{}
+		zomboidServer.ManagedFields = []metav1.ManagedFieldsEntry{
+			{Manager: "kubectl-client-side-apply", Time: &earlier, FieldsV1: &metav1.FieldsV1{Raw: []byte(`{"f:spec":{"f:version":{}}}`)}},
+			{Manager: "kubectl-zomboid", Time: &later, FieldsV1: &metav1.FieldsV1{Raw: []byte(`{"f:spec":{"f:settings":{}}}`)}},
+			{Manager: "manager", Time: &later, Subresource: "status", FieldsV1: &metav1.FieldsV1{Raw: []byte(`{"f:status":{}}`)}},
+			{Manager: "labeler", Time: &later, FieldsV1: &metav1.FieldsV1{Raw: []byte(`{"f:metadata":{}}`)}},
+		}
+
+		Expect(SpecManager(zomboidServer)).To(Equal("kubectl-zomboid"))
+		Expect(SpecManager(&zomboidv1.ZomboidServer{})).To(BeEmpty())
+	})
+})
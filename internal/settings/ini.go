@@ -0,0 +1,34 @@
+package settings
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// ReadServerINI reads the settings in a server.ini file, such as
+// servertest.ini, in the order they appear
+func ReadServerINI(r io.Reader) ([][2]string, error) {
+	var values [][2]string
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") || strings.HasPrefix(text, ";") {
+			continue
+		}
+
+		key, value, ok := strings.Cut(text, "=")
+		if !ok {
+			return nil, fmt.Errorf("line %d: expected SETTING=VALUE, got %q", line, text)
+		}
+		values = append(values, [2]string{strings.TrimSpace(key), value})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read server.ini: %w", err)
+	}
+
+	return values, nil
+}
@@ -0,0 +1,68 @@
+package settings
+
+import (
+	"strings"
+
+	zomboidv1 "github.com/zomboidhost/zomboid-operator/api/v1"
+)
+
+// VanillaMap is the folder of the vanilla map in the Map setting
+const VanillaMap = "Muldraugh, KY"
+
+// MergeMaps merges the structured maps into the Map setting, after the maps
+// it already lists.  Duplicates are dropped and the vanilla map is loaded
+// last, since the game lets the first map loaded win wherever maps overlap.
+func MergeMaps(settings *zomboidv1.ZomboidSettings) {
+	if len(settings.Maps) == 0 {
+		return
+	}
+
+	var maps []string
+	if settings.Map.Map != nil {
+		maps = append(maps, splitList(*settings.Map.Map)...)
+	}
+	maps = append(maps, settings.Maps...)
+
+	seen := map[string]bool{VanillaMap: true}
+	var ordered []string
+	for _, name := range maps {
+		name = strings.TrimSpace(name)
+		if name == "" || seen[name] {
+			continue
+		}
+		seen[name] = true
+		ordered = append(ordered, name)
+	}
+	ordered = append(ordered, VanillaMap)
+
+	mapString := strings.Join(ordered, ";")
+	settings.Map.Map = &mapString
+}
+
+// Maps returns the map folders a server with settings loads, in order, once
+// the structured maps are merged into the Map setting
+func Maps(settings zomboidv1.ZomboidSettings) []string {
+	MergeMaps(&settings)
+	if settings.Map.Map == nil {
+		return []string{VanillaMap}
+	}
+	return splitList(*settings.Map.Map)
+}
+
+// CustomMaps returns the maps a server with settings loads besides the
+// vanilla map and the towns on it
+func CustomMaps(settings zomboidv1.ZomboidSettings) []string {
+	var custom []string
+	for _, name := range Maps(settings) {
+		vanilla := false
+		for _, town := range VanillaSpawnRegions {
+			if name == town {
+				vanilla = true
+			}
+		}
+		if !vanilla {
+			custom = append(custom, name)
+		}
+	}
+	return custom
+}
@@ -0,0 +1,47 @@
+package settings
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/utils/ptr"
+
+	zomboidv1 "github.com/zomboidhost/zomboid-operator/api/v1"
+)
+
+var _ = Describe("Maps", func() {
+	It("should load the structured maps in order before the vanilla map", func() {
+		settings := zomboidv1.ZomboidSettings{Maps: []string{"Muldraugh, KY", "Bedford Falls", "RavenCreek", "Bedford Falls"}}
+		MergeMaps(&settings)
+		Expect(settings.Map.Map).To(Equal(ptr.To("Bedford Falls;RavenCreek;Muldraugh, KY")))
+	})
+
+	It("should keep the maps the Map setting lists first", func() {
+		settings := zomboidv1.ZomboidSettings{Maps: []string{"RavenCreek"}}
+		settings.Map.Map = ptr.To("Bedford Falls;Muldraugh, KY")
+		MergeMaps(&settings)
+		Expect(settings.Map.Map).To(Equal(ptr.To("Bedford Falls;RavenCreek;Muldraugh, KY")))
+	})
+
+	It("should leave the Map setting alone without structured maps", func() {
+		settings := zomboidv1.ZomboidSettings{}
+		settings.Map.Map = ptr.To("Standalone")
+		MergeMaps(&settings)
+		Expect(settings.Map.Map).To(Equal(ptr.To("Standalone")))
+		Expect(Maps(settings)).To(Equal([]string{"Standalone"}))
+		Expect(Maps(zomboidv1.ZomboidSettings{})).To(Equal([]string{"Muldraugh, KY"}))
+	})
+
+	It("should change the Map setting to the merged maps", func() {
+		spec := zomboidv1.ZomboidSettings{Maps: []string{"RavenCreek"}}
+		observed := *zomboidv1.DefaultZomboidSettings.DeepCopy()
+
+		Expect(PendingUpdates(spec, observed)).To(ContainElement([2]string{"Map", "RavenCreek;Muldraugh, KY"}))
+		Expect(ManagedUpdates(zomboidv1.SettingsPolicyAdopt, spec, observed)).To(Equal([][2]string{{"Map", "RavenCreek;Muldraugh, KY"}}))
+	})
+
+	It("should list the maps loaded besides the vanilla map", func() {
+		settings := zomboidv1.ZomboidSettings{Maps: []string{"RavenCreek"}}
+		settings.Map.Map = ptr.To("Riverside, KY")
+		Expect(CustomMaps(settings)).To(Equal([]string{"RavenCreek"}))
+	})
+})
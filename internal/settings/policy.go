@@ -0,0 +1,79 @@
+package settings
+
+import (
+	"reflect"
+
+	zomboidv1 "github.com/zomboidhost/zomboid-operator/api/v1"
+)
+
+// ManagedUpdates returns the settings the operator will change on a server
+// under its settings policy
+func ManagedUpdates(policy zomboidv1.SettingsPolicy, spec, observed zomboidv1.ZomboidSettings) [][2]string {
+	switch policy {
+	case zomboidv1.SettingsPolicyObserveOnly:
+		return nil
+	case zomboidv1.SettingsPolicyAdopt:
+		return PendingUpdates(AdoptUnset(spec, observed), observed)
+	default:
+		return PendingUpdates(spec, observed)
+	}
+}
+
+// AdoptUnset returns spec with every setting it leaves unset taken from
+// observed, so that only the settings the spec sets are compared
+func AdoptUnset(spec, observed zomboidv1.ZomboidSettings) zomboidv1.ZomboidSettings {
+	adopted := *spec.DeepCopy()
+
+	groups := reflect.ValueOf(&adopted).Elem()
+	observedGroups := reflect.ValueOf(observed)
+	for i := 0; i < groups.NumField(); i++ {
+		group := groups.Field(i)
+		if group.Kind() != reflect.Struct {
+			continue
+		}
+		for j := 0; j < group.NumField(); j++ {
+			field := group.Field(j)
+			observedField := observedGroups.Field(i).Field(j)
+			if field.Kind() == reflect.Ptr && field.IsNil() && !observedField.IsNil() {
+				value := reflect.New(field.Type().Elem())
+				value.Elem().Set(observedField.Elem())
+				field.Set(value)
+			}
+		}
+	}
+
+	// Structured workshop mods and maps are merged into the mod lists and
+	// the Map setting, so those are only adopted when there are none
+	if len(spec.WorkshopMods) > 0 {
+		adopted.Mods = *spec.Mods.DeepCopy()
+	}
+	if len(spec.Maps) > 0 {
+		adopted.Map = *spec.Map.DeepCopy()
+	}
+
+	return adopted
+}
+
+// Drift lists the settings whose observed value differs from the spec, with
+// unset settings compared against their defaults
+func Drift(spec, observed zomboidv1.ZomboidSettings) []zomboidv1.SettingDrift {
+	updates := PendingUpdates(spec, observed)
+	if len(updates) == 0 {
+		return nil
+	}
+
+	observedValues := map[string]string{}
+	for _, value := range Values(observed) {
+		observedValues[value[0]] = value[1]
+	}
+
+	drift := make([]zomboidv1.SettingDrift, len(updates))
+	for i, update := range updates {
+		drift[i] = zomboidv1.SettingDrift{
+			Name:     update[0],
+			Desired:  update[1],
+			Observed: observedValues[update[0]],
+		}
+	}
+	return drift
+}
@@ -0,0 +1,67 @@
+package settings
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/utils/ptr"
+
+	zomboidv1 "github.com/zomboidhost/zomboid-operator/api/v1"
+)
+
+var _ = Describe("Settings Policy", func() {
+	var (
+		spec     zomboidv1.ZomboidSettings
+		observed zomboidv1.ZomboidSettings
+	)
+
+	BeforeEach(func() {
+		observed = *zomboidv1.DefaultZomboidSettings.DeepCopy()
+		// An admin changed these in game
+		observed.Player.MaxPlayers = ptr.To(int32(20))
+		observed.Identity.PublicName = ptr.To("In-game Name")
+
+		spec = zomboidv1.ZomboidSettings{}
+		spec.Identity.PublicName = ptr.To("Spec Name")
+	})
+
+	It("should change every drifted setting under Enforce", func() {
+		updates := ManagedUpdates(zomboidv1.SettingsPolicyEnforce, spec, observed)
+		Expect(updates).To(ConsistOf(
+			[2]string{"PublicName", "Spec Name"},
+			[2]string{"MaxPlayers", "32"},
+		))
+		Expect(ManagedUpdates("", spec, observed)).To(Equal(updates))
+	})
+
+	It("should only change the settings set in the spec under Adopt", func() {
+		Expect(ManagedUpdates(zomboidv1.SettingsPolicyAdopt, spec, observed)).To(Equal([][2]string{
+			{"PublicName", "Spec Name"},
+		}))
+	})
+
+	It("should change nothing under ObserveOnly", func() {
+		Expect(ManagedUpdates(zomboidv1.SettingsPolicyObserveOnly, spec, observed)).To(BeEmpty())
+	})
+
+	It("should keep structured workshop mods when adopting", func() {
+		observed.Mods.Mods = ptr.To("OldMod")
+		observed.Mods.WorkshopItems = ptr.To("111")
+		spec.WorkshopMods = []zomboidv1.WorkshopMod{{ModID: ptr.To("NewMod"), WorkshopID: ptr.To("222")}}
+
+		Expect(ManagedUpdates(zomboidv1.SettingsPolicyAdopt, spec, observed)).To(ContainElements(
+			[2]string{"Mods", "NewMod"},
+			[2]string{"WorkshopItems", "222"},
+		))
+	})
+
+	It("should list drift with the observed and desired values", func() {
+		Expect(Drift(spec, observed)).To(ConsistOf(
+			zomboidv1.SettingDrift{Name: "PublicName", Desired: "Spec Name", Observed: "In-game Name"},
+			zomboidv1.SettingDrift{Name: "MaxPlayers", Desired: "32", Observed: "20"},
+		))
+
+		spec.Identity.PublicName = ptr.To("In-game Name")
+		spec.Player.MaxPlayers = ptr.To(int32(20))
+		Expect(Drift(spec, observed)).To(BeEmpty())
+	})
+})
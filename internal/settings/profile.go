@@ -0,0 +1,113 @@
+package settings
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"slices"
+
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	zomboidv1 "github.com/zomboidhost/zomboid-operator/api/v1"
+)
+
+// Layer returns base with every setting override sets taken from override.
+// Workshop mods are merged by mod ID, keeping base's order and appending the
+// mods only override has, maps are merged the same way, and extra settings
+// are merged by name.
+func Layer(base, override zomboidv1.ZomboidSettings) zomboidv1.ZomboidSettings {
+	layered := *base.DeepCopy()
+
+	layeredValue := reflect.ValueOf(&layered).Elem()
+	overrideValue := reflect.ValueOf(override)
+	for _, setting := range registry {
+		field := setting.field(overrideValue)
+		if field.IsNil() {
+			continue
+		}
+		value := reflect.New(field.Type().Elem())
+		value.Elem().Set(field.Elem())
+		setting.field(layeredValue).Set(value)
+	}
+
+	for _, mod := range override.WorkshopMods {
+		replaced := false
+		for i, existing := range layered.WorkshopMods {
+			if sameWorkshopMod(existing, mod) {
+				layered.WorkshopMods[i] = *mod.DeepCopy()
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			layered.WorkshopMods = append(layered.WorkshopMods, *mod.DeepCopy())
+		}
+	}
+
+	for _, mapName := range override.Maps {
+		if !slices.Contains(layered.Maps, mapName) {
+			layered.Maps = append(layered.Maps, mapName)
+		}
+	}
+
+	for name, value := range override.Extra {
+		if layered.Extra == nil {
+			layered.Extra = map[string]string{}
+		}
+		layered.Extra[name] = value
+	}
+
+	return layered
+}
+
+// Resolve layers the profiles a server refers to in spec.settingsFrom, in
+// order, and then the server's own spec.settings
+func Resolve(ctx context.Context, reader client.Reader, zomboidServer *zomboidv1.ZomboidServer) (zomboidv1.ZomboidSettings, error) {
+	var resolved zomboidv1.ZomboidSettings
+	for _, ref := range zomboidServer.Spec.SettingsFrom {
+		var profile zomboidv1.ZomboidSettingsProfileSpec
+		switch ref.Kind {
+		case "ClusterZomboidSettingsProfile":
+			clusterProfile := &zomboidv1.ClusterZomboidSettingsProfile{}
+			if err := reader.Get(ctx, types.NamespacedName{Name: ref.Name}, clusterProfile); err != nil {
+				return resolved, fmt.Errorf("failed to get ClusterZomboidSettingsProfile %s: %w", ref.Name, err)
+			}
+			profile = clusterProfile.Spec
+		default:
+			namespacedProfile := &zomboidv1.ZomboidSettingsProfile{}
+			if err := reader.Get(ctx, types.NamespacedName{Name: ref.Name, Namespace: zomboidServer.Namespace}, namespacedProfile); err != nil {
+				return resolved, fmt.Errorf("failed to get ZomboidSettingsProfile %s: %w", ref.Name, err)
+			}
+			profile = namespacedProfile.Spec
+		}
+		resolved = Layer(resolved, profile.Settings)
+	}
+
+	return Layer(resolved, zomboidServer.Spec.Settings), nil
+}
+
+// sameWorkshopMod returns whether two workshop mods load the same mod, or,
+// without mod IDs, every mod of the same workshop item
+func sameWorkshopMod(a, b zomboidv1.WorkshopMod) bool {
+	if a.ModID != nil && b.ModID != nil {
+		return *a.ModID == *b.ModID
+	}
+	return a.ModID == nil && b.ModID == nil && a.WorkshopID != nil && b.WorkshopID != nil && *a.WorkshopID == *b.WorkshopID
+}
+
+// Desired returns the settings the operator manages a server with: its
+// configured settings, with the workshop mods the operator resolved
+func Desired(zomboidServer *zomboidv1.ZomboidServer) zomboidv1.ZomboidSettings {
+	return ResolveWorkshopMods(Configured(zomboidServer), zomboidServer.Spec.WorkshopDependencies, zomboidServer.Status.WorkshopMods)
+}
+
+// Configured returns the settings a server is configured with: the settings
+// resolved from its profiles when it refers to any, otherwise its
+// spec.settings
+func Configured(zomboidServer *zomboidv1.ZomboidServer) zomboidv1.ZomboidSettings {
+	if len(zomboidServer.Spec.SettingsFrom) > 0 && zomboidServer.Status.ResolvedSettings != nil {
+		return *zomboidServer.Status.ResolvedSettings
+	}
+	return zomboidServer.Spec.Settings
+}
@@ -0,0 +1,143 @@
+package settings
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	clientfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	zomboidv1 "github.com/zomboidhost/zomboid-operator/api/v1"
+)
+
+var _ = Describe("Settings profiles", func() {
+	Describe("Layer", func() {
+		It("should only override the settings the override sets", func() {
+			base := zomboidv1.ZomboidSettings{}
+			base.Player.MaxPlayers = ptr.To(int32(24))
+			base.Player.PingLimit = ptr.To(int32(400))
+			override := zomboidv1.ZomboidSettings{}
+			override.Player.MaxPlayers = ptr.To(int32(16))
+			override.PVP.PVP = ptr.To(true)
+
+			layered := Layer(base, override)
+			Expect(layered.Player.MaxPlayers).To(Equal(ptr.To(int32(16))))
+			Expect(layered.Player.PingLimit).To(Equal(ptr.To(int32(400))))
+			Expect(layered.PVP.PVP).To(Equal(ptr.To(true)))
+			Expect(base.Player.MaxPlayers).To(Equal(ptr.To(int32(24))))
+		})
+
+		It("should merge workshop mods by mod ID", func() {
+			base := zomboidv1.ZomboidSettings{WorkshopMods: []zomboidv1.WorkshopMod{
+				{ModID: ptr.To("AuthenticZ"), WorkshopID: ptr.To("2392709985")},
+				{ModID: ptr.To("Brita_2"), WorkshopID: ptr.To("2478768005")},
+			}}
+			override := zomboidv1.ZomboidSettings{WorkshopMods: []zomboidv1.WorkshopMod{
+				{ModID: ptr.To("Hydrocraft"), WorkshopID: ptr.To("498441420")},
+				{ModID: ptr.To("AuthenticZ"), WorkshopID: ptr.To("1111111111")},
+			}}
+
+			Expect(Layer(base, override).WorkshopMods).To(Equal([]zomboidv1.WorkshopMod{
+				{ModID: ptr.To("AuthenticZ"), WorkshopID: ptr.To("1111111111")},
+				{ModID: ptr.To("Brita_2"), WorkshopID: ptr.To("2478768005")},
+				{ModID: ptr.To("Hydrocraft"), WorkshopID: ptr.To("498441420")},
+			}))
+		})
+
+		It("should merge workshop mods without a mod ID by workshop ID", func() {
+			base := zomboidv1.ZomboidSettings{WorkshopMods: []zomboidv1.WorkshopMod{
+				{WorkshopID: ptr.To("2169435993")},
+				{ModID: ptr.To("modoptions"), WorkshopID: ptr.To("2200148440")},
+			}}
+			override := zomboidv1.ZomboidSettings{WorkshopMods: []zomboidv1.WorkshopMod{
+				{WorkshopID: ptr.To("2169435993")},
+				{WorkshopID: ptr.To("2200148440")},
+			}}
+
+			Expect(Layer(base, override).WorkshopMods).To(Equal([]zomboidv1.WorkshopMod{
+				{WorkshopID: ptr.To("2169435993")},
+				{ModID: ptr.To("modoptions"), WorkshopID: ptr.To("2200148440")},
+				{WorkshopID: ptr.To("2200148440")},
+			}))
+		})
+
+		It("should merge maps in order", func() {
+			base := zomboidv1.ZomboidSettings{Maps: []string{"Bedford Falls", "RavenCreek"}}
+			override := zomboidv1.ZomboidSettings{Maps: []string{"Louisville", "Bedford Falls"}}
+
+			Expect(Layer(base, override).Maps).To(Equal([]string{"Bedford Falls", "RavenCreek", "Louisville"}))
+		})
+
+		It("should merge extra settings by name", func() {
+			base := zomboidv1.ZomboidSettings{Extra: map[string]string{"UltraHardcoreMode": "true", "ServerNews": "hi"}}
+			override := zomboidv1.ZomboidSettings{Extra: map[string]string{"ServerNews": "bye"}}
+
+			Expect(Layer(base, override).Extra).To(Equal(map[string]string{"UltraHardcoreMode": "true", "ServerNews": "bye"}))
+		})
+	})
+
+	Describe("Resolve", func() {
+		var (
+			ctx           context.Context
+			scheme        *runtime.Scheme
+			zomboidServer *zomboidv1.ZomboidServer
+			objects       []runtime.Object
+		)
+
+		BeforeEach(func() {
+			ctx = context.Background()
+			scheme = runtime.NewScheme()
+			Expect(zomboidv1.AddToScheme(scheme)).To(Succeed())
+
+			clusterProfile := &zomboidv1.ClusterZomboidSettingsProfile{ObjectMeta: metav1.ObjectMeta{Name: "community"}}
+			clusterProfile.Spec.Settings.Player.MaxPlayers = ptr.To(int32(24))
+			clusterProfile.Spec.Settings.Player.PingLimit = ptr.To(int32(400))
+			profile := &zomboidv1.ZomboidSettingsProfile{ObjectMeta: metav1.ObjectMeta{Name: "pvp", Namespace: "default"}}
+			profile.Spec.Settings.Player.PingLimit = ptr.To(int32(300))
+			profile.Spec.Settings.PVP.PVP = ptr.To(true)
+			objects = []runtime.Object{clusterProfile, profile}
+
+			zomboidServer = &zomboidv1.ZomboidServer{ObjectMeta: metav1.ObjectMeta{Name: "knox", Namespace: "default"}}
+			zomboidServer.Spec.SettingsFrom = []zomboidv1.SettingsProfileReference{
+				{Kind: "ClusterZomboidSettingsProfile", Name: "community"},
+				{Name: "pvp"},
+			}
+			zomboidServer.Spec.Settings.Player.MaxPlayers = ptr.To(int32(16))
+		})
+
+		It("should layer the profiles in order under the server's settings", func() {
+			reader := clientfake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(objects...).Build()
+
+			resolved, err := Resolve(ctx, reader, zomboidServer)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resolved.Player.MaxPlayers).To(Equal(ptr.To(int32(16))))
+			Expect(resolved.Player.PingLimit).To(Equal(ptr.To(int32(300))))
+			Expect(resolved.PVP.PVP).To(Equal(ptr.To(true)))
+		})
+
+		It("should only look for namespaced profiles in the server's namespace", func() {
+			zomboidServer.Namespace = "other"
+			reader := clientfake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(objects...).Build()
+
+			_, err := Resolve(ctx, reader, zomboidServer)
+			Expect(apierrors.IsNotFound(err)).To(BeTrue())
+			Expect(err.Error()).To(ContainSubstring("ZomboidSettingsProfile pvp"))
+		})
+
+		It("should manage servers with their resolved settings once they refer to profiles", func() {
+			Expect(Desired(zomboidServer).Player.MaxPlayers).To(Equal(ptr.To(int32(16))))
+
+			resolved := zomboidv1.ZomboidSettings{}
+			resolved.Player.PingLimit = ptr.To(int32(300))
+			zomboidServer.Status.ResolvedSettings = &resolved
+			Expect(Desired(zomboidServer).Player.PingLimit).To(Equal(ptr.To(int32(300))))
+
+			zomboidServer.Spec.SettingsFrom = nil
+			Expect(Desired(zomboidServer).Player.PingLimit).To(BeNil())
+		})
+	})
+})
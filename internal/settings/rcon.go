@@ -5,15 +5,15 @@ import (
 	"fmt"
 	"strings"
 
-	"github.com/gorcon/rcon"
 	zomboidv1 "github.com/zomboidhost/zomboid-operator/api/v1"
+	"github.com/zomboidhost/zomboid-operator/internal/rcon"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 )
 
 // ReadServerOptions executes the showoptions command and updates the provided settings object
 // with the current server settings.
-func ReadServerOptions(ctx context.Context, conn *rcon.Conn, settings *zomboidv1.ZomboidSettings) error {
-	response, err := conn.Execute("showoptions")
+func ReadServerOptions(ctx context.Context, client rcon.Client, settings *zomboidv1.ZomboidSettings) error {
+	response, err := client.Execute("showoptions")
 	if err != nil {
 		return fmt.Errorf("failed to execute showoptions command: %w", err)
 	}
@@ -43,14 +43,22 @@ func ParseRCONShowOptions(output string, settings *zomboidv1.ZomboidSettings) {
 		key := strings.TrimSpace(parts[0])
 		value := strings.TrimSpace(parts[1])
 
+		// Settings the operator doesn't manage are kept in Extra, even when
+		// empty, so that they can be compared with the spec's
+		if _, ok := LookupSetting(key); !ok {
+			_ = setValue(settings, key, value)
+			continue
+		}
+
+		// Settings whose values can't be read are left unset
 		if value != "" {
-			ParseSettingValue(settings, key, value)
+			_ = ParseSettingValue(settings, key, value)
 		}
 	}
 }
 
 // ApplySettingsUpdates applies the given settings changes via RCON
-func ApplySettingsUpdates(ctx context.Context, conn *rcon.Conn, updates [][2]string, settings *zomboidv1.ZomboidSettings) error {
+func ApplySettingsUpdates(ctx context.Context, client rcon.Client, updates [][2]string, settings *zomboidv1.ZomboidSettings) error {
 	logger := log.FromContext(ctx)
 
 	for _, update := range updates {
@@ -58,7 +66,7 @@ func ApplySettingsUpdates(ctx context.Context, conn *rcon.Conn, updates [][2]str
 		settingValue := update[1]
 
 		command := fmt.Sprintf("changeoption %s \"%s\"", settingName, settingValue)
-		resultLine, err := conn.Execute(command)
+		resultLine, err := client.Execute(command)
 		if err != nil {
 			return fmt.Errorf("failed to execute RCON command %q: %w", command, err)
 		}
@@ -77,7 +85,9 @@ func ApplySettingsUpdates(ctx context.Context, conn *rcon.Conn, updates [][2]str
 
 		// Update the settings object with the confirmed value
 		if settings != nil {
-			ParseSettingValue(settings, settingName, newValue)
+			if err := setValue(settings, settingName, newValue); err != nil {
+				return fmt.Errorf("failed to read the new value of %s: %w", settingName, err)
+			}
 		}
 
 		logger.Info("Applied setting change", "setting", settingName, "value", settingValue)
@@ -86,8 +96,14 @@ func ApplySettingsUpdates(ctx context.Context, conn *rcon.Conn, updates [][2]str
 	return nil
 }
 
+// SaveWorld asks the server to save the world to disk
+func SaveWorld(ctx context.Context, client rcon.Client) error {
+	_, err := client.Execute("save")
+	return err
+}
+
 // RestartServer sends the quit command to the RCON server to restart it
-func RestartServer(ctx context.Context, conn *rcon.Conn) error {
-	_, err := conn.Execute("quit")
+func RestartServer(ctx context.Context, client rcon.Client) error {
+	_, err := client.Execute("quit")
 	return err
 }
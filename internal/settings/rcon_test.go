@@ -53,6 +53,8 @@ List of Server Options:
 * AntiCheatProtectionType1=true
 * AntiCheatProtectionType2=true
 * AntiCheatProtectionType2ThresholdMultiplier=3.0
+* UltraHardcoreMode=true
+* ServerNews=
 `
 
 var _ = Describe("RCON Settings Parser", func() {
@@ -115,6 +117,13 @@ var _ = Describe("RCON Settings Parser", func() {
 			Expect(*settings.PVP.PVPMeleeWhileHitReaction).To(BeFalse())
 		})
 
+		It("should keep settings it doesn't manage as extra settings", func() {
+			Expect(settings.Extra).To(Equal(map[string]string{
+				"UltraHardcoreMode": "true",
+				"ServerNews":        "",
+			}))
+		})
+
 		It("should parse anti-cheat settings correctly", func() {
 			Expect(*settings.AntiCheat.DoLuaChecksum).To(BeTrue())
 			Expect(*settings.AntiCheat.KickFastPlayers).To(BeFalse())
@@ -0,0 +1,114 @@
+package settings
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+
+	"k8s.io/utils/ptr"
+
+	zomboidv1 "github.com/zomboidhost/zomboid-operator/api/v1"
+)
+
+// VanillaSpawnRegions are the spawn regions the game lists for the vanilla
+// map, each with the spawnpoints.lua in its own folder
+var VanillaSpawnRegions = []string{"Muldraugh, KY", "Riverside, KY", "Rosewood, KY", "West Point, KY"}
+
+// SpawnRegionsConfigMapName returns the name of the ConfigMap holding a
+// server's spawn regions
+func SpawnRegionsConfigMapName(serverName string) string {
+	return serverName + "-spawn-regions"
+}
+
+// SpawnRegionsFile returns the name of a server's spawn regions file, which
+// is also its key in the spawn regions ConfigMap
+func SpawnRegionsFile(serverName string) string {
+	return serverName + "_spawnregions.lua"
+}
+
+// MapSpawnPoints returns the spawnpoints.lua of a map folder
+func MapSpawnPoints(mapName string) string {
+	return fmt.Sprintf("media/maps/%s/spawnpoints.lua", mapName)
+}
+
+// SpawnRegions returns the spawn regions of a server: those in spec.spawn,
+// followed, unless spec.spawn.mapRegions is off, by a region for every map
+// the server loads that they don't already cover.  It returns nil when the
+// server doesn't configure spec.spawn.
+func SpawnRegions(zomboidServer *zomboidv1.ZomboidServer) []zomboidv1.SpawnRegion {
+	spawn := zomboidServer.Spec.Spawn
+	if spawn == nil {
+		return nil
+	}
+
+	regions := make([]zomboidv1.SpawnRegion, 0, len(spawn.Regions))
+	names := map[string]bool{}
+	files := map[string]bool{}
+	for _, region := range spawn.Regions {
+		regions = append(regions, *region.DeepCopy())
+		names[region.Name] = true
+		if region.File != nil {
+			files[*region.File] = true
+		}
+	}
+
+	if spawn.MapRegions != nil && !*spawn.MapRegions {
+		return regions
+	}
+
+	for _, mapName := range Maps(Desired(zomboidServer)) {
+		mapRegions := []string{mapName}
+		if mapName == VanillaMap {
+			mapRegions = VanillaSpawnRegions
+		}
+		for _, name := range mapRegions {
+			file := MapSpawnPoints(name)
+			if names[name] || files[file] {
+				continue
+			}
+			regions = append(regions, zomboidv1.SpawnRegion{Name: name, File: ptr.To(file)})
+			names[name] = true
+			files[file] = true
+		}
+	}
+	return regions
+}
+
+// RenderSpawnRegions renders spawn regions as a spawnregions.lua
+func RenderSpawnRegions(regions []zomboidv1.SpawnRegion) []byte {
+	var buf bytes.Buffer
+	buf.WriteString("function SpawnRegions()\n")
+	buf.WriteString("\treturn {\n")
+	for _, region := range regions {
+		if region.File != nil {
+			fmt.Fprintf(&buf, "\t\t{ name = %s, file = %s },\n", luaString(region.Name), luaString(*region.File))
+			continue
+		}
+
+		fmt.Fprintf(&buf, "\t\t{ name = %s, points = {\n", luaString(region.Name))
+		professions := make([]string, 0, len(region.Points))
+		for profession := range region.Points {
+			professions = append(professions, profession)
+		}
+		sort.Strings(professions)
+		for _, profession := range professions {
+			fmt.Fprintf(&buf, "\t\t\t[%s] = {\n", luaString(profession))
+			for _, point := range region.Points[profession] {
+				fmt.Fprintf(&buf, "\t\t\t\t{ worldX = %d, worldY = %d, posX = %d, posY = %d, posZ = %d },\n",
+					point.WorldX, point.WorldY, point.PosX, point.PosY, point.PosZ)
+			}
+			buf.WriteString("\t\t\t},\n")
+		}
+		buf.WriteString("\t\t} },\n")
+	}
+	buf.WriteString("\t}\n")
+	buf.WriteString("end\n")
+	return buf.Bytes()
+}
+
+// luaString quotes s as a Lua string literal
+func luaString(s string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`, "\r", `\r`)
+	return `"` + replacer.Replace(s) + `"`
+}
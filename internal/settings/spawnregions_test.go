@@ -0,0 +1,92 @@
+package settings
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/utils/ptr"
+
+	zomboidv1 "github.com/zomboidhost/zomboid-operator/api/v1"
+)
+
+var _ = Describe("Spawn regions", func() {
+	var zomboidServer *zomboidv1.ZomboidServer
+
+	BeforeEach(func() {
+		zomboidServer = &zomboidv1.ZomboidServer{}
+		zomboidServer.Spec.Spawn = &zomboidv1.Spawn{}
+	})
+
+	It("should leave servers without spec.spawn to manage their own", func() {
+		zomboidServer.Spec.Spawn = nil
+		Expect(SpawnRegions(zomboidServer)).To(BeNil())
+	})
+
+	It("should list the vanilla regions when the server loads the vanilla map", func() {
+		regions := SpawnRegions(zomboidServer)
+		Expect(regions).To(HaveLen(4))
+		Expect(regions[1]).To(Equal(zomboidv1.SpawnRegion{
+			Name: "Riverside, KY",
+			File: ptr.To("media/maps/Riverside, KY/spawnpoints.lua"),
+		}))
+	})
+
+	It("should add the regions of maps the listed regions don't cover", func() {
+		zomboidServer.Spec.Settings.Map.Map = ptr.To("Bedford Falls;RavenCreek;Muldraugh, KY")
+		zomboidServer.Spec.Spawn.Regions = []zomboidv1.SpawnRegion{
+			{Name: "Raven Creek", File: ptr.To("media/maps/RavenCreek/spawnpoints.lua")},
+			{Name: "Riverside, KY", Points: map[string][]zomboidv1.SpawnPoint{
+				"unemployed": {{WorldX: 20, WorldY: 17, PosX: 150, PosY: 120}},
+			}},
+		}
+
+		var names []string
+		for _, region := range SpawnRegions(zomboidServer) {
+			names = append(names, region.Name)
+		}
+		Expect(names).To(Equal([]string{"Raven Creek", "Riverside, KY", "Bedford Falls", "Muldraugh, KY", "Rosewood, KY", "West Point, KY"}))
+	})
+
+	It("should only list the configured regions without map regions", func() {
+		zomboidServer.Spec.Spawn.MapRegions = ptr.To(false)
+		zomboidServer.Spec.Spawn.Regions = []zomboidv1.SpawnRegion{
+			{Name: "Rosewood, KY", File: ptr.To("media/maps/Rosewood, KY/spawnpoints.lua")},
+		}
+		Expect(SpawnRegions(zomboidServer)).To(HaveLen(1))
+	})
+
+	It("should follow the map of the resolved settings", func() {
+		zomboidServer.Spec.SettingsFrom = []zomboidv1.SettingsProfileReference{{Name: "maps"}}
+		resolved := zomboidv1.ZomboidSettings{}
+		resolved.Map.Map = ptr.To("Bedford Falls")
+		zomboidServer.Status.ResolvedSettings = &resolved
+
+		Expect(SpawnRegions(zomboidServer)).To(Equal([]zomboidv1.SpawnRegion{
+			{Name: "Bedford Falls", File: ptr.To("media/maps/Bedford Falls/spawnpoints.lua")},
+		}))
+	})
+
+	It("should render the regions as a spawnregions.lua", func() {
+		regions := []zomboidv1.SpawnRegion{
+			{Name: "Muldraugh, KY", File: ptr.To("media/maps/Muldraugh, KY/spawnpoints.lua")},
+			{Name: `The "Mall"`, Points: map[string][]zomboidv1.SpawnPoint{
+				"unemployed":  {{WorldX: 45, WorldY: 33, PosX: 120, PosY: 150}},
+				"fireofficer": {{WorldX: 45, WorldY: 34, PosX: 10, PosY: 20, PosZ: 1}},
+			}},
+		}
+
+		Expect(string(RenderSpawnRegions(regions))).To(Equal(`function SpawnRegions()
+	return {
+		{ name = "Muldraugh, KY", file = "media/maps/Muldraugh, KY/spawnpoints.lua" },
+		{ name = "The \"Mall\"", points = {
+			["fireofficer"] = {
+				{ worldX = 45, worldY = 34, posX = 10, posY = 20, posZ = 1 },
+			},
+			["unemployed"] = {
+				{ worldX = 45, worldY = 33, posX = 120, posY = 150, posZ = 0 },
+			},
+		} },
+	}
+end
+`))
+	})
+})
@@ -1,8 +1,12 @@
 package settings
 
 import (
+	"fmt"
+	"slices"
 	"strings"
 
+	"k8s.io/utils/ptr"
+
 	zomboidv1 "github.com/zomboidhost/zomboid-operator/api/v1"
 )
 
@@ -22,12 +26,13 @@ func MergeWorkshopMods(settings *zomboidv1.ZomboidSettings) {
 		workshopIDs = append(workshopIDs, strings.Split(*settings.Mods.WorkshopItems, ";")...)
 	}
 
-	// Add the structured workshop mods
+	// Add the structured workshop mods, listing an item with several mods
+	// once
 	for _, mod := range settings.WorkshopMods {
 		if mod.ModID != nil {
 			modIDs = append(modIDs, *mod.ModID)
 		}
-		if mod.WorkshopID != nil {
+		if mod.WorkshopID != nil && !slices.Contains(workshopIDs, *mod.WorkshopID) {
 			workshopIDs = append(workshopIDs, *mod.WorkshopID)
 		}
 	}
@@ -42,3 +47,169 @@ func MergeWorkshopMods(settings *zomboidv1.ZomboidSettings) {
 		settings.Mods.WorkshopItems = &workshopString
 	}
 }
+
+// WorkshopItems returns the workshop items of the mods in settings, once the
+// structured workshop mods are merged, without duplicates
+func WorkshopItems(settings zomboidv1.ZomboidSettings) []string {
+	MergeWorkshopMods(&settings)
+	if settings.Mods.WorkshopItems == nil {
+		return nil
+	}
+
+	var items []string
+	seen := map[string]bool{}
+	for _, item := range splitList(*settings.Mods.WorkshopItems) {
+		if !seen[item] {
+			seen[item] = true
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// ResolveWorkshopMods fills in the workshop mods of settings from the
+// workshop items the operator resolved: a workshop mod without a mod ID
+// loads every mod its item contains, once they're known, and under the Add
+// policy the items the mods require are loaded too.  Mods already loaded
+// aren't listed again.
+func ResolveWorkshopMods(settings zomboidv1.ZomboidSettings, policy zomboidv1.WorkshopDependencyPolicy, resolved []zomboidv1.WorkshopModStatus) zomboidv1.ZomboidSettings {
+	if len(resolved) == 0 {
+		return settings
+	}
+
+	items := map[string]zomboidv1.WorkshopModStatus{}
+	for _, item := range resolved {
+		items[item.WorkshopID] = item
+	}
+
+	loaded := map[string]bool{}
+	if settings.Mods.Mods != nil {
+		for _, modID := range splitList(*settings.Mods.Mods) {
+			loaded[modID] = true
+		}
+	}
+	for _, mod := range settings.WorkshopMods {
+		if mod.ModID != nil && *mod.ModID != "" {
+			loaded[*mod.ModID] = true
+		}
+	}
+
+	var mods []zomboidv1.WorkshopMod
+	addItem := func(item zomboidv1.WorkshopModStatus) {
+		if len(item.Mods) == 0 {
+			mods = append(mods, zomboidv1.WorkshopMod{WorkshopID: ptr.To(item.WorkshopID)})
+			return
+		}
+		for _, mod := range item.Mods {
+			if !loaded[mod.ID] {
+				loaded[mod.ID] = true
+				mods = append(mods, zomboidv1.WorkshopMod{ModID: ptr.To(mod.ID), WorkshopID: ptr.To(item.WorkshopID)})
+			}
+		}
+	}
+
+	for _, mod := range settings.WorkshopMods {
+		if (mod.ModID == nil || *mod.ModID == "") && mod.WorkshopID != nil {
+			if item, ok := items[*mod.WorkshopID]; ok {
+				addItem(item)
+				continue
+			}
+		}
+		mods = append(mods, *mod.DeepCopy())
+	}
+	if policy != zomboidv1.WorkshopDependenciesAdd {
+		settings.WorkshopMods = mods
+		return settings
+	}
+
+	for _, item := range resolved {
+		if len(item.RequiredBy) > 0 {
+			addItem(item)
+		}
+	}
+
+	// Mods can also require mods their item doesn't load, such as another
+	// mod in an item with a mod ID picked
+	providers := map[string]string{}
+	for _, item := range resolved {
+		for _, mod := range item.Mods {
+			if _, ok := providers[mod.ID]; !ok {
+				providers[mod.ID] = item.WorkshopID
+			}
+		}
+	}
+	for added := true; added; {
+		added = false
+		for _, item := range resolved {
+			for _, mod := range item.Mods {
+				if !loaded[mod.ID] {
+					continue
+				}
+				for _, required := range mod.Requires {
+					if workshopID, ok := providers[required]; ok && !loaded[required] {
+						loaded[required] = true
+						mods = append(mods, zomboidv1.WorkshopMod{ModID: ptr.To(required), WorkshopID: ptr.To(workshopID)})
+						added = true
+					}
+				}
+			}
+		}
+	}
+
+	settings.WorkshopMods = mods
+	return settings
+}
+
+// MissingDependencies lists what the loaded mods of settings require but
+// settings don't load, going by the workshop items the operator resolved:
+// the mods their mod.info files require, and the workshop items their pages
+// list as required
+func MissingDependencies(settings zomboidv1.ZomboidSettings, resolved []zomboidv1.WorkshopModStatus) []string {
+	merged := settings
+	MergeWorkshopMods(&merged)
+	loadedMods := map[string]bool{}
+	if merged.Mods.Mods != nil {
+		for _, modID := range splitList(*merged.Mods.Mods) {
+			loadedMods[modID] = true
+		}
+	}
+	loadedItems := map[string]bool{}
+	for _, workshopID := range WorkshopItems(settings) {
+		loadedItems[workshopID] = true
+	}
+	titles := map[string]string{}
+	for _, item := range resolved {
+		titles[item.WorkshopID] = item.Title
+	}
+
+	var missing []string
+	reported := map[string]bool{}
+	for _, item := range resolved {
+		if !loadedItems[item.WorkshopID] {
+			continue
+		}
+		for _, mod := range item.Mods {
+			if !loadedMods[mod.ID] {
+				continue
+			}
+			for _, required := range mod.Requires {
+				if !loadedMods[required] && !reported["mod "+required] {
+					reported["mod "+required] = true
+					missing = append(missing, fmt.Sprintf("mod %s, required by %s", required, mod.ID))
+				}
+			}
+		}
+		for _, required := range item.RequiredItems {
+			if loadedItems[required] || reported["item "+required] {
+				continue
+			}
+			reported["item "+required] = true
+			name := required
+			if title := titles[required]; title != "" {
+				name = fmt.Sprintf("%s (%s)", required, title)
+			}
+			missing = append(missing, fmt.Sprintf("workshop item %s, required by %s", name, item.WorkshopID))
+		}
+	}
+	return missing
+}
@@ -89,4 +89,107 @@ var _ = Describe("Workshop mod handling", func() {
 			Expect(*settings.Mods.WorkshopItems).To(Equal("444444"))
 		})
 	})
+
+	Context("MergeWorkshopMods with several mods from one item", func() {
+		It("should list the item once", func() {
+			settings := &zomboidv1.ZomboidSettings{
+				WorkshopMods: []zomboidv1.WorkshopMod{
+					{ModID: ptr.To("ModManager"), WorkshopID: ptr.To("2169435993")},
+					{ModID: ptr.To("ModManagerServer"), WorkshopID: ptr.To("2169435993")},
+				},
+			}
+
+			MergeWorkshopMods(settings)
+
+			Expect(*settings.Mods.Mods).To(Equal("ModManager;ModManagerServer"))
+			Expect(*settings.Mods.WorkshopItems).To(Equal("2169435993"))
+		})
+	})
+
+	Context("WorkshopItems", func() {
+		It("should list the merged workshop items once each", func() {
+			settings := zomboidv1.ZomboidSettings{
+				Mods: zomboidv1.Mods{WorkshopItems: ptr.To("111111;222222")},
+				WorkshopMods: []zomboidv1.WorkshopMod{
+					{ModID: ptr.To("NewMod1"), WorkshopID: ptr.To("222222")},
+					{ModID: ptr.To("NewMod2"), WorkshopID: ptr.To("333333")},
+				},
+			}
+
+			Expect(WorkshopItems(settings)).To(Equal([]string{"111111", "222222", "333333"}))
+			Expect(*settings.Mods.WorkshopItems).To(Equal("111111;222222"))
+		})
+
+		It("should return nothing without workshop items", func() {
+			Expect(WorkshopItems(zomboidv1.ZomboidSettings{})).To(BeEmpty())
+		})
+	})
+	Context("ResolveWorkshopMods", func() {
+		resolved := []zomboidv1.WorkshopModStatus{
+			{WorkshopID: "2169435993", Title: "Mod Manager", Mods: []zomboidv1.ResolvedMod{
+				{ID: "ModManager"},
+				{ID: "ModManagerServer", Requires: []string{"ModManager", "modoptions"}},
+			}},
+			{WorkshopID: "2200148440", Title: "Mod Options", RequiredBy: []string{"2169435993"}, Mods: []zomboidv1.ResolvedMod{
+				{ID: "modoptions"},
+			}},
+		}
+
+		It("should load every mod of the items without a mod ID", func() {
+			settings := zomboidv1.ZomboidSettings{WorkshopMods: []zomboidv1.WorkshopMod{
+				{ModID: ptr.To("Hydrocraft"), WorkshopID: ptr.To("498441420")},
+				{WorkshopID: ptr.To("2169435993")},
+			}}
+
+			desired := ResolveWorkshopMods(settings, zomboidv1.WorkshopDependenciesReport, resolved)
+			Expect(desired.WorkshopMods).To(Equal([]zomboidv1.WorkshopMod{
+				{ModID: ptr.To("Hydrocraft"), WorkshopID: ptr.To("498441420")},
+				{ModID: ptr.To("ModManager"), WorkshopID: ptr.To("2169435993")},
+				{ModID: ptr.To("ModManagerServer"), WorkshopID: ptr.To("2169435993")},
+			}))
+			Expect(settings.WorkshopMods).To(HaveLen(2))
+		})
+
+		It("should keep items that aren't resolved yet", func() {
+			settings := zomboidv1.ZomboidSettings{WorkshopMods: []zomboidv1.WorkshopMod{{WorkshopID: ptr.To("1")}}}
+
+			desired := ResolveWorkshopMods(settings, zomboidv1.WorkshopDependenciesAdd, resolved)
+			Expect(desired.WorkshopMods).To(ContainElement(zomboidv1.WorkshopMod{WorkshopID: ptr.To("1")}))
+		})
+
+		It("should load the items and mods the loaded mods require under the Add policy", func() {
+			settings := zomboidv1.ZomboidSettings{WorkshopMods: []zomboidv1.WorkshopMod{
+				{ModID: ptr.To("ModManagerServer"), WorkshopID: ptr.To("2169435993")},
+			}}
+
+			desired := ResolveWorkshopMods(settings, zomboidv1.WorkshopDependenciesAdd, resolved)
+			Expect(desired.WorkshopMods).To(Equal([]zomboidv1.WorkshopMod{
+				{ModID: ptr.To("ModManagerServer"), WorkshopID: ptr.To("2169435993")},
+				{ModID: ptr.To("modoptions"), WorkshopID: ptr.To("2200148440")},
+				{ModID: ptr.To("ModManager"), WorkshopID: ptr.To("2169435993")},
+			}))
+			Expect(MissingDependencies(desired, resolved)).To(BeEmpty())
+		})
+	})
+
+	Context("MissingDependencies", func() {
+		It("should list the mods and items the loaded mods require", func() {
+			settings := zomboidv1.ZomboidSettings{WorkshopMods: []zomboidv1.WorkshopMod{
+				{ModID: ptr.To("ModManagerServer"), WorkshopID: ptr.To("2169435993")},
+			}}
+			resolved := []zomboidv1.WorkshopModStatus{
+				{WorkshopID: "2169435993", RequiredItems: []string{"2200148440"}, Mods: []zomboidv1.ResolvedMod{
+					{ID: "ModManager", Requires: []string{"unused"}},
+					{ID: "ModManagerServer", Requires: []string{"ModManager", "modoptions"}},
+				}},
+				{WorkshopID: "2200148440", Title: "Mod Options", RequiredBy: []string{"2169435993"}},
+			}
+
+			Expect(MissingDependencies(settings, resolved)).To(Equal([]string{
+				"mod ModManager, required by ModManagerServer",
+				"mod modoptions, required by ModManagerServer",
+				"workshop item 2200148440 (Mod Options), required by 2169435993",
+			}))
+		})
+	})
 })
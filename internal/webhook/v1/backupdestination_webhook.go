@@ -0,0 +1,97 @@
+package v1
+
+import (
+	"context"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	zomboidv1 "github.com/zomboidhost/zomboid-operator/api/v1"
+)
+
+// log is for logging in this package.
+var backupdestinationlog = logf.Log.WithName("backupdestination-resource")
+
+// SetupBackupDestinationWebhookWithManager registers the webhook for BackupDestination in the manager.
+func SetupBackupDestinationWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).For(&zomboidv1.BackupDestination{}).
+		WithValidator(&BackupDestinationCustomValidator{}).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/validate-zomboid-host-v1-backupdestination,mutating=false,failurePolicy=fail,sideEffects=None,groups=zomboid.host,resources=backupdestinations,verbs=create;update,versions=v1,name=vbackupdestination-v1.kb.io,admissionReviewVersions=v1
+
+// BackupDestinationCustomValidator checks that a BackupDestination
+// configures exactly one storage provider.
+type BackupDestinationCustomValidator struct{}
+
+var _ webhook.CustomValidator = &BackupDestinationCustomValidator{}
+
+// ValidateCreate implements webhook.CustomValidator so a webhook will be registered for the type BackupDestination.
+func (v *BackupDestinationCustomValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	backupdestination, ok := obj.(*zomboidv1.BackupDestination)
+	if !ok {
+		return nil, fmt.Errorf("expected a BackupDestination object but got %T", obj)
+	}
+	backupdestinationlog.Info("Validation for BackupDestination upon creation", "name", backupdestination.GetName())
+
+	return nil, validateBackupDestination(backupdestination)
+}
+
+// ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type BackupDestination.
+func (v *BackupDestinationCustomValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	backupdestination, ok := newObj.(*zomboidv1.BackupDestination)
+	if !ok {
+		return nil, fmt.Errorf("expected a BackupDestination object for the newObj but got %T", newObj)
+	}
+	backupdestinationlog.Info("Validation for BackupDestination upon update", "name", backupdestination.GetName())
+
+	if backupdestination.DeletionTimestamp != nil {
+		return nil, nil
+	}
+
+	return nil, validateBackupDestination(backupdestination)
+}
+
+// ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type BackupDestination.
+func (v *BackupDestinationCustomValidator) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func validateBackupDestination(destination *zomboidv1.BackupDestination) error {
+	specPath := field.NewPath("spec")
+
+	var configured []string
+	if destination.Spec.Dropbox != nil {
+		configured = append(configured, "dropbox")
+	}
+	if destination.Spec.GoogleDrive != nil {
+		configured = append(configured, "googleDrive")
+	}
+	if destination.Spec.S3 != nil {
+		configured = append(configured, "s3")
+	}
+
+	var allErrs field.ErrorList
+	switch len(configured) {
+	case 0:
+		allErrs = append(allErrs, field.Required(specPath, "one of dropbox, googleDrive or s3 must be set"))
+	case 1:
+	default:
+		allErrs = append(allErrs, field.Forbidden(specPath,
+			fmt.Sprintf("only one of dropbox, googleDrive or s3 may be set, but found %v", configured)))
+	}
+
+	if len(allErrs) == 0 {
+		return nil
+	}
+	return apierrors.NewInvalid(
+		zomboidv1.GroupVersion.WithKind("BackupDestination").GroupKind(),
+		destination.Name, allErrs)
+}
@@ -0,0 +1,180 @@
+package v1
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+	// The manager image has no zoneinfo, so time zones are checked against
+	// the copy embedded in the binary
+	_ "time/tzdata"
+
+	"github.com/robfig/cron/v3"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	zomboidv1 "github.com/zomboidhost/zomboid-operator/api/v1"
+	"github.com/zomboidhost/zomboid-operator/internal/controller"
+)
+
+// log is for logging in this package.
+var zomboidbackupplanlog = logf.Log.WithName("zomboidbackupplan-resource")
+
+// SetupZomboidBackupPlanWebhookWithManager registers the webhook for ZomboidBackupPlan in the manager.
+func SetupZomboidBackupPlanWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).For(&zomboidv1.ZomboidBackupPlan{}).
+		WithValidator(&ZomboidBackupPlanCustomValidator{Client: mgr.GetClient()}).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/validate-zomboid-host-v1-zomboidbackupplan,mutating=false,failurePolicy=fail,sideEffects=None,groups=zomboid.host,resources=zomboidbackupplans,verbs=create;update,versions=v1,name=vzomboidbackupplan-v1.kb.io,admissionReviewVersions=v1
+
+// ZomboidBackupPlanCustomValidator checks a ZomboidBackupPlan's schedule,
+// and that the server it backs up keeps backups to copy.
+type ZomboidBackupPlanCustomValidator struct {
+	Client client.Reader
+}
+
+var _ webhook.CustomValidator = &ZomboidBackupPlanCustomValidator{}
+
+// ValidateCreate implements webhook.CustomValidator so a webhook will be registered for the type ZomboidBackupPlan.
+func (v *ZomboidBackupPlanCustomValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	zomboidbackupplan, ok := obj.(*zomboidv1.ZomboidBackupPlan)
+	if !ok {
+		return nil, fmt.Errorf("expected a ZomboidBackupPlan object but got %T", obj)
+	}
+	zomboidbackupplanlog.Info("Validation for ZomboidBackupPlan upon creation", "name", zomboidbackupplan.GetName())
+
+	return v.validate(ctx, zomboidbackupplan)
+}
+
+// ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type ZomboidBackupPlan.
+func (v *ZomboidBackupPlanCustomValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	zomboidbackupplan, ok := newObj.(*zomboidv1.ZomboidBackupPlan)
+	if !ok {
+		return nil, fmt.Errorf("expected a ZomboidBackupPlan object for the newObj but got %T", newObj)
+	}
+	zomboidbackupplanlog.Info("Validation for ZomboidBackupPlan upon update", "name", zomboidbackupplan.GetName())
+
+	if zomboidbackupplan.DeletionTimestamp != nil {
+		return nil, nil
+	}
+
+	return v.validate(ctx, zomboidbackupplan)
+}
+
+// ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type ZomboidBackupPlan.
+func (v *ZomboidBackupPlanCustomValidator) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func (v *ZomboidBackupPlanCustomValidator) validate(ctx context.Context, backupPlan *zomboidv1.ZomboidBackupPlan) (admission.Warnings, error) {
+	specPath := field.NewPath("spec")
+
+	var warnings admission.Warnings
+	var allErrs field.ErrorList
+	allErrs = append(allErrs, validateSchedule(backupPlan.Spec.Schedule, specPath.Child("schedule"))...)
+	if backupPlan.Spec.TimeZone != nil {
+		if _, err := time.LoadLocation(*backupPlan.Spec.TimeZone); err != nil || *backupPlan.Spec.TimeZone == "" {
+			allErrs = append(allErrs, field.Invalid(specPath.Child("timeZone"), *backupPlan.Spec.TimeZone,
+				"must be an IANA time zone, like Europe/London"))
+		}
+	}
+
+	// The server and destination may well be created after the plan, so
+	// only what's wrong with them once they exist is an error
+	server := &zomboidv1.ZomboidServer{}
+	if err := v.Client.Get(ctx, types.NamespacedName{Name: backupPlan.Spec.Server.Name, Namespace: backupPlan.Namespace}, server); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return nil, fmt.Errorf("failed to get ZomboidServer: %w", err)
+		}
+		warnings = append(warnings, fmt.Sprintf("ZomboidServer %s does not exist yet", backupPlan.Spec.Server.Name))
+	} else if server.Spec.Backups.Request == nil {
+		allErrs = append(allErrs, field.Invalid(specPath.Child("server", "name"), backupPlan.Spec.Server.Name,
+			"ZomboidServer doesn't keep backups; set its spec.backups.request"))
+	}
+
+	destination := &zomboidv1.BackupDestination{}
+	if err := v.Client.Get(ctx, types.NamespacedName{Name: backupPlan.Spec.Destination.Name, Namespace: backupPlan.Namespace}, destination); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return nil, fmt.Errorf("failed to get BackupDestination: %w", err)
+		}
+		warnings = append(warnings, fmt.Sprintf("BackupDestination %s does not exist yet", backupPlan.Spec.Destination.Name))
+	} else {
+		overlapping, err := v.overlappingPlans(ctx, backupPlan, destination)
+		if err != nil {
+			return nil, err
+		}
+		for _, name := range overlapping {
+			warnings = append(warnings, fmt.Sprintf("ZomboidBackupPlan %s also copies backups to %s, so the two will overwrite each other",
+				name, controller.RemotePath(backupPlan, destination)))
+		}
+	}
+
+	if len(allErrs) == 0 {
+		return warnings, nil
+	}
+	return warnings, apierrors.NewInvalid(
+		zomboidv1.GroupVersion.WithKind("ZomboidBackupPlan").GroupKind(),
+		backupPlan.Name, allErrs)
+}
+
+// validateSchedule parses a schedule the way the CronJob controller does,
+// pointing time zones at spec.timeZone, which CronJobs no longer allow in
+// the schedule itself
+func validateSchedule(schedule string, path *field.Path) field.ErrorList {
+	if strings.Contains(schedule, "TZ=") {
+		return field.ErrorList{field.Invalid(path, schedule, "can't contain a time zone; set spec.timeZone instead")}
+	}
+	if _, err := cron.ParseStandard(schedule); err != nil {
+		return field.ErrorList{field.Invalid(path, schedule, err.Error())}
+	}
+	return nil
+}
+
+// overlappingPlans returns the other backup plans in the namespace that copy
+// to the same remote path as backupPlan
+func (v *ZomboidBackupPlanCustomValidator) overlappingPlans(ctx context.Context, backupPlan *zomboidv1.ZomboidBackupPlan, destination *zomboidv1.BackupDestination) ([]string, error) {
+	remotePath := controller.RemotePath(backupPlan, destination)
+	if remotePath == "" {
+		return nil, nil
+	}
+
+	backupPlans := &zomboidv1.ZomboidBackupPlanList{}
+	if err := v.Client.List(ctx, backupPlans, client.InNamespace(backupPlan.Namespace)); err != nil {
+		return nil, fmt.Errorf("failed to list ZomboidBackupPlans: %w", err)
+	}
+
+	destinations := map[string]*zomboidv1.BackupDestination{destination.Name: destination}
+	var overlapping []string
+	for i := range backupPlans.Items {
+		other := &backupPlans.Items[i]
+		if other.Name == backupPlan.Name {
+			continue
+		}
+
+		otherDestination, ok := destinations[other.Spec.Destination.Name]
+		if !ok {
+			otherDestination = &zomboidv1.BackupDestination{}
+			if err := v.Client.Get(ctx, types.NamespacedName{Name: other.Spec.Destination.Name, Namespace: other.Namespace}, otherDestination); err != nil {
+				if !apierrors.IsNotFound(err) {
+					return nil, fmt.Errorf("failed to get BackupDestination: %w", err)
+				}
+				otherDestination = nil
+			}
+			destinations[other.Spec.Destination.Name] = otherDestination
+		}
+
+		if otherDestination != nil && controller.RemotePath(other, otherDestination) == remotePath {
+			overlapping = append(overlapping, other.Name)
+		}
+	}
+	return overlapping, nil
+}
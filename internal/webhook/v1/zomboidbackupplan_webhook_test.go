@@ -0,0 +1,155 @@
+package v1
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	clientfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	zomboidv1 "github.com/zomboidhost/zomboid-operator/api/v1"
+)
+
+var _ = Describe("ZomboidBackupPlan Webhook", func() {
+	var (
+		ctx       context.Context
+		k8sClient client.Client
+		obj       *zomboidv1.ZomboidBackupPlan
+		validator ZomboidBackupPlanCustomValidator
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+
+		scheme := runtime.NewScheme()
+		Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+		Expect(zomboidv1.AddToScheme(scheme)).To(Succeed())
+
+		k8sClient = clientfake.NewClientBuilder().WithScheme(scheme).WithObjects(
+			&zomboidv1.ZomboidServer{
+				ObjectMeta: metav1.ObjectMeta{Name: "with-backups", Namespace: "default"},
+				Spec: zomboidv1.ZomboidServerSpec{
+					Backups: zomboidv1.Backups{Request: ptr.To(resource.MustParse("10Gi"))},
+				},
+			},
+			&zomboidv1.ZomboidServer{
+				ObjectMeta: metav1.ObjectMeta{Name: "without-backups", Namespace: "default"},
+			},
+			&zomboidv1.BackupDestination{
+				ObjectMeta: metav1.ObjectMeta{Name: "dropbox", Namespace: "default"},
+				Spec: zomboidv1.BackupDestinationSpec{
+					Dropbox: &zomboidv1.Dropbox{Path: "/zomboid"},
+				},
+			},
+		).Build()
+		validator = ZomboidBackupPlanCustomValidator{Client: k8sClient}
+
+		obj = &zomboidv1.ZomboidBackupPlan{
+			ObjectMeta: metav1.ObjectMeta{Name: "nightly", Namespace: "default"},
+			Spec: zomboidv1.ZomboidBackupPlanSpec{
+				Server:      corev1.LocalObjectReference{Name: "with-backups"},
+				Destination: corev1.LocalObjectReference{Name: "dropbox"},
+				Schedule:    "0 4 * * *",
+			},
+		}
+	})
+
+	Context("When creating or updating ZomboidBackupPlan under Validating Webhook", func() {
+		It("Should admit schedules the CronJob controller accepts", func() {
+			for _, schedule := range []string{"0 4 * * *", "*/15 * * * *", "0 4 * * MON-FRI", "@daily", "@hourly"} {
+				obj.Spec.Schedule = schedule
+				Expect(validator.ValidateCreate(ctx, obj)).To(BeEmpty(), schedule)
+			}
+		})
+
+		It("Should deny invalid schedules", func() {
+			for _, schedule := range []string{"0 4 * *", "61 * * * *", "@fortnightly", "CRON_TZ=Europe/London 0 4 * * *"} {
+				obj.Spec.Schedule = schedule
+				_, err := validator.ValidateCreate(ctx, obj)
+				Expect(err).To(MatchError(ContainSubstring("spec.schedule")), schedule)
+			}
+		})
+
+		It("Should check the time zone", func() {
+			obj.Spec.TimeZone = ptr.To("Europe/London")
+			Expect(validator.ValidateCreate(ctx, obj)).To(BeEmpty())
+
+			obj.Spec.TimeZone = ptr.To("Europe/Springfield")
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(MatchError(ContainSubstring("spec.timeZone")))
+		})
+
+		It("Should deny servers that don't keep backups", func() {
+			obj.Spec.Server.Name = "without-backups"
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(MatchError(ContainSubstring("spec.backups.request")))
+		})
+
+		It("Should only warn about servers and destinations that don't exist yet", func() {
+			obj.Spec.Server.Name = "missing"
+			obj.Spec.Destination.Name = "missing"
+			warnings, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(warnings).To(HaveLen(2))
+		})
+
+		It("Should warn when two plans copy to the same place", func() {
+			Expect(k8sClient.Create(ctx, &zomboidv1.ZomboidBackupPlan{
+				ObjectMeta: metav1.ObjectMeta{Name: "hourly", Namespace: "default"},
+				Spec: zomboidv1.ZomboidBackupPlanSpec{
+					Server:      corev1.LocalObjectReference{Name: "without-backups"},
+					Destination: corev1.LocalObjectReference{Name: "dropbox"},
+					Schedule:    "@hourly",
+				},
+			})).To(Succeed())
+
+			warnings, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(warnings).To(ConsistOf(ContainSubstring("ZomboidBackupPlan hourly also copies backups to dropbox:zomboid")))
+
+			Expect(validator.ValidateUpdate(ctx, obj, obj)).To(HaveLen(1))
+		})
+	})
+})
+
+var _ = Describe("BackupDestination Webhook", func() {
+	var (
+		ctx       context.Context
+		obj       *zomboidv1.BackupDestination
+		validator BackupDestinationCustomValidator
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		obj = &zomboidv1.BackupDestination{
+			ObjectMeta: metav1.ObjectMeta{Name: "destination", Namespace: "default"},
+		}
+		validator = BackupDestinationCustomValidator{}
+	})
+
+	Context("When creating or updating BackupDestination under Validating Webhook", func() {
+		It("Should require a provider", func() {
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(MatchError(ContainSubstring("one of dropbox, googleDrive or s3 must be set")))
+		})
+
+		It("Should admit exactly one provider", func() {
+			obj.Spec.S3 = &zomboidv1.S3{Provider: "AWS", BucketName: "backups"}
+			Expect(validator.ValidateCreate(ctx, obj)).To(BeEmpty())
+		})
+
+		It("Should deny several providers", func() {
+			obj.Spec.S3 = &zomboidv1.S3{Provider: "AWS", BucketName: "backups"}
+			obj.Spec.Dropbox = &zomboidv1.Dropbox{}
+			_, err := validator.ValidateUpdate(ctx, obj, obj)
+			Expect(err).To(MatchError(ContainSubstring("only one of dropbox, googleDrive or s3 may be set")))
+		})
+	})
+})
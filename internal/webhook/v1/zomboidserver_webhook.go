@@ -0,0 +1,524 @@
+package v1
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	zomboidv1 "github.com/zomboidhost/zomboid-operator/api/v1"
+	zomboidsettings "github.com/zomboidhost/zomboid-operator/internal/settings"
+)
+
+// log is for logging in this package.
+var zomboidserverlog = logf.Log.WithName("zomboidserver-resource")
+
+// SetupZomboidServerWebhookWithManager registers the webhook for ZomboidServer in the manager.
+func SetupZomboidServerWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).For(&zomboidv1.ZomboidServer{}).
+		WithValidator(&ZomboidServerCustomValidator{Client: mgr.GetClient()}).
+		WithDefaulter(&ZomboidServerCustomDefaulter{}).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/mutate-zomboid-host-v1-zomboidserver,mutating=true,failurePolicy=fail,sideEffects=None,groups=zomboid.host,resources=zomboidservers,verbs=create;update,versions=v1,name=mzomboidserver-v1.kb.io,admissionReviewVersions=v1
+
+// Ports the server's pod always uses, which the game ports can't share
+const (
+	rconPort      = 27015
+	ws4sqlitePort = 12321
+	metricsPort   = 9090
+)
+
+// defaultMemory is what a server gets when its spec doesn't say.  The JVM
+// heap is sized from the memory limit, so a server without one can't start.
+var defaultMemory = resource.MustParse("4Gi")
+
+// ZomboidServerCustomDefaulter sets the ports and resources of a
+// ZomboidServer when they're left out.
+type ZomboidServerCustomDefaulter struct{}
+
+var _ webhook.CustomDefaulter = &ZomboidServerCustomDefaulter{}
+
+// Default implements webhook.CustomDefaulter so a webhook will be registered for the Kind ZomboidServer.
+func (d *ZomboidServerCustomDefaulter) Default(ctx context.Context, obj runtime.Object) error {
+	zomboidserver, ok := obj.(*zomboidv1.ZomboidServer)
+	if !ok {
+		return fmt.Errorf("expected an ZomboidServer object but got %T", obj)
+	}
+	zomboidserverlog.Info("Defaulting for ZomboidServer", "name", zomboidserver.GetName())
+
+	spec := &zomboidserver.Spec
+	if spec.ServerPort == nil {
+		spec.ServerPort = ptr.To(int32(16261))
+	}
+	if spec.UDPPort == nil {
+		spec.UDPPort = ptr.To(*spec.ServerPort + 1)
+	}
+
+	// The memory limit and request default to each other, falling back to
+	// defaultMemory, so that the server is sized the same as its heap
+	if spec.Resources.Limits == nil {
+		spec.Resources.Limits = corev1.ResourceList{}
+	}
+	if spec.Resources.Requests == nil {
+		spec.Resources.Requests = corev1.ResourceList{}
+	}
+	if _, ok := spec.Resources.Limits[corev1.ResourceMemory]; !ok {
+		if request, ok := spec.Resources.Requests[corev1.ResourceMemory]; ok {
+			spec.Resources.Limits[corev1.ResourceMemory] = request
+		} else {
+			spec.Resources.Limits[corev1.ResourceMemory] = defaultMemory
+		}
+	}
+	if _, ok := spec.Resources.Requests[corev1.ResourceMemory]; !ok {
+		spec.Resources.Requests[corev1.ResourceMemory] = spec.Resources.Limits[corev1.ResourceMemory]
+	}
+	if _, ok := spec.Resources.Requests[corev1.ResourceCPU]; !ok {
+		spec.Resources.Requests[corev1.ResourceCPU] = resource.MustParse("1")
+	}
+
+	return nil
+}
+
+// +kubebuilder:webhook:path=/validate-zomboid-host-v1-zomboidserver,mutating=false,failurePolicy=fail,sideEffects=None,groups=zomboid.host,resources=zomboidservers,verbs=create;update,versions=v1,name=vzomboidserver-v1.kb.io,admissionReviewVersions=v1
+
+// ZomboidServerCustomValidator catches mistakes in a ZomboidServer that
+// would otherwise only surface once the operator tries to act on them.
+type ZomboidServerCustomValidator struct {
+	Client client.Reader
+}
+
+var _ webhook.CustomValidator = &ZomboidServerCustomValidator{}
+
+// ValidateCreate implements webhook.CustomValidator so a webhook will be registered for the type ZomboidServer.
+func (v *ZomboidServerCustomValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	zomboidserver, ok := obj.(*zomboidv1.ZomboidServer)
+	if !ok {
+		return nil, fmt.Errorf("expected a ZomboidServer object but got %T", obj)
+	}
+	zomboidserverlog.Info("Validation for ZomboidServer upon creation", "name", zomboidserver.GetName())
+
+	return v.validate(ctx, zomboidserver, nil)
+}
+
+// ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type ZomboidServer.
+func (v *ZomboidServerCustomValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	zomboidserver, ok := newObj.(*zomboidv1.ZomboidServer)
+	if !ok {
+		return nil, fmt.Errorf("expected a ZomboidServer object for the newObj but got %T", newObj)
+	}
+	oldZomboidserver, ok := oldObj.(*zomboidv1.ZomboidServer)
+	if !ok {
+		return nil, fmt.Errorf("expected a ZomboidServer object for the oldObj but got %T", oldObj)
+	}
+	zomboidserverlog.Info("Validation for ZomboidServer upon update", "name", zomboidserver.GetName())
+
+	// Let servers being deleted go, even if their spec has since gone bad
+	if zomboidserver.DeletionTimestamp != nil {
+		return nil, nil
+	}
+
+	return v.validate(ctx, zomboidserver, oldZomboidserver)
+}
+
+// ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type ZomboidServer.
+func (v *ZomboidServerCustomValidator) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func (v *ZomboidServerCustomValidator) validate(ctx context.Context, zomboidserver, old *zomboidv1.ZomboidServer) (admission.Warnings, error) {
+	specPath := field.NewPath("spec")
+	spec := &zomboidserver.Spec
+
+	var warnings admission.Warnings
+	var allErrs field.ErrorList
+	allErrs = append(allErrs, validateVersion(spec.Version, specPath.Child("version"))...)
+	allErrs = append(allErrs, validatePorts(spec, specPath)...)
+	allErrs = append(allErrs, validateUsers(spec.Users, specPath.Child("users"))...)
+
+	settingsErrs, settingsWarnings := validateSettings(&spec.Settings, specPath.Child("settings"))
+	allErrs = append(allErrs, settingsErrs...)
+	warnings = append(warnings, settingsWarnings...)
+
+	allErrs = append(allErrs, validateRestarts(spec, specPath)...)
+
+	spawnErrs, spawnWarnings := validateSpawn(spec.Spawn, &spec.Settings, specPath.Child("spawn"))
+	allErrs = append(allErrs, spawnErrs...)
+	warnings = append(warnings, spawnWarnings...)
+
+	if old != nil {
+		allErrs = append(allErrs, validateStorageNotShrunk(spec, &old.Spec, specPath)...)
+	}
+
+	secretErrs, err := v.validateSecrets(ctx, zomboidserver, old, specPath)
+	if err != nil {
+		return warnings, err
+	}
+	allErrs = append(allErrs, secretErrs...)
+
+	if len(allErrs) == 0 {
+		return warnings, nil
+	}
+	return warnings, apierrors.NewInvalid(
+		zomboidv1.GroupVersion.WithKind("ZomboidServer").GroupKind(),
+		zomboidserver.Name, allErrs)
+}
+
+// versionPattern matches the tags zomboidhost/zomboid-server is published
+// with: latest, or a game version like 41.78.16-20241117211036
+var versionPattern = regexp.MustCompile(`^(latest|\d+\.\d+[A-Za-z0-9_.-]*)$`)
+
+// settingNamePattern matches the names of settings in server.ini
+var settingNamePattern = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_]*$`)
+
+func validateVersion(version string, path *field.Path) field.ErrorList {
+	if len(version) > 128 || !versionPattern.MatchString(version) {
+		return field.ErrorList{field.Invalid(path, version,
+			"must be latest or a game version, like 41.78.16-20241117211036")}
+	}
+	return nil
+}
+
+func validatePorts(spec *zomboidv1.ZomboidServerSpec, path *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	reserved := map[int32]string{
+		rconPort:      "RCON",
+		ws4sqlitePort: "ws4sqlite",
+		metricsPort:   "metrics",
+	}
+
+	ports := []struct {
+		path *field.Path
+		port *int32
+	}{
+		{path.Child("serverPort"), spec.ServerPort},
+		{path.Child("udpPort"), spec.UDPPort},
+	}
+	for _, p := range ports {
+		if p.port == nil {
+			continue
+		}
+		if *p.port < 1 || *p.port > 65535 {
+			allErrs = append(allErrs, field.Invalid(p.path, *p.port, "must be between 1 and 65535"))
+		} else if name, ok := reserved[*p.port]; ok {
+			allErrs = append(allErrs, field.Invalid(p.path, *p.port, fmt.Sprintf("is used by the server's %s port", name)))
+		}
+	}
+
+	if spec.ServerPort != nil && spec.UDPPort != nil && *spec.ServerPort == *spec.UDPPort {
+		allErrs = append(allErrs, field.Invalid(path.Child("udpPort"), *spec.UDPPort, "must differ from serverPort"))
+	}
+	return allErrs
+}
+
+func validateUsers(users []zomboidv1.User, path *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	seen := map[string]bool{}
+	for i, user := range users {
+		if seen[user.Username] {
+			allErrs = append(allErrs, field.Duplicate(path.Index(i).Child("username"), user.Username))
+		}
+		seen[user.Username] = true
+	}
+	return allErrs
+}
+
+// validateSettings checks settings the game would refuse through
+// changeoption, or that would misbehave once applied
+func validateSettings(settings *zomboidv1.ZomboidSettings, path *field.Path) (field.ErrorList, admission.Warnings) {
+	var allErrs field.ErrorList
+	var warnings admission.Warnings
+
+	playerPath := path.Child("player")
+	if maxPlayers := settings.Player.MaxPlayers; maxPlayers != nil {
+		if *maxPlayers < 1 || *maxPlayers > 100 {
+			allErrs = append(allErrs, field.Invalid(playerPath.Child("MaxPlayers"), *maxPlayers, "must be between 1 and 100"))
+		} else if *maxPlayers > 32 {
+			warnings = append(warnings, "spec.settings.player.MaxPlayers above 32 may cause poor map streaming and desync")
+		}
+	}
+	if pingLimit := settings.Player.PingLimit; pingLimit != nil && *pingLimit < 100 {
+		allErrs = append(allErrs, field.Invalid(playerPath.Child("PingLimit"), *pingLimit, "must be at least 100, which disables the limit"))
+	}
+
+	if scoreboard := settings.Steam.SteamScoreboard; scoreboard != nil {
+		allErrs = append(allErrs, validateEnum(path.Child("steam", "SteamScoreboard"), *scoreboard, "true", "false", "admin")...)
+	}
+
+	communication := settings.Communication
+	if communication.VoiceMinDistance != nil && communication.VoiceMaxDistance != nil &&
+		*communication.VoiceMinDistance > *communication.VoiceMaxDistance {
+		allErrs = append(allErrs, field.Invalid(path.Child("communication", "VoiceMinDistance"),
+			*communication.VoiceMinDistance, "must not be more than VoiceMaxDistance"))
+	}
+
+	if spawnPoint := settings.Gameplay.SpawnPoint; spawnPoint != nil && !validSpawnPoint(*spawnPoint) {
+		allErrs = append(allErrs, field.Invalid(path.Child("gameplay", "SpawnPoint"), *spawnPoint, "must be x,y,z coordinates"))
+	}
+
+	mapsPath := path.Child("maps")
+	seenMaps := map[string]bool{}
+	for i, mapName := range settings.Maps {
+		if strings.TrimSpace(mapName) == "" || strings.ContainsAny(mapName, ";\"\n") {
+			allErrs = append(allErrs, field.Invalid(mapsPath.Index(i), mapName, "must be the folder of a map under media/maps"))
+		} else if seenMaps[mapName] {
+			allErrs = append(allErrs, field.Duplicate(mapsPath.Index(i), mapName))
+		} else if mapName == zomboidsettings.VanillaMap && i != len(settings.Maps)-1 {
+			warnings = append(warnings, fmt.Sprintf("%s is %s, which is always loaded after the other maps", mapsPath.Index(i), mapName))
+		}
+		seenMaps[mapName] = true
+	}
+
+	modsPath := path.Child("workshopMods")
+	seen := map[string]bool{}
+	loadsAll := map[string]bool{}
+	for i, mod := range settings.WorkshopMods {
+		if mod.ModID != nil && *mod.ModID == "" {
+			allErrs = append(allErrs, field.Invalid(modsPath.Index(i).Child("modID"), "", "must not be empty; leave it unset to load every mod in the workshop item"))
+		} else if mod.ModID != nil && seen[*mod.ModID] {
+			allErrs = append(allErrs, field.Duplicate(modsPath.Index(i).Child("modID"), *mod.ModID))
+		} else if mod.ModID != nil {
+			seen[*mod.ModID] = true
+		}
+
+		if mod.WorkshopID == nil || *mod.WorkshopID == "" {
+			allErrs = append(allErrs, field.Required(modsPath.Index(i).Child("workshopID"), ""))
+		} else if _, err := strconv.ParseUint(*mod.WorkshopID, 10, 64); err != nil {
+			allErrs = append(allErrs, field.Invalid(modsPath.Index(i).Child("workshopID"), *mod.WorkshopID, "must be a numeric Steam Workshop ID"))
+		} else if mod.ModID == nil && loadsAll[*mod.WorkshopID] {
+			allErrs = append(allErrs, field.Duplicate(modsPath.Index(i).Child("workshopID"), *mod.WorkshopID))
+		} else if mod.ModID == nil {
+			loadsAll[*mod.WorkshopID] = true
+		}
+	}
+
+	extraPath := path.Child("extra")
+	for name, value := range settings.Extra {
+		if _, ok := zomboidsettings.LookupSetting(name); ok {
+			allErrs = append(allErrs, field.Forbidden(extraPath.Key(name), "is managed by the operator; set it through its own field instead"))
+		} else if !settingNamePattern.MatchString(name) {
+			allErrs = append(allErrs, field.Invalid(extraPath.Key(name), name, "must be a server.ini setting name"))
+		}
+		if strings.ContainsAny(value, "\"\n") {
+			allErrs = append(allErrs, field.Invalid(extraPath.Key(name), value, "must not contain quotes or newlines"))
+		}
+	}
+
+	return allErrs, warnings
+}
+
+// validateRestarts checks the durations that pace the operator's restarts
+// and the workshop update checks that lead to them
+func validateRestarts(spec *zomboidv1.ZomboidServerSpec, path *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if warning := spec.RestartWarning; warning != nil && warning.Duration < 0 {
+		allErrs = append(allErrs, field.Invalid(path.Child("restartWarning"), warning.Duration.String(), "must not be negative"))
+	}
+	if updates := spec.WorkshopUpdates; updates != nil && updates.Interval != nil && updates.Interval.Duration < time.Minute {
+		allErrs = append(allErrs, field.Invalid(path.Child("workshopUpdates", "interval"), updates.Interval.Duration.String(),
+			"must be at least 1m, to stay within the Steam Web API's rate limits"))
+	}
+	return allErrs
+}
+
+// validateSpawn checks the spawn regions are each read from one place, and
+// warns about regions the server's settings would keep players out of
+func validateSpawn(spawn *zomboidv1.Spawn, settings *zomboidv1.ZomboidSettings, path *field.Path) (field.ErrorList, admission.Warnings) {
+	if spawn == nil {
+		return nil, nil
+	}
+
+	var allErrs field.ErrorList
+	var warnings admission.Warnings
+
+	maps := map[string]bool{}
+	for _, mapName := range zomboidsettings.Maps(*settings) {
+		maps[mapName] = true
+	}
+
+	regionsPath := path.Child("regions")
+	seen := map[string]bool{}
+	for i, region := range spawn.Regions {
+		regionPath := regionsPath.Index(i)
+		if seen[region.Name] {
+			allErrs = append(allErrs, field.Duplicate(regionPath.Child("name"), region.Name))
+		}
+		seen[region.Name] = true
+
+		switch {
+		case region.File != nil && len(region.Points) > 0:
+			allErrs = append(allErrs, field.Forbidden(regionPath.Child("points"), "must not be set with file"))
+		case region.File == nil && len(region.Points) == 0:
+			allErrs = append(allErrs, field.Required(regionPath, "either file or points must be set"))
+		case region.File != nil:
+			if *region.File == "" {
+				allErrs = append(allErrs, field.Invalid(regionPath.Child("file"), *region.File, "must be the path of a spawnpoints.lua"))
+			} else if mapName, ok := spawnPointsMap(*region.File); ok && !maps[mapName] &&
+				!(maps[zomboidsettings.VanillaMap] && slices.Contains(zomboidsettings.VanillaSpawnRegions, mapName)) {
+				warnings = append(warnings, fmt.Sprintf("%s is in the map %q, which the Map setting doesn't load", regionPath.Child("file"), mapName))
+			}
+		}
+
+		for profession, points := range region.Points {
+			if profession == "" {
+				allErrs = append(allErrs, field.Invalid(regionPath.Child("points"), profession, "profession must not be empty"))
+			} else if len(points) == 0 {
+				allErrs = append(allErrs, field.Required(regionPath.Child("points").Key(profession), "must list at least one spawn point"))
+			}
+		}
+	}
+
+	if spawnPoint := settings.Gameplay.SpawnPoint; spawnPoint != nil && validSpawnPoint(*spawnPoint) &&
+		strings.ReplaceAll(*spawnPoint, " ", "") != "0,0,0" {
+		warnings = append(warnings, "spec.settings.gameplay.SpawnPoint spawns every player at the same place, ignoring spec.spawn")
+	}
+
+	return allErrs, warnings
+}
+
+// spawnPointsMap returns the map folder of a spawnpoints.lua under
+// media/maps
+func spawnPointsMap(file string) (string, bool) {
+	rest, ok := strings.CutPrefix(file, "media/maps/")
+	if !ok {
+		return "", false
+	}
+	mapName, _, ok := strings.Cut(rest, "/")
+	return mapName, ok
+}
+
+func validateEnum(path *field.Path, value string, allowed ...string) field.ErrorList {
+	for _, a := range allowed {
+		if value == a {
+			return nil
+		}
+	}
+	return field.ErrorList{field.NotSupported(path, value, allowed)}
+}
+
+func validSpawnPoint(spawnPoint string) bool {
+	coordinates := strings.Split(spawnPoint, ",")
+	if len(coordinates) != 3 {
+		return false
+	}
+	for _, coordinate := range coordinates {
+		if _, err := strconv.Atoi(strings.TrimSpace(coordinate)); err != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// validateStorageNotShrunk blocks smaller storage requests, which
+// PersistentVolumeClaims can't be resized to
+func validateStorageNotShrunk(spec, old *zomboidv1.ZomboidServerSpec, path *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	requests := []struct {
+		path     *field.Path
+		new, old *resource.Quantity
+	}{
+		{path.Child("storage", "request"), &spec.Storage.Request, &old.Storage.Request},
+		{path.Child("storage", "workshopRequest"), spec.Storage.WorkshopRequest, old.Storage.WorkshopRequest},
+		{path.Child("backups", "request"), spec.Backups.Request, old.Backups.Request},
+	}
+	for _, r := range requests {
+		if r.new != nil && r.old != nil && r.new.Cmp(*r.old) < 0 {
+			allErrs = append(allErrs, field.Forbidden(r.path,
+				fmt.Sprintf("can't be shrunk from %s to %s", r.old.String(), r.new.String())))
+		}
+	}
+	return allErrs
+}
+
+// secretReference is where a server's spec references a Secret key
+type secretReference struct {
+	path     *field.Path
+	selector *corev1.SecretKeySelector
+}
+
+// secretReferences lists the Secret keys a server's spec references
+func secretReferences(spec *zomboidv1.ZomboidServerSpec, path *field.Path) []secretReference {
+	references := []secretReference{
+		{path.Child("administrator", "password"), &spec.Administrator.Password},
+		{path.Child("password"), spec.Password},
+	}
+	if spec.RCON != nil {
+		references = append(references, secretReference{path.Child("rcon", "password"), spec.RCON.Password})
+	}
+	for i, user := range spec.Users {
+		references = append(references, secretReference{path.Child("users").Index(i).Child("password"), user.Password})
+	}
+	if spec.Discord != nil {
+		references = append(references,
+			secretReference{path.Child("discord", "DiscordToken"), spec.Discord.DiscordToken},
+			secretReference{path.Child("discord", "DiscordChannel"), spec.Discord.DiscordChannel},
+			secretReference{path.Child("discord", "DiscordChannelID"), spec.Discord.DiscordChannelID},
+		)
+	}
+	return references
+}
+
+// validateSecrets checks that the Secret keys a server references exist.  On
+// update, only references the old spec didn't already make are checked, so
+// that a Secret rotated or deleted since doesn't block unrelated changes.
+func (v *ZomboidServerCustomValidator) validateSecrets(ctx context.Context, zomboidserver, old *zomboidv1.ZomboidServer, path *field.Path) (field.ErrorList, error) {
+	var previous []secretReference
+	if old != nil {
+		previous = secretReferences(&old.Spec, path)
+	}
+
+	var allErrs field.ErrorList
+	secrets := map[string]*corev1.Secret{}
+	for _, ref := range secretReferences(&zomboidserver.Spec, path) {
+		if ref.selector == nil || ptr.Deref(ref.selector.Optional, false) {
+			continue
+		}
+		if slices.ContainsFunc(previous, func(p secretReference) bool {
+			return p.selector != nil && p.selector.Name == ref.selector.Name && p.selector.Key == ref.selector.Key &&
+				!ptr.Deref(p.selector.Optional, false)
+		}) {
+			continue
+		}
+
+		secret, ok := secrets[ref.selector.Name]
+		if !ok {
+			secret = &corev1.Secret{}
+			err := v.Client.Get(ctx, types.NamespacedName{Name: ref.selector.Name, Namespace: zomboidserver.Namespace}, secret)
+			if apierrors.IsNotFound(err) {
+				secret = nil
+			} else if err != nil {
+				return nil, fmt.Errorf("failed to get secret %s: %w", ref.selector.Name, err)
+			}
+			secrets[ref.selector.Name] = secret
+		}
+
+		if secret == nil {
+			allErrs = append(allErrs, field.NotFound(ref.path.Child("name"), ref.selector.Name))
+		} else if _, ok := secret.Data[ref.selector.Key]; !ok {
+			allErrs = append(allErrs, field.Invalid(ref.path.Child("key"), ref.selector.Key,
+				fmt.Sprintf("secret %s has no such key", ref.selector.Name)))
+		}
+	}
+	return allErrs, nil
+}
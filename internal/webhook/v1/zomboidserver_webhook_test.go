@@ -0,0 +1,288 @@
+package v1
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	clientfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	zomboidv1 "github.com/zomboidhost/zomboid-operator/api/v1"
+)
+
+var _ = Describe("ZomboidServer Webhook", func() {
+	var (
+		ctx       context.Context
+		obj       *zomboidv1.ZomboidServer
+		oldObj    *zomboidv1.ZomboidServer
+		validator ZomboidServerCustomValidator
+		defaulter ZomboidServerCustomDefaulter
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+
+		scheme := runtime.NewScheme()
+		Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+		Expect(zomboidv1.AddToScheme(scheme)).To(Succeed())
+
+		validator = ZomboidServerCustomValidator{
+			Client: clientfake.NewClientBuilder().WithScheme(scheme).WithObjects(
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Name: "passwords", Namespace: "default"},
+					Data: map[string][]byte{
+						"admin": []byte("admin-password"),
+						"alice": []byte("alice-password"),
+					},
+				},
+			).Build(),
+		}
+		defaulter = ZomboidServerCustomDefaulter{}
+
+		obj = &zomboidv1.ZomboidServer{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-server", Namespace: "default"},
+			Spec: zomboidv1.ZomboidServerSpec{
+				Version: "41.78.16-20241117211036",
+				Storage: zomboidv1.Storage{Request: resource.MustParse("10Gi")},
+				Administrator: zomboidv1.Administrator{
+					Username: "admin",
+					Password: corev1.SecretKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{Name: "passwords"},
+						Key:                  "admin",
+					},
+				},
+			},
+		}
+		oldObj = obj.DeepCopy()
+	})
+
+	Context("When creating ZomboidServer under Defaulting Webhook", func() {
+		It("Should default the ports and resources", func() {
+			Expect(defaulter.Default(ctx, obj)).To(Succeed())
+
+			Expect(obj.Spec.ServerPort).To(Equal(ptr.To(int32(16261))))
+			Expect(obj.Spec.UDPPort).To(Equal(ptr.To(int32(16262))))
+			Expect(obj.Spec.Resources.Limits.Memory().String()).To(Equal("4Gi"))
+			Expect(obj.Spec.Resources.Requests.Memory().String()).To(Equal("4Gi"))
+			Expect(obj.Spec.Resources.Requests.Cpu().String()).To(Equal("1"))
+		})
+
+		It("Should keep resources and ports that are set", func() {
+			obj.Spec.ServerPort = ptr.To(int32(17000))
+			obj.Spec.Resources.Requests = corev1.ResourceList{
+				corev1.ResourceMemory: resource.MustParse("6Gi"),
+				corev1.ResourceCPU:    resource.MustParse("2"),
+			}
+
+			Expect(defaulter.Default(ctx, obj)).To(Succeed())
+
+			Expect(obj.Spec.UDPPort).To(Equal(ptr.To(int32(17001))))
+			Expect(obj.Spec.Resources.Limits.Memory().String()).To(Equal("6Gi"))
+			Expect(obj.Spec.Resources.Requests.Cpu().String()).To(Equal("2"))
+		})
+	})
+
+	Context("When creating or updating ZomboidServer under Validating Webhook", func() {
+		It("Should admit a valid server", func() {
+			Expect(validator.ValidateCreate(ctx, obj)).To(BeEmpty())
+		})
+
+		It("Should deny unknown versions", func() {
+			obj.Spec.Version = "v41 stable"
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(MatchError(ContainSubstring("spec.version")))
+		})
+
+		It("Should deny conflicting ports", func() {
+			obj.Spec.ServerPort = ptr.To(int32(16261))
+			obj.Spec.UDPPort = ptr.To(int32(16261))
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(MatchError(ContainSubstring("must differ from serverPort")))
+
+			obj.Spec.UDPPort = ptr.To(int32(27015))
+			_, err = validator.ValidateCreate(ctx, obj)
+			Expect(err).To(MatchError(ContainSubstring("RCON")))
+		})
+
+		It("Should deny out of range and unknown settings values", func() {
+			obj.Spec.Settings.Player.MaxPlayers = ptr.To(int32(0))
+			obj.Spec.Settings.Player.PingLimit = ptr.To(int32(50))
+			obj.Spec.Settings.Steam.SteamScoreboard = ptr.To("friends")
+			obj.Spec.Settings.Gameplay.SpawnPoint = ptr.To("10,20")
+
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(MatchError(ContainSubstring("spec.settings.player.MaxPlayers")))
+			Expect(err).To(MatchError(ContainSubstring("spec.settings.player.PingLimit")))
+			Expect(err).To(MatchError(ContainSubstring("spec.settings.steam.SteamScoreboard")))
+			Expect(err).To(MatchError(ContainSubstring("spec.settings.gameplay.SpawnPoint")))
+		})
+
+		It("Should deny extra settings the operator manages or can't apply", func() {
+			obj.Spec.Settings.Extra = map[string]string{
+				"MaxPlayers":          "64",
+				"Not a setting":       "1",
+				"ServerWelcomeBanner": "Say \"hi\"",
+				"BloodSplatLifespan":  "3",
+			}
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(MatchError(ContainSubstring("spec.settings.extra[MaxPlayers]: Forbidden")))
+			Expect(err).To(MatchError(ContainSubstring("spec.settings.extra[Not a setting]")))
+			Expect(err).To(MatchError(ContainSubstring("spec.settings.extra[ServerWelcomeBanner]")))
+			Expect(err).NotTo(MatchError(ContainSubstring("BloodSplatLifespan")))
+		})
+
+		It("Should warn about large servers", func() {
+			obj.Spec.Settings.Player.MaxPlayers = ptr.To(int32(64))
+			warnings, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(warnings).To(ConsistOf(ContainSubstring("MaxPlayers")))
+		})
+
+		It("Should deny malformed workshop mods", func() {
+			obj.Spec.Settings.WorkshopMods = []zomboidv1.WorkshopMod{
+				{ModID: ptr.To("Hydrocraft"), WorkshopID: ptr.To("498441420")},
+				{ModID: ptr.To("Hydrocraft"), WorkshopID: ptr.To("hydrocraft")},
+			}
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(MatchError(ContainSubstring("spec.settings.workshopMods[1].modID")))
+			Expect(err).To(MatchError(ContainSubstring("spec.settings.workshopMods[1].workshopID")))
+		})
+
+		It("Should allow workshop mods without a mod ID, once per workshop item", func() {
+			obj.Spec.Settings.WorkshopMods = []zomboidv1.WorkshopMod{
+				{WorkshopID: ptr.To("2169435993")},
+				{ModID: ptr.To("modoptions"), WorkshopID: ptr.To("2200148440")},
+			}
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).NotTo(HaveOccurred())
+
+			obj.Spec.Settings.WorkshopMods = append(obj.Spec.Settings.WorkshopMods,
+				zomboidv1.WorkshopMod{WorkshopID: ptr.To("2169435993")},
+				zomboidv1.WorkshopMod{ModID: ptr.To(""), WorkshopID: ptr.To("498441420")},
+			)
+			_, err = validator.ValidateCreate(ctx, obj)
+			Expect(err).To(MatchError(ContainSubstring("spec.settings.workshopMods[2].workshopID: Duplicate value")))
+			Expect(err).To(MatchError(ContainSubstring("spec.settings.workshopMods[3].modID: Invalid value")))
+		})
+
+		It("Should deny spawn regions without exactly one source", func() {
+			obj.Spec.Spawn = &zomboidv1.Spawn{Regions: []zomboidv1.SpawnRegion{
+				{Name: "Louisville", File: ptr.To("media/maps/Louisville, KY/spawnpoints.lua"),
+					Points: map[string][]zomboidv1.SpawnPoint{"unemployed": {{WorldX: 40, WorldY: 22, PosX: 67, PosY: 201}}}},
+				{Name: "Nowhere"},
+				{Name: "Nowhere", Points: map[string][]zomboidv1.SpawnPoint{"unemployed": {}}},
+			}}
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(MatchError(ContainSubstring("spec.spawn.regions[0].points: Forbidden")))
+			Expect(err).To(MatchError(ContainSubstring("spec.spawn.regions[1]: Required value")))
+			Expect(err).To(MatchError(ContainSubstring("spec.spawn.regions[2].name: Duplicate value")))
+			Expect(err).To(MatchError(ContainSubstring("spec.spawn.regions[2].points[unemployed]: Required value")))
+		})
+
+		It("Should warn about spawn regions in maps the server doesn't load", func() {
+			obj.Spec.Settings.Map.Map = ptr.To("Bedford Falls;Muldraugh, KY")
+			obj.Spec.Settings.Gameplay.SpawnPoint = ptr.To("10000,10000,0")
+			obj.Spec.Spawn = &zomboidv1.Spawn{Regions: []zomboidv1.SpawnRegion{
+				{Name: "Bedford Falls", File: ptr.To("media/maps/Bedford Falls/spawnpoints.lua")},
+				{Name: "Riverside", File: ptr.To("media/maps/Riverside, KY/spawnpoints.lua")},
+				{Name: "Raven Creek", File: ptr.To("media/maps/RavenCreek/spawnpoints.lua")},
+			}}
+			warnings, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(warnings).To(ConsistOf(
+				ContainSubstring(`"RavenCreek"`),
+				ContainSubstring("SpawnPoint"),
+			))
+		})
+
+		It("Should deny malformed and duplicate maps", func() {
+			obj.Spec.Settings.Maps = []string{"Bedford Falls", "Muldraugh, KY", "RavenCreek;Louisville", "Bedford Falls"}
+			warnings, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(MatchError(ContainSubstring("spec.settings.maps[2]: Invalid value")))
+			Expect(err).To(MatchError(ContainSubstring("spec.settings.maps[3]: Duplicate value")))
+			Expect(warnings).To(ConsistOf(ContainSubstring("spec.settings.maps[1] is Muldraugh, KY")))
+		})
+
+		It("Should deny restart warnings and workshop update intervals out of range", func() {
+			obj.Spec.RestartWarning = &metav1.Duration{Duration: -time.Minute}
+			obj.Spec.WorkshopUpdates = &zomboidv1.WorkshopUpdates{Enabled: true, Interval: &metav1.Duration{Duration: 10 * time.Second}}
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(MatchError(ContainSubstring("spec.restartWarning")))
+			Expect(err).To(MatchError(ContainSubstring("spec.workshopUpdates.interval")))
+		})
+
+		It("Should deny duplicate usernames", func() {
+			obj.Spec.Users = []zomboidv1.User{{Username: "alice"}, {Username: "bob"}, {Username: "alice"}}
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(MatchError(ContainSubstring("spec.users[2].username: Duplicate value")))
+		})
+
+		It("Should deny references to missing secrets", func() {
+			obj.Spec.Users = []zomboidv1.User{
+				{
+					Username: "alice",
+					Password: &corev1.SecretKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{Name: "passwords"},
+						Key:                  "alice",
+					},
+				},
+				{
+					Username: "bob",
+					Password: &corev1.SecretKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{Name: "passwords"},
+						Key:                  "bob",
+					},
+				},
+			}
+			obj.Spec.Password = &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "server-password"},
+				Key:                  "password",
+			}
+
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(MatchError(ContainSubstring("spec.users[1].password.key")))
+			Expect(err).To(MatchError(ContainSubstring("spec.password.name: Not found")))
+			Expect(err).NotTo(MatchError(ContainSubstring("spec.users[0]")))
+
+			obj.Spec.Password.Optional = ptr.To(true)
+			obj.Spec.Users = obj.Spec.Users[:1]
+			Expect(validator.ValidateCreate(ctx, obj)).To(BeEmpty())
+		})
+
+		It("Should only check new secret references on update", func() {
+			missing := &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "deleted-password"},
+				Key:                  "password",
+			}
+			oldObj.Spec.Password = missing.DeepCopy()
+			obj.Spec.Password = missing.DeepCopy()
+			obj.Spec.Settings.Player.MaxPlayers = ptr.To(int32(16))
+			Expect(validator.ValidateUpdate(ctx, oldObj, obj)).To(BeEmpty())
+
+			obj.Spec.Password.Key = "new-password"
+			_, err := validator.ValidateUpdate(ctx, oldObj, obj)
+			Expect(err).To(MatchError(ContainSubstring("spec.password.name: Not found")))
+		})
+
+		It("Should deny shrinking storage", func() {
+			oldObj.Spec.Backups.Request = ptr.To(resource.MustParse("20Gi"))
+			obj.Spec.Storage.Request = resource.MustParse("5Gi")
+			obj.Spec.Backups.Request = ptr.To(resource.MustParse("10Gi"))
+
+			_, err := validator.ValidateUpdate(ctx, oldObj, obj)
+			Expect(err).To(MatchError(ContainSubstring("spec.storage.request")))
+			Expect(err).To(MatchError(ContainSubstring("spec.backups.request")))
+
+			obj.Spec.Storage.Request = resource.MustParse("20Gi")
+			obj.Spec.Backups.Request = ptr.To(resource.MustParse("20Gi"))
+			Expect(validator.ValidateUpdate(ctx, oldObj, obj)).To(BeEmpty())
+		})
+	})
+})
@@ -0,0 +1,87 @@
+package v1
+
+import (
+	"context"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	zomboidv1 "github.com/zomboidhost/zomboid-operator/api/v1"
+)
+
+// log is for logging in this package.
+var zomboidsettingsprofilelog = logf.Log.WithName("zomboidsettingsprofile-resource")
+
+// SetupZomboidSettingsProfileWebhookWithManager registers the webhooks for
+// ZomboidSettingsProfile and ClusterZomboidSettingsProfile in the manager.
+func SetupZomboidSettingsProfileWebhookWithManager(mgr ctrl.Manager) error {
+	if err := ctrl.NewWebhookManagedBy(mgr).For(&zomboidv1.ZomboidSettingsProfile{}).
+		WithValidator(&ZomboidSettingsProfileCustomValidator{}).
+		Complete(); err != nil {
+		return err
+	}
+	return ctrl.NewWebhookManagedBy(mgr).For(&zomboidv1.ClusterZomboidSettingsProfile{}).
+		WithValidator(&ZomboidSettingsProfileCustomValidator{}).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/validate-zomboid-host-v1-zomboidsettingsprofile,mutating=false,failurePolicy=fail,sideEffects=None,groups=zomboid.host,resources=zomboidsettingsprofiles,verbs=create;update,versions=v1,name=vzomboidsettingsprofile-v1.kb.io,admissionReviewVersions=v1
+// +kubebuilder:webhook:path=/validate-zomboid-host-v1-clusterzomboidsettingsprofile,mutating=false,failurePolicy=fail,sideEffects=None,groups=zomboid.host,resources=clusterzomboidsettingsprofiles,verbs=create;update,versions=v1,name=vclusterzomboidsettingsprofile-v1.kb.io,admissionReviewVersions=v1
+
+// ZomboidSettingsProfileCustomValidator checks the settings of a
+// ZomboidSettingsProfile or ClusterZomboidSettingsProfile the same way as
+// those of a ZomboidServer.
+type ZomboidSettingsProfileCustomValidator struct{}
+
+var _ webhook.CustomValidator = &ZomboidSettingsProfileCustomValidator{}
+
+// ValidateCreate implements webhook.CustomValidator so a webhook will be registered for the settings profile types.
+func (v *ZomboidSettingsProfileCustomValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	zomboidsettingsprofilelog.Info("Validation for settings profile upon creation", "kind", obj.GetObjectKind().GroupVersionKind().Kind)
+
+	return validateSettingsProfile(obj)
+}
+
+// ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the settings profile types.
+func (v *ZomboidSettingsProfileCustomValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	zomboidsettingsprofilelog.Info("Validation for settings profile upon update", "kind", newObj.GetObjectKind().GroupVersionKind().Kind)
+
+	if profile, ok := newObj.(client.Object); ok && profile.GetDeletionTimestamp() != nil {
+		return nil, nil
+	}
+
+	return validateSettingsProfile(newObj)
+}
+
+// ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the settings profile types.
+func (v *ZomboidSettingsProfileCustomValidator) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func validateSettingsProfile(obj runtime.Object) (admission.Warnings, error) {
+	var kind, name string
+	var spec *zomboidv1.ZomboidSettingsProfileSpec
+	switch profile := obj.(type) {
+	case *zomboidv1.ZomboidSettingsProfile:
+		kind, name, spec = "ZomboidSettingsProfile", profile.Name, &profile.Spec
+	case *zomboidv1.ClusterZomboidSettingsProfile:
+		kind, name, spec = "ClusterZomboidSettingsProfile", profile.Name, &profile.Spec
+	default:
+		return nil, fmt.Errorf("expected a settings profile object but got %T", obj)
+	}
+
+	allErrs, warnings := validateSettings(&spec.Settings, field.NewPath("spec", "settings"))
+	if len(allErrs) == 0 {
+		return warnings, nil
+	}
+	return warnings, apierrors.NewInvalid(
+		zomboidv1.GroupVersion.WithKind(kind).GroupKind(),
+		name, allErrs)
+}
@@ -0,0 +1,45 @@
+package v1
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	zomboidv1 "github.com/zomboidhost/zomboid-operator/api/v1"
+)
+
+var _ = Describe("ZomboidSettingsProfile Webhook", func() {
+	var (
+		ctx       context.Context
+		validator ZomboidSettingsProfileCustomValidator
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		validator = ZomboidSettingsProfileCustomValidator{}
+	})
+
+	It("Should admit valid profiles of either kind", func() {
+		profile := &zomboidv1.ZomboidSettingsProfile{ObjectMeta: metav1.ObjectMeta{Name: "pvp", Namespace: "default"}}
+		profile.Spec.Settings.PVP.PVP = ptr.To(true)
+		Expect(validator.ValidateCreate(ctx, profile)).Error().NotTo(HaveOccurred())
+
+		clusterProfile := &zomboidv1.ClusterZomboidSettingsProfile{ObjectMeta: metav1.ObjectMeta{Name: "community"}}
+		clusterProfile.Spec.Settings.Player.MaxPlayers = ptr.To(int32(24))
+		Expect(validator.ValidateCreate(ctx, clusterProfile)).Error().NotTo(HaveOccurred())
+	})
+
+	It("Should deny settings a ZomboidServer couldn't have", func() {
+		profile := &zomboidv1.ClusterZomboidSettingsProfile{ObjectMeta: metav1.ObjectMeta{Name: "community"}}
+		profile.Spec.Settings.Player.MaxPlayers = ptr.To(int32(0))
+		profile.Spec.Settings.Extra = map[string]string{"MaxPlayers": "64"}
+
+		_, err := validator.ValidateUpdate(ctx, profile.DeepCopy(), profile)
+		Expect(err).To(MatchError(ContainSubstring("ClusterZomboidSettingsProfile.zomboid.host \"community\" is invalid")))
+		Expect(err).To(MatchError(ContainSubstring("spec.settings.player.MaxPlayers")))
+		Expect(err).To(MatchError(ContainSubstring("spec.settings.extra[MaxPlayers]: Forbidden")))
+	})
+})
@@ -0,0 +1,170 @@
+// Package workshop reads the details of Project Zomboid's Steam Workshop
+// items, both as published on the Steam Workshop and as installed on a server.
+package workshop
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultEndpoint is the Steam Web API method returning the details of
+// published workshop items, which needs no API key
+const DefaultEndpoint = "https://api.steampowered.com/ISteamRemoteStorage/GetPublishedFileDetails/v1/"
+
+// DefaultDetailsEndpoint is the Steam Web API method that also lists the
+// items each workshop item requires, which needs an API key
+const DefaultDetailsEndpoint = "https://api.steampowered.com/IPublishedFileService/GetDetails/v1/"
+
+// Item is a workshop item as published on the Steam Workshop
+type Item struct {
+	WorkshopID string
+	Title      string
+	Size       int64
+	Updated    time.Time
+
+	// RequiredItems are the workshop IDs of the items the item's page lists
+	// as required, only known when the client has an API key
+	RequiredItems []string
+}
+
+// Client looks up workshop items through an endpoint serving the
+// GetPublishedFileDetails method of the Steam Web API.  With an API key, it
+// uses the GetDetails method at DetailsEndpoint instead, to learn the items
+// each one requires.
+type Client struct {
+	Endpoint        string
+	DetailsEndpoint string
+	Key             string
+	HTTPClient      *http.Client
+}
+
+// NewClient returns a client for endpoint, or for DefaultEndpoint if it's empty
+func NewClient(endpoint string) *Client {
+	if endpoint == "" {
+		endpoint = DefaultEndpoint
+	}
+	return &Client{
+		Endpoint:        endpoint,
+		DetailsEndpoint: DefaultDetailsEndpoint,
+		HTTPClient:      &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+type publishedFileDetailsResponse struct {
+	Response struct {
+		PublishedFileDetails []publishedFileDetails `json:"publishedfiledetails"`
+	} `json:"response"`
+}
+
+type publishedFileDetails struct {
+	PublishedFileID string `json:"publishedfileid"`
+	Result          int    `json:"result"`
+	Title           string `json:"title"`
+	FileSize        number `json:"file_size"`
+	TimeUpdated     number `json:"time_updated"`
+	Children        []struct {
+		PublishedFileID string `json:"publishedfileid"`
+	} `json:"children"`
+}
+
+// number is an integer the Steam Web API sends either as a JSON number or as
+// a string
+type number int64
+
+func (n *number) UnmarshalJSON(data []byte) error {
+	value, err := strconv.ParseInt(strings.Trim(string(data), `"`), 10, 64)
+	if err != nil {
+		return err
+	}
+	*n = number(value)
+	return nil
+}
+
+// Items returns the published workshop items with the given IDs, by ID.
+// Items that don't exist or that the Steam Workshop hides are left out.
+func (c *Client) Items(ctx context.Context, workshopIDs []string) (map[string]Item, error) {
+	items := map[string]Item{}
+	if len(workshopIDs) == 0 {
+		return items, nil
+	}
+
+	request, err := c.newRequest(ctx, workshopIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	httpClient := c.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	response, err := httpClient.Do(request)
+	if err != nil {
+		// The URL carries the API key, so it's left out of the error
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			err = fmt.Errorf("%s: %w", urlErr.Op, urlErr.Err)
+		}
+		return nil, fmt.Errorf("failed to get workshop item details: %w", err)
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to get workshop item details: %s", response.Status)
+	}
+
+	var details publishedFileDetailsResponse
+	if err := json.NewDecoder(response.Body).Decode(&details); err != nil {
+		return nil, fmt.Errorf("failed to read workshop item details: %w", err)
+	}
+
+	for _, detail := range details.Response.PublishedFileDetails {
+		// Any other result means the item doesn't exist or isn't visible
+		if detail.Result != 1 {
+			continue
+		}
+		item := Item{
+			WorkshopID: detail.PublishedFileID,
+			Title:      detail.Title,
+			Size:       int64(detail.FileSize),
+			Updated:    time.Unix(int64(detail.TimeUpdated), 0).UTC(),
+		}
+		for _, child := range detail.Children {
+			item.RequiredItems = append(item.RequiredItems, child.PublishedFileID)
+		}
+		items[detail.PublishedFileID] = item
+	}
+	return items, nil
+}
+
+// newRequest asks for the details of workshop items, through GetDetails
+// when the client has an API key and GetPublishedFileDetails otherwise
+func (c *Client) newRequest(ctx context.Context, workshopIDs []string) (*http.Request, error) {
+	if c.Key != "" {
+		endpoint := c.DetailsEndpoint
+		if endpoint == "" {
+			endpoint = DefaultDetailsEndpoint
+		}
+		query := url.Values{"key": {c.Key}, "includechildren": {"true"}}
+		for i, workshopID := range workshopIDs {
+			query.Set(fmt.Sprintf("publishedfileids[%d]", i), workshopID)
+		}
+		return http.NewRequestWithContext(ctx, http.MethodGet, endpoint+"?"+query.Encode(), nil)
+	}
+
+	form := url.Values{"itemcount": {strconv.Itoa(len(workshopIDs))}}
+	for i, workshopID := range workshopIDs {
+		form.Set(fmt.Sprintf("publishedfileids[%d]", i), workshopID)
+	}
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, c.Endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	return request, nil
+}
@@ -0,0 +1,83 @@
+package workshop_test
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/zomboidhost/zomboid-operator/internal/workshop"
+	"github.com/zomboidhost/zomboid-operator/internal/workshop/fake"
+)
+
+var _ = Describe("Client", func() {
+	var (
+		ctx    context.Context
+		server *fake.Server
+		client *workshop.Client
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		server = fake.NewServer()
+		DeferCleanup(server.Close)
+		client = workshop.NewClient(server.URL())
+	})
+
+	It("should return the published items by ID", func() {
+		updated := time.Date(2024, 11, 17, 21, 10, 36, 0, time.UTC)
+		server.SetItem(workshop.Item{WorkshopID: "498441420", Title: "Hydrocraft", Size: 52428800, Updated: updated})
+
+		items, err := client.Items(ctx, []string{"498441420", "1"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(items).To(Equal(map[string]workshop.Item{
+			"498441420": {WorkshopID: "498441420", Title: "Hydrocraft", Size: 52428800, Updated: updated},
+		}))
+	})
+
+	It("should not ask about no items", func() {
+		items, err := client.Items(ctx, nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(items).To(BeEmpty())
+		Expect(server.Requests()).To(BeZero())
+	})
+
+	It("should list the required items through GetDetails with an API key", func() {
+		updated := time.Date(2024, 11, 17, 21, 10, 36, 0, time.UTC)
+		server.SetKey("secret")
+		server.SetItem(workshop.Item{WorkshopID: "2169435993", Title: "Mod Manager", Size: 1024, Updated: updated,
+			RequiredItems: []string{"2200148440"}})
+		client.DetailsEndpoint = server.URL()
+		client.Key = "secret"
+
+		items, err := client.Items(ctx, []string{"2169435993"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(items).To(Equal(map[string]workshop.Item{
+			"2169435993": {WorkshopID: "2169435993", Title: "Mod Manager", Size: 1024, Updated: updated,
+				RequiredItems: []string{"2200148440"}},
+		}))
+
+		client.Key = "wrong"
+		_, err = client.Items(ctx, []string{"2169435993"})
+		Expect(err).To(MatchError(ContainSubstring("403 Forbidden")))
+	})
+
+	It("should report endpoints that fail", func() {
+		client.Endpoint = server.URL() + "/missing"
+		server.Close()
+
+		_, err := client.Items(ctx, []string{"498441420"})
+		Expect(err).To(MatchError(ContainSubstring("failed to get workshop item details")))
+	})
+	It("should keep the API key out of errors", func() {
+		server.SetKey("secret")
+		client.DetailsEndpoint = server.URL()
+		client.Key = "secret"
+		server.Close()
+
+		_, err := client.Items(ctx, []string{"2169435993"})
+		Expect(err).To(MatchError(ContainSubstring("failed to get workshop item details")))
+		Expect(err.Error()).NotTo(ContainSubstring("secret"))
+	})
+})
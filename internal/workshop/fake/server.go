@@ -0,0 +1,135 @@
+// Package fake provides an in-process stand-in for the Steam Web API's
+// GetPublishedFileDetails and GetDetails methods, so that code checking
+// workshop items can be tested without reaching Steam.
+package fake
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+
+	"github.com/zomboidhost/zomboid-operator/internal/workshop"
+)
+
+// Server serves the details of the workshop items it's given, answering
+// like Steam does for items that don't exist.  POST requests are answered as
+// GetPublishedFileDetails, and GET requests, which must carry the server's
+// API key, as GetDetails.
+type Server struct {
+	server *httptest.Server
+
+	mu       sync.Mutex
+	items    map[string]workshop.Item
+	key      string
+	requests int
+}
+
+// NewServer starts a server with no workshop items
+func NewServer() *Server {
+	s := &Server{items: map[string]workshop.Item{}}
+	s.server = httptest.NewServer(http.HandlerFunc(s.serveDetails))
+	return s
+}
+
+// URL is the endpoint to point a workshop.Client at
+func (s *Server) URL() string {
+	return s.server.URL
+}
+
+// Close stops the server
+func (s *Server) Close() {
+	s.server.Close()
+}
+
+// SetItem adds or replaces a workshop item
+func (s *Server) SetItem(item workshop.Item) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.items[item.WorkshopID] = item
+}
+
+// SetKey sets the API key GetDetails requests must carry
+func (s *Server) SetKey(key string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.key = key
+}
+
+// Requests returns how many requests the server has answered
+func (s *Server) Requests() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.requests
+}
+
+func (s *Server) serveDetails(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost && r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var workshopIDs []string
+	withChildren := r.Method == http.MethodGet
+	if withChildren {
+		if s.key == "" || r.Form.Get("key") != s.key {
+			http.Error(w, "Forbidden", http.StatusForbidden)
+			return
+		}
+		for i := 0; r.Form.Has(fmt.Sprintf("publishedfileids[%d]", i)); i++ {
+			workshopIDs = append(workshopIDs, r.Form.Get(fmt.Sprintf("publishedfileids[%d]", i)))
+		}
+	} else {
+		count, err := strconv.Atoi(r.PostForm.Get("itemcount"))
+		if err != nil {
+			http.Error(w, "itemcount is required", http.StatusBadRequest)
+			return
+		}
+		for i := 0; i < count; i++ {
+			workshopIDs = append(workshopIDs, r.PostForm.Get(fmt.Sprintf("publishedfileids[%d]", i)))
+		}
+	}
+	s.requests++
+
+	details := []map[string]any{}
+	for _, workshopID := range workshopIDs {
+		item, ok := s.items[workshopID]
+		if !ok {
+			details = append(details, map[string]any{"publishedfileid": workshopID, "result": 9})
+			continue
+		}
+		detail := map[string]any{
+			"publishedfileid": workshopID,
+			"result":          1,
+			"title":           item.Title,
+			"file_size":       strconv.FormatInt(item.Size, 10),
+			"time_updated":    item.Updated.Unix(),
+		}
+		if withChildren {
+			children := []map[string]any{}
+			for i, child := range item.RequiredItems {
+				children = append(children, map[string]any{"publishedfileid": child, "sortorder": i, "file_type": 0})
+			}
+			detail["children"] = children
+		}
+		details = append(details, detail)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]any{
+		"response": map[string]any{
+			"result":               1,
+			"resultcount":          len(details),
+			"publishedfiledetails": details,
+		},
+	})
+}
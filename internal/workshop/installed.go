@@ -0,0 +1,112 @@
+package workshop
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// InstalledManifest is where SteamCMD records the workshop items installed
+// on a server, in Valve's KeyValues format
+const InstalledManifest = "/server/steamapps/workshop/appworkshop_108600.acf"
+
+// ParseInstalled returns when the installed version of each workshop item in
+// a manifest was published, by workshop ID
+func ParseInstalled(manifest []byte) (map[string]time.Time, error) {
+	root, err := parseKeyValues(string(manifest))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the installed workshop items: %w", err)
+	}
+
+	installed := map[string]time.Time{}
+	appWorkshop, _ := root["AppWorkshop"].(map[string]any)
+	items, _ := appWorkshop["WorkshopItemsInstalled"].(map[string]any)
+	for workshopID, value := range items {
+		item, ok := value.(map[string]any)
+		if !ok {
+			continue
+		}
+		timeUpdated, _ := item["timeupdated"].(string)
+		seconds, err := strconv.ParseInt(timeUpdated, 10, 64)
+		if err != nil {
+			continue
+		}
+		installed[workshopID] = time.Unix(seconds, 0).UTC()
+	}
+	return installed, nil
+}
+
+// parseKeyValues parses Valve's KeyValues text format, where every key is
+// followed by either a quoted value or a block of keys in braces
+func parseKeyValues(data string) (map[string]any, error) {
+	tokens, err := tokenizeKeyValues(data)
+	if err != nil {
+		return nil, err
+	}
+
+	root, rest, err := parseKeyValuesBlock(tokens)
+	if err != nil {
+		return nil, err
+	}
+	if len(rest) > 0 {
+		return nil, fmt.Errorf("unexpected %q", rest[0])
+	}
+	return root, nil
+}
+
+func parseKeyValuesBlock(tokens []string) (map[string]any, []string, error) {
+	block := map[string]any{}
+	for len(tokens) > 0 {
+		key := tokens[0]
+		if key == "}" {
+			return block, tokens, nil
+		}
+		if key == "{" || len(tokens) < 2 {
+			return nil, nil, fmt.Errorf("expected a key and value at %q", key)
+		}
+
+		if tokens[1] != "{" {
+			block[strings.Trim(key, `"`)] = strings.Trim(tokens[1], `"`)
+			tokens = tokens[2:]
+			continue
+		}
+
+		child, rest, err := parseKeyValuesBlock(tokens[2:])
+		if err != nil {
+			return nil, nil, err
+		}
+		if len(rest) == 0 {
+			return nil, nil, fmt.Errorf("unclosed block %s", key)
+		}
+		block[strings.Trim(key, `"`)] = child
+		tokens = rest[1:]
+	}
+	return block, nil, nil
+}
+
+// tokenizeKeyValues splits KeyValues text into braces and quoted strings,
+// keeping the quotes so that a quoted brace isn't taken for a block
+func tokenizeKeyValues(data string) ([]string, error) {
+	var tokens []string
+	for i := 0; i < len(data); {
+		switch c := data[i]; {
+		case unicode.IsSpace(rune(c)):
+			i++
+		case c == '{' || c == '}':
+			tokens = append(tokens, string(c))
+			i++
+		case c == '"':
+			end := strings.IndexByte(data[i+1:], '"')
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated string at offset %d", i)
+			}
+			tokens = append(tokens, data[i:i+end+2])
+			i += end + 2
+		default:
+			return nil, fmt.Errorf("unexpected %q at offset %d", c, i)
+		}
+	}
+	return tokens, nil
+}
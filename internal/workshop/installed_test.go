@@ -0,0 +1,60 @@
+package workshop_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/zomboidhost/zomboid-operator/internal/workshop"
+)
+
+const sampleManifest = `"AppWorkshop"
+{
+	"appid"		"108600"
+	"SizeOnDisk"		"52431000"
+	"NeedsUpdate"		"0"
+	"NeedsDownload"		"0"
+	"TimeLastUpdated"		"1731877836"
+	"WorkshopItemsInstalled"
+	{
+		"498441420"
+		{
+			"size"		"52428800"
+			"timeupdated"		"1731877836"
+			"manifest"		"5938466452170911112"
+		}
+		"2313387159"
+		{
+			"size"		"2200"
+			"timeupdated"		"1600000000"
+			"manifest"		"{not a block}"
+		}
+	}
+	"WorkshopItemDetails"
+	{
+	}
+}
+`
+
+var _ = Describe("Installed workshop items", func() {
+	It("should read when each installed item was published", func() {
+		installed, err := workshop.ParseInstalled([]byte(sampleManifest))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(installed).To(Equal(map[string]time.Time{
+			"498441420":  time.Unix(1731877836, 0).UTC(),
+			"2313387159": time.Unix(1600000000, 0).UTC(),
+		}))
+	})
+
+	It("should treat a missing manifest as no items", func() {
+		installed, err := workshop.ParseInstalled(nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(installed).To(BeEmpty())
+	})
+
+	It("should reject manifests it can't read", func() {
+		_, err := workshop.ParseInstalled([]byte(`"AppWorkshop" { "appid" `))
+		Expect(err).To(HaveOccurred())
+	})
+})
@@ -0,0 +1,89 @@
+package workshop
+
+import (
+	"bufio"
+	"bytes"
+	"strings"
+)
+
+// ContentDir is where the server downloads Project Zomboid's workshop items
+const ContentDir = "/server/steamapps/workshop/content/108600"
+
+// modInfoHeader starts each mod.info printed by ListModInfos, followed by
+// its path
+const modInfoHeader = "==> "
+
+// ListModInfos prints every mod.info in the downloaded workshop items, each
+// after a line with its path.  Items lay their mods out as
+// <item>/mods/<mod>/mod.info, or in a folder per game version below <mod>.
+var ListModInfos = []string{"/bin/sh", "-c",
+	"find " + ContentDir + " -mindepth 4 -maxdepth 5 -name mod.info -path '*/mods/*' " +
+		`-exec sh -c 'for f; do echo "` + modInfoHeader + `$f"; cat "$f"; echo; done' sh {} + 2>/dev/null || true`}
+
+// ModInfo is a mod as described by its mod.info
+type ModInfo struct {
+	// ID is the ID the server loads the mod by
+	ID string
+	// Name is the mod's display name
+	Name string
+	// Requires are the IDs of the mods it needs loaded with it
+	Requires []string
+}
+
+// ParseModInfos reads the output of ListModInfos into the mods of each
+// downloaded workshop item, by workshop ID.  A mod with a folder per game
+// version is only listed once.
+func ParseModInfos(output []byte) map[string][]ModInfo {
+	mods := map[string][]ModInfo{}
+	var workshopID string
+	var mod *ModInfo
+
+	flush := func() {
+		if mod == nil || mod.ID == "" {
+			return
+		}
+		for _, existing := range mods[workshopID] {
+			if existing.ID == mod.ID {
+				return
+			}
+		}
+		mods[workshopID] = append(mods[workshopID], *mod)
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(output))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if path, ok := strings.CutPrefix(line, modInfoHeader); ok {
+			flush()
+			mod = nil
+			if relative, ok := strings.CutPrefix(path, ContentDir+"/"); ok {
+				workshopID, _, _ = strings.Cut(relative, "/")
+				mod = &ModInfo{}
+			}
+			continue
+		}
+		if mod == nil {
+			continue
+		}
+
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			continue
+		}
+		value = strings.TrimSpace(value)
+		switch strings.ToLower(strings.TrimSpace(key)) {
+		case "id":
+			mod.ID = strings.TrimPrefix(value, `\`)
+		case "name":
+			mod.Name = value
+		case "require":
+			for _, required := range strings.Split(value, ",") {
+				if required = strings.TrimPrefix(strings.TrimSpace(required), `\`); required != "" {
+					mod.Requires = append(mod.Requires, required)
+				}
+			}
+		}
+	}
+	flush()
+	return mods
+}
@@ -0,0 +1,58 @@
+package workshop_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/zomboidhost/zomboid-operator/internal/workshop"
+)
+
+var _ = Describe("ParseModInfos", func() {
+	It("should read the mods of each downloaded item", func() {
+		output := "==> " + workshop.ContentDir + "/2200148440/mods/modoptions/mod.info\n" +
+			"name=Mod Options\n" +
+			"id=modoptions\n" +
+			"description=Lets mods add options\n" +
+			"\n" +
+			"==> " + workshop.ContentDir + "/2169435993/mods/ModManager/mod.info\n" +
+			"name=Mod Manager\n" +
+			"id=ModManager\n" +
+			"\n" +
+			"==> " + workshop.ContentDir + "/2169435993/mods/ModManagerServer/mod.info\n" +
+			"name=Mod Manager: Server\n" +
+			"id=ModManagerServer\n" +
+			"require=ModManager, modoptions\n"
+
+		Expect(workshop.ParseModInfos([]byte(output))).To(Equal(map[string][]workshop.ModInfo{
+			"2200148440": {{ID: "modoptions", Name: "Mod Options"}},
+			"2169435993": {
+				{ID: "ModManager", Name: "Mod Manager"},
+				{ID: "ModManagerServer", Name: "Mod Manager: Server", Requires: []string{"ModManager", "modoptions"}},
+			},
+		}))
+	})
+
+	It("should list mods with a folder per game version once", func() {
+		output := "==> " + workshop.ContentDir + "/3387071946/mods/Bandits/42/mod.info\n" +
+			"name=Bandits\n" +
+			"id=\\Bandits\n" +
+			"require=\\BanditsCore\n" +
+			"==> " + workshop.ContentDir + "/3387071946/mods/Bandits/common/mod.info\n" +
+			"name=Bandits\n" +
+			"id=\\Bandits\n"
+
+		Expect(workshop.ParseModInfos([]byte(output))).To(Equal(map[string][]workshop.ModInfo{
+			"3387071946": {{ID: "Bandits", Name: "Bandits", Requires: []string{"BanditsCore"}}},
+		}))
+	})
+
+	It("should skip mod.info files without an ID or outside the workshop content", func() {
+		output := "==> " + workshop.ContentDir + "/2200148440/mods/broken/mod.info\n" +
+			"name=Broken\n" +
+			"==> /tmp/mod.info\n" +
+			"id=Elsewhere\n"
+
+		Expect(workshop.ParseModInfos([]byte(output))).To(BeEmpty())
+		Expect(workshop.ParseModInfos(nil)).To(BeEmpty())
+	})
+})